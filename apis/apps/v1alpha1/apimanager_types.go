@@ -18,7 +18,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
@@ -43,6 +46,42 @@ const (
 	Default3scaleAppLabel       = "3scale-api-management"
 )
 
+const (
+	// DatabaseCredentialsRotationAnnotation triggers a rotation of the
+	// internal database credentials every time its value changes
+	DatabaseCredentialsRotationAnnotation = "apps.3scale.net/rotate-database-credentials"
+	// DatabaseCredentialsRevisionAnnotation is set on the rotated secrets and on
+	// the pod templates of the rolled out DeploymentConfigs
+	DatabaseCredentialsRevisionAnnotation = "apps.3scale.net/database-credentials-revision"
+)
+
+const (
+	// UpgradeRetryAnnotation retries a failed upgrade every time its value
+	// changes
+	UpgradeRetryAnnotation = "apps.3scale.net/retry-upgrade"
+	// PreUpgradeBackupLabel is set in the APIManagerBackups created before an
+	// upgrade. Its value is the name of the APIManager
+	PreUpgradeBackupLabel = "apps.3scale.net/pre-upgrade-backup"
+	// PreviousImageRestoredAnnotation is set on the DeploymentConfigs whose
+	// previous image was restored by a failed upgrade. Their image change
+	// trigger is paused until the upgrade is retried
+	PreviousImageRestoredAnnotation = "apps.3scale.net/previous-image-restored"
+)
+
+const (
+	// MaintenanceReplicasAnnotation stores the replicas a DeploymentConfig had
+	// before being scaled down by the maintenance mode
+	MaintenanceReplicasAnnotation = "apps.3scale.net/maintenance-replicas"
+)
+
+const (
+	// SystemDatabaseRestorePendingAnnotation is set by the APIManagerRestore
+	// controller on the restored APIManager. While it is present only the
+	// internal databases are reconciled, so the system database dump can be
+	// loaded before system starts
+	SystemDatabaseRestorePendingAnnotation = "apps.3scale.net/system-database-restore-pending"
+)
+
 const (
 	defaultTenantName                  = "3scale"
 	defaultImageStreamImportInsecure   = false
@@ -61,6 +100,16 @@ const (
 	DefaultHTTPSPort int32 = 8443
 )
 
+const (
+	DefaultUpgradeAvailabilityTimeout = 30 * time.Minute
+)
+
+const (
+	DefaultApicastCanaryWeight           int32 = 10
+	DefaultApicastCanaryAnalysisDuration       = 10 * time.Minute
+	DefaultApicastCanaryMaxErrorRate     int32 = 5
+)
+
 // APIManagerSpec defines the desired state of APIManager
 type APIManagerSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -81,6 +130,14 @@ type APIManagerSpec struct {
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// +optional
 	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// +optional
+	DatabaseCredentialsRotation *DatabaseCredentialsRotationSpec `json:"databaseCredentialsRotation,omitempty"`
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// +optional
+	UpgradePolicy *UpgradePolicySpec `json:"upgradePolicy,omitempty"`
+	// +optional
+	UpgradeApproval *UpgradeApprovalSpec `json:"upgradeApproval,omitempty"`
 }
 
 // APIManagerStatus defines the observed state of APIManager
@@ -98,6 +155,158 @@ type APIManagerStatus struct {
 	// APIManager Deployment Configs
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployments",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// Replica counts of the APIManager Deployment Configs
+	// +optional
+	DeploymentReplicas []DeploymentConfigReplicasStatus `json:"deploymentReplicas,omitempty"`
+
+	// 3scale release running when the APIManager was last available
+	// +optional
+	ThreescaleVersion string `json:"threescaleVersion,omitempty"`
+
+	// 3scale release the APIManager can be upgraded to once approved
+	// +optional
+	AvailableThreescaleVersion string `json:"availableThreescaleVersion,omitempty"`
+
+	// Internal database credentials rotation state
+	// +optional
+	DatabaseCredentialsRotation *DatabaseCredentialsRotationStatus `json:"databaseCredentialsRotation,omitempty"`
+
+	// Progress of the last upgrade of the 3scale release
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// State of the last apicast-production canary rollout
+	// +optional
+	ApicastCanary *ApicastCanaryStatus `json:"apicastCanary,omitempty"`
+
+	// Images imported by the APIManager ImageStreams
+	// +optional
+	Images []ImageStatus `json:"images,omitempty"`
+}
+
+type ImageStatus struct {
+	// ImageStream tag, in <imagestream>:<tag> form
+	Name string `json:"name"`
+	// Image the tag is imported from
+	Image string `json:"image"`
+	// Digest the image has been resolved to. Empty until the image is imported
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+type DeploymentConfigReplicasStatus struct {
+	// Deployment Config name
+	Name string `json:"name"`
+	// Desired replicas
+	Replicas int32 `json:"replicas"`
+	// Replicas running the latest pod template
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Ready replicas
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// Available replicas
+	AvailableReplicas int32 `json:"availableReplicas"`
+	// Unavailable replicas
+	UnavailableReplicas int32 `json:"unavailableReplicas"`
+}
+
+type DatabaseCredentialsRotationStatus struct {
+	// Value of the rotation annotation that triggered the last rotation
+	// +optional
+	ObservedTrigger string `json:"observedTrigger,omitempty"`
+	// Revision of the rotation in progress or last completed
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
+	// Time when the last rotation was completed
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+type UpgradeStatus struct {
+	// 3scale release the APIManager is upgraded from
+	FromVersion string `json:"fromVersion"`
+	// 3scale release the APIManager is upgraded to
+	ToVersion string `json:"toVersion"`
+	// Version of the operator performing the upgrade
+	OperatorVersion string `json:"operatorVersion"`
+	// Upgrade paths applied in order, in <from>-><to> form
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+	// Upgrade step being applied, in <path>/<step> form
+	// +optional
+	CurrentStep string `json:"currentStep,omitempty"`
+	// Upgrade steps already applied, in <path>/<step> form
+	// +optional
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+	// Time when the upgrade was started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time when the upgrade was completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Value of the retry-upgrade annotation when the upgrade was started
+	// +optional
+	ObservedRetryTrigger string `json:"observedRetryTrigger,omitempty"`
+	// Name of the APIManagerBackup created before the upgrade
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+	// Images the DeploymentConfigs were deployed from before the upgrade.
+	// They are restored when the upgrade is rolled back
+	// +optional
+	PreviousImages []DeploymentConfigImageStatus `json:"previousImages,omitempty"`
+	// Time by which the upgraded components have to be available
+	// +optional
+	AvailabilityDeadline *metav1.Time `json:"availabilityDeadline,omitempty"`
+}
+
+const (
+	ApicastCanaryPhaseProgressing = "Progressing"
+	ApicastCanaryPhasePromoted    = "Promoted"
+	ApicastCanaryPhaseRolledBack  = "RolledBack"
+)
+
+type ApicastCanaryStatus struct {
+	// Progressing, Promoted or RolledBack
+	Phase string `json:"phase"`
+	// Hash of the apicast-production pod template rolled out by the canary
+	Revision string `json:"revision"`
+	// Image of the canary
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Time when the canary was started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time when the canary was promoted or rolled back
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Reason of the rollback
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Response counters of the ready canary pods at the last analysis. The
+	// error rate is computed from the responses served since then
+	// +optional
+	Samples []ApicastCanaryPodSample `json:"samples,omitempty"`
+}
+
+type ApicastCanaryPodSample struct {
+	// Canary pod name
+	Pod string `json:"pod"`
+	// Responses counted by the apicast_status metric of the pod
+	Responses int64 `json:"responses"`
+	// 5xx responses counted by the apicast_status metric of the pod
+	ErrorResponses int64 `json:"errorResponses"`
+}
+
+type DeploymentConfigImageStatus struct {
+	// Deployment Config name
+	Name string `json:"name"`
+	// ImageStreamTag the Deployment Config is deployed from
+	ImageStreamTag string `json:"imageStreamTag"`
+	// Image the Deployment Config is running, as resolved by its image change
+	// trigger. Tags are not renamed within a release, so the tag alone does
+	// not identify the image
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) bool {
@@ -118,6 +327,46 @@ func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) b
 		return false
 	}
 
+	if !reflect.DeepEqual(s.DeploymentReplicas, other.DeploymentReplicas) {
+		diff := cmp.Diff(s.DeploymentReplicas, other.DeploymentReplicas)
+		logger.V(1).Info("DeploymentReplicas not equal", "difference", diff)
+		return false
+	}
+
+	if s.ThreescaleVersion != other.ThreescaleVersion {
+		logger.V(1).Info("ThreescaleVersion not equal", "current", s.ThreescaleVersion, "other", other.ThreescaleVersion)
+		return false
+	}
+
+	if s.AvailableThreescaleVersion != other.AvailableThreescaleVersion {
+		logger.V(1).Info("AvailableThreescaleVersion not equal", "current", s.AvailableThreescaleVersion, "other", other.AvailableThreescaleVersion)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.DatabaseCredentialsRotation, other.DatabaseCredentialsRotation) {
+		diff := cmp.Diff(s.DatabaseCredentialsRotation, other.DatabaseCredentialsRotation)
+		logger.V(1).Info("DatabaseCredentialsRotation not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Upgrade, other.Upgrade) {
+		diff := cmp.Diff(s.Upgrade, other.Upgrade)
+		logger.V(1).Info("Upgrade not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.ApicastCanary, other.ApicastCanary) {
+		diff := cmp.Diff(s.ApicastCanary, other.ApicastCanary)
+		logger.V(1).Info("ApicastCanary not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Images, other.Images) {
+		diff := cmp.Diff(s.Images, other.Images)
+		logger.V(1).Info("Images not equal", "difference", diff)
+		return false
+	}
+
 	return true
 }
 
@@ -142,6 +391,65 @@ type APIManager struct {
 
 const (
 	APIManagerAvailableConditionType common.ConditionType = "Available"
+
+	// Per component health conditions
+	APIManagerApicastStagingAvailableConditionType    common.ConditionType = "ApicastStagingAvailable"
+	APIManagerApicastProductionAvailableConditionType common.ConditionType = "ApicastProductionAvailable"
+	APIManagerBackendAvailableConditionType           common.ConditionType = "BackendAvailable"
+	APIManagerSystemAvailableConditionType            common.ConditionType = "SystemAvailable"
+	APIManagerZyncAvailableConditionType              common.ConditionType = "ZyncAvailable"
+	APIManagerDatabasesAvailableConditionType         common.ConditionType = "DatabasesAvailable"
+	APIManagerRoutesReadyConditionType                common.ConditionType = "RoutesReady"
+
+	// APIManagerPausedConditionType is true while the reconciliation of the
+	// 3scale components is paused by the maintenance mode
+	APIManagerPausedConditionType common.ConditionType = "Paused"
+	// APIManagerDatabaseCredentialsRotatingConditionType is true while the
+	// internal database credentials are being rotated
+	APIManagerDatabaseCredentialsRotatingConditionType common.ConditionType = "DatabaseCredentialsRotating"
+	// APIManagerUpgradingConditionType is true while the 3scale release is
+	// being upgraded
+	APIManagerUpgradingConditionType common.ConditionType = "Upgrading"
+	// APIManagerUpgradeFailedConditionType is true when the last upgrade has
+	// failed. The upgrade is not retried until requested
+	APIManagerUpgradeFailedConditionType common.ConditionType = "UpgradeFailed"
+	// APIManagerUpgradeAvailableConditionType is true while an upgrade of the
+	// 3scale release waits for approval
+	APIManagerUpgradeAvailableConditionType common.ConditionType = "UpgradeAvailable"
+)
+
+const (
+	DeploymentsAvailableReason   common.ConditionReason = "DeploymentsAvailable"
+	DeploymentsMissingReason     common.ConditionReason = "DeploymentsMissing"
+	DeploymentsUnavailableReason common.ConditionReason = "DeploymentsUnavailable"
+	RoutesAdmittedReason         common.ConditionReason = "RoutesAdmitted"
+	RoutesMissingReason          common.ConditionReason = "RoutesMissing"
+	RoutesNotAdmittedReason      common.ConditionReason = "RoutesNotAdmitted"
+)
+
+const (
+	ReconciliationActiveReason common.ConditionReason = "ReconciliationActive"
+	ReconciliationPausedReason common.ConditionReason = "ReconciliationPaused"
+	ComponentsScaledDownReason common.ConditionReason = "ComponentsScaledDown"
+)
+
+const (
+	DatabaseCredentialsRotationStartedReason   common.ConditionReason = "RotationStarted"
+	DatabaseCredentialsSecretsRotatedReason    common.ConditionReason = "SecretsRotated"
+	DatabaseCredentialsDatabaseRolloutReason   common.ConditionReason = "DatabaseRollout"
+	DatabaseCredentialsDependentsRolloutReason common.ConditionReason = "DependentsRollout"
+	DatabaseCredentialsRotationCompletedReason common.ConditionReason = "RotationCompleted"
+)
+
+const (
+	UpgradePathNotFoundReason          common.ConditionReason = "UpgradePathNotFound"
+	UpgradePreflightChecksFailedReason common.ConditionReason = "PreflightChecksFailed"
+	UpgradeInProgressReason            common.ConditionReason = "UpgradeInProgress"
+	UpgradeCompletedReason             common.ConditionReason = "UpgradeCompleted"
+	UpgradeFailedReason                common.ConditionReason = "UpgradeFailed"
+	PreUpgradeBackupFailedReason       common.ConditionReason = "PreUpgradeBackupFailed"
+	ComponentsUnavailableReason        common.ConditionReason = "ComponentsUnavailable"
+	UpgradeApprovalRequiredReason      common.ConditionReason = "UpgradeApprovalRequired"
 )
 
 type APIManagerCommonSpec struct {
@@ -158,6 +466,8 @@ type APIManagerCommonSpec struct {
 	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// +optional
+	ImageRegistry *ImageRegistrySpec `json:"imageRegistry,omitempty"`
 }
 
 // CustomEnvironmentSpec contains or has reference to an APIcast custom environment
@@ -194,6 +504,10 @@ type ApicastSpec struct {
 	ProductionSpec *ApicastProductionSpec `json:"productionSpec,omitempty"`
 	// +optional
 	StagingSpec *ApicastStagingSpec `json:"stagingSpec,omitempty"`
+	// Gateways specifies additional named APIcast gateways. Each gateway
+	// is deployed as a separate DeploymentConfig and Service named apicast-<name>
+	// +optional
+	Gateways []ApicastGatewaySpec `json:"gateways,omitempty"`
 }
 
 type ApicastProductionSpec struct {
@@ -218,6 +532,10 @@ type ApicastProductionSpec struct {
 	// with APIcast in the production environment.
 	// +optional
 	OpenTracing *APIcastOpenTracingSpec `json:"openTracing,omitempty"`
+	// OpenTelemetry contains the OpenTelemetry integration configuration
+	// with APIcast in the production environment.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
 	// CustomEnvironments specifies an array of defined custom environments to be loaded
 	// +optional
 	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
@@ -252,6 +570,124 @@ type ApicastProductionSpec struct {
 	// * character, which matches all hosts, effectively disables the proxy.
 	// +optional
 	NoProxy *string `json:"noProxy,omitempty"` // NO_PROXY
+	// Canary rolls out the changes of the apicast-production pods to a
+	// canary deployment first
+	// +optional
+	Canary *ApicastCanarySpec `json:"canary,omitempty"`
+}
+
+// ApicastCanarySpec configures the canary rollout of apicast-production. The
+// canary pods are added to the apicast-production Service, analyzed and then
+// promoted or rolled back
+type ApicastCanarySpec struct {
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Percentage of the apicast-production traffic routed to the canary.
+	// The Service balances traffic across pods, so the weight is
+	// approximated with the canary replicas. Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+	// Time the canary has to be healthy before being promoted. Defaults to 10m
+	// +optional
+	AnalysisDuration *metav1.Duration `json:"analysisDuration,omitempty"`
+	// Maximum percentage of 5xx responses served by the canary.
+	// Defaults to 5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxErrorRate *int32 `json:"maxErrorRate,omitempty"`
+}
+
+const (
+	ApicastGatewayEnvironmentStaging    = "staging"
+	ApicastGatewayEnvironmentProduction = "production"
+
+	// apicastProductionCanaryGatewayName would clash with the
+	// apicast-production-canary DeploymentConfig
+	apicastProductionCanaryGatewayName = "production-canary"
+)
+
+// ApicastGatewaySpec defines an additional APIcast gateway
+type ApicastGatewaySpec struct {
+	// Name of the gateway. It is used to name the gateway DeploymentConfig and Service as apicast-<name>
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// Environment specifies the 3scale environment the gateway loads the configuration from
+	// +kubebuilder:validation:Enum=staging;production
+	Environment string `json:"environment"`
+	// PortalEndpointSecretRef references a secret holding the `THREESCALE_PORTAL_ENDPOINT` key.
+	// Allows the gateway to load the configuration of a specific tenant.
+	// If not set, the gateway loads the configuration of all tenants from the master account.
+	// +optional
+	PortalEndpointSecretRef *v1.LocalObjectReference `json:"portalEndpointSecretRef,omitempty"` // THREESCALE_PORTAL_ENDPOINT
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// Workers can only be set for gateways in the production environment
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit;alert;emerg
+	LogLevel *string `json:"logLevel,omitempty"` // APICAST_LOG_LEVEL
+	// CustomPolicies specifies an array of defined custome policies to be loaded
+	// +optional
+	CustomPolicies []CustomPolicySpec `json:"customPolicies,omitempty"`
+	// OpenTracing contains the OpenTracing integration configuration
+	// with the APIcast gateway.
+	// +optional
+	OpenTracing *APIcastOpenTracingSpec `json:"openTracing,omitempty"`
+	// CustomEnvironments specifies an array of defined custom environments to be loaded
+	// +optional
+	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
+	// HttpsPort controls on which port APIcast should start listening for HTTPS connections.
+	// If this clashes with HTTP port it will be used only for HTTPS.
+	// Enable TLS at APIcast pod level setting either `httpsPort` or `httpsCertificateSecretRef` fields or both.
+	// +optional
+	HTTPSPort *int32 `json:"httpsPort,omitempty"` // APICAST_HTTPS_PORT
+	// HTTPSVerifyDepth defines the maximum length of the client certificate chain.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HTTPSVerifyDepth *int64 `json:"httpsVerifyDepth,omitempty"` // APICAST_HTTPS_VERIFY_DEPTH
+	// HTTPSCertificateSecretRef references secret containing the X.509 certificate in the PEM format and the X.509 certificate secret key.
+	// Enable TLS at APIcast pod level setting either `httpsPort` or `httpsCertificateSecretRef` fields or both.
+	// +optional
+	HTTPSCertificateSecretRef *v1.LocalObjectReference `json:"httpsCertificateSecretRef,omitempty"`
+	// AllProxy specifies a HTTP(S) proxy to be used for connecting to services if
+	// a protocol-specific proxy is not specified. Authentication is not supported.
+	// Format is <scheme>://<host>:<port>
+	// +optional
+	AllProxy *string `json:"allProxy,omitempty"` // ALL_PROXY
+	// HTTPProxy specifies a HTTP(S) Proxy to be used for connecting to HTTP services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"` // HTTP_PROXY
+	// HTTPSProxy specifies a HTTP(S) Proxy to be used for connecting to HTTPS services.
+	// Authentication is not supported. Format is <scheme>://<host>:<port>
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"` // HTTPS_PROXY
+	// NoProxy specifies a comma-separated list of hostnames and domain
+	// names for which the requests should not be proxied. Setting to a single
+	// * character, which matches all hosts, effectively disables the proxy.
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"` // NO_PROXY
+}
+
+// DeploymentConfigName returns the name of the gateway DeploymentConfig
+func (g *ApicastGatewaySpec) DeploymentConfigName() string {
+	return component.ApicastGatewayName(g.Name)
+}
+
+func (g *ApicastGatewaySpec) IsOpenTracingEnabled() bool {
+	return g.OpenTracing != nil && g.OpenTracing.Enabled != nil && *g.OpenTracing.Enabled
 }
 
 type ApicastStagingSpec struct {
@@ -273,6 +709,10 @@ type ApicastStagingSpec struct {
 	// with APIcast in the staging environment.
 	// +optional
 	OpenTracing *APIcastOpenTracingSpec `json:"openTracing,omitempty"`
+	// OpenTelemetry contains the OpenTelemetry integration configuration
+	// with APIcast in the staging environment.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
 	// CustomEnvironments specifies an array of defined custom environments to be loaded
 	// +optional
 	CustomEnvironments []CustomEnvironmentSpec `json:"customEnvironments,omitempty"` // APICAST_ENVIRONMENT
@@ -328,6 +768,65 @@ type BackendSpec struct {
 	WorkerSpec *BackendWorkerSpec `json:"workerSpec,omitempty"`
 	// +optional
 	CronSpec *BackendCronSpec `json:"cronSpec,omitempty"`
+	// Redis configures the external Redis topology used by backend.
+	// Only takes effect when `.spec.highAvailability.enabled` is set to true
+	// +optional
+	Redis *BackendRedisSpec `json:"redis,omitempty"`
+}
+
+const (
+	BackendRedisTopologyStandalone = "Standalone"
+	BackendRedisTopologySentinel   = "Sentinel"
+	BackendRedisTopologyCluster    = "Cluster"
+)
+
+// BackendRedisSpec describes the external Redis databases used by backend
+type BackendRedisSpec struct {
+	// Storage configures the Redis database where backend stores its data
+	Storage BackendRedisEndpointSpec `json:"storage"`
+	// Queues configures the Redis database backend uses for its job queues
+	Queues BackendRedisEndpointSpec `json:"queues"`
+}
+
+type BackendRedisEndpointSpec struct {
+	// Topology of the Redis deployment. `Standalone` when not set.
+	// `Cluster` topology is only supported for storage and requires URL
+	// to point to a cluster-aware proxy
+	// +kubebuilder:validation:Enum=Standalone;Sentinel;Cluster
+	// +optional
+	Topology *string `json:"topology,omitempty"`
+	// URL of the Redis database. Format is redis://<host>:<port>/<db>.
+	// With `Sentinel` topology the host is the name of the master group
+	URL string `json:"url"`
+	// Sentinel configures the Redis Sentinels monitoring the master group.
+	// Required when topology is `Sentinel`
+	// +optional
+	Sentinel *BackendRedisSentinelSpec `json:"sentinel,omitempty"`
+	// CredentialsSecretRef references a secret with the `password` and,
+	// optionally, `username` fields used to authenticate against Redis
+	// +optional
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+func (b *BackendRedisEndpointSpec) IsTopology(topology string) bool {
+	if b.Topology == nil {
+		return topology == BackendRedisTopologyStandalone
+	}
+	return *b.Topology == topology
+}
+
+type BackendRedisSentinelSpec struct {
+	// Hosts is the list of Sentinel endpoints. Format is <host>:<port>
+	// +kubebuilder:validation:MinItems=1
+	Hosts []string `json:"hosts"`
+	// Role of the Redis instances backend connects to
+	// +kubebuilder:validation:Enum=master;slave
+	// +optional
+	Role *string `json:"role,omitempty"`
+	// CredentialsSecretRef references a secret with the `password` field
+	// used to authenticate against the Sentinels
+	// +optional
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 type BackendRedisPersistentVolumeClaimSpec struct {
@@ -430,6 +929,10 @@ type SystemAppSpec struct {
 	ProviderContainerResources *v1.ResourceRequirements `json:"providerContainerResources,omitempty"`
 	// +optional
 	DeveloperContainerResources *v1.ResourceRequirements `json:"developerContainerResources,omitempty"`
+	// OpenTelemetry contains the OpenTelemetry integration configuration
+	// with the system-app containers.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
 }
 
 type SystemSidekiqSpec struct {
@@ -582,6 +1085,10 @@ type ZyncAppSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// OpenTelemetry contains the OpenTelemetry integration configuration
+	// with zync.
+	// +optional
+	OpenTelemetry *OpenTelemetrySpec `json:"openTelemetry,omitempty"`
 }
 
 type ZyncQueSpec struct {
@@ -609,6 +1116,242 @@ type MonitoringSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// +optional
 	EnablePrometheusRules *bool `json:"enablePrometheusRules,omitempty"`
+	// +optional
+	PrometheusRules *PrometheusRulesSpec `json:"prometheusRules,omitempty"`
+	// +optional
+	SLO *SLOSpec `json:"slo,omitempty"`
+	// Kind of the prometheus-operator monitors scraping the 3scale
+	// components. ServiceMonitors scrape the components through dedicated
+	// metrics services
+	// +kubebuilder:validation:Enum=PodMonitor;ServiceMonitor
+	// +optional
+	MonitorType *string `json:"monitorType,omitempty"`
+	// +optional
+	Scrape *ScrapeSpec `json:"scrape,omitempty"`
+}
+
+const (
+	PodMonitorType     = "PodMonitor"
+	ServiceMonitorType = "ServiceMonitor"
+)
+
+// ScrapeSpec configures how the 3scale components are scraped
+type ScrapeSpec struct {
+	// Interval between scrapes, i.e. 30s. Defaults to the Prometheus global
+	// scrape interval
+	// +kubebuilder:validation:Pattern=`^[0-9]+(ms|s|m|h)$`
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+	// Relabelings applied to the targets before scraping
+	// +optional
+	Relabelings []RelabelingSpec `json:"relabelings,omitempty"`
+	// Regular expressions matching the names of the metrics dropped after
+	// scraping
+	// +optional
+	DropMetrics []string `json:"dropMetrics,omitempty"`
+	// Scheme used to scrape. Only applies to ServiceMonitors
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme *string `json:"scheme,omitempty"`
+	// TLS configuration used to scrape. Only applies to ServiceMonitors
+	// +optional
+	TLSConfig *ScrapeTLSSpec `json:"tlsConfig,omitempty"`
+	// Secret key holding the bearer token used to scrape. Only applies to
+	// ServiceMonitors
+	// +optional
+	BearerTokenSecret *v1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+}
+
+// RelabelingSpec is a Prometheus relabeling rule
+type RelabelingSpec struct {
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// +optional
+	Separator *string `json:"separator,omitempty"`
+	// +optional
+	TargetLabel *string `json:"targetLabel,omitempty"`
+	// +optional
+	Regex *string `json:"regex,omitempty"`
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+	// +kubebuilder:validation:Enum=replace;keep;drop;labelmap;labeldrop;labelkeep
+	// +optional
+	Action *string `json:"action,omitempty"`
+}
+
+// ScrapeTLSSpec configures the TLS connection used to scrape
+type ScrapeTLSSpec struct {
+	// Secret key holding the CA certificate used to verify the targets
+	// +optional
+	CASecret *v1.SecretKeySelector `json:"caSecret,omitempty"`
+	// Secret key holding the client certificate
+	// +optional
+	CertSecret *v1.SecretKeySelector `json:"certSecret,omitempty"`
+	// Secret key holding the client key
+	// +optional
+	KeySecret *v1.SecretKeySelector `json:"keySecret,omitempty"`
+	// +optional
+	ServerName *string `json:"serverName,omitempty"`
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// SLOSpec enables the multiwindow, multi-burn-rate alerts on the
+// availability and latency service level objectives of APIcast production
+// and backend listener
+type SLOSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// +optional
+	ApicastProduction *ServiceLevelObjectiveSpec `json:"apicastProduction,omitempty"`
+	// +optional
+	BackendListener *ServiceLevelObjectiveSpec `json:"backendListener,omitempty"`
+}
+
+// ServiceLevelObjectiveSpec defines the availability and latency objectives
+// of a service
+type ServiceLevelObjectiveSpec struct {
+	// Percentage of requests not answered with a 5xx response
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,2}(\.[0-9]+)?$`
+	// +optional
+	AvailabilityTarget *string `json:"availabilityTarget,omitempty"`
+	// Percentage of requests answered within the latency threshold
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,2}(\.[0-9]+)?$`
+	// +optional
+	LatencyTarget *string `json:"latencyTarget,omitempty"`
+	// Response time, in seconds, the latency target applies to. It has to
+	// be a bucket boundary of the response time histogram
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	LatencyThreshold *string `json:"latencyThreshold,omitempty"`
+}
+
+// PrometheusRulesSpec customizes the alerts of the PrometheusRules generated
+// by the operator
+type PrometheusRulesSpec struct {
+	// Labels added to every alert. The labels of the alert overrides take
+	// precedence
+	// +optional
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+	// Overrides of the generated alerts
+	// +optional
+	Alerts []PrometheusAlertSpec `json:"alerts,omitempty"`
+	// Rules added to the generated PrometheusRules
+	// +optional
+	AdditionalRules []PrometheusAdditionalRulesSpec `json:"additionalRules,omitempty"`
+}
+
+// PrometheusAlertSpec overrides a generated alert
+type PrometheusAlertSpec struct {
+	// Name of the generated alert, i.e. ThreescaleApicastJobDown
+	Name string `json:"name"`
+	// Disabled removes the alert from the generated PrometheusRule
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// Value the alert expression is compared with
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Threshold *string `json:"threshold,omitempty"`
+	// Time the alert expression has to be true before the alert fires
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h|d|w|y))+$`
+	// +optional
+	For *string `json:"for,omitempty"`
+	// Labels added to the alert
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusAdditionalRulesSpec adds rules to a generated PrometheusRule
+type PrometheusAdditionalRulesSpec struct {
+	// Name of the generated PrometheusRule the rules are added to, i.e.
+	// apicast
+	PrometheusRule string `json:"prometheusRule"`
+	// Rules added to the PrometheusRule
+	Rules []PrometheusRuleSpec `json:"rules"`
+}
+
+// PrometheusRuleSpec is an alerting or recording rule
+type PrometheusRuleSpec struct {
+	// Name of the alert. Either alert or record has to be set
+	// +optional
+	Alert string `json:"alert,omitempty"`
+	// Name of the time series recorded
+	// +optional
+	Record string `json:"record,omitempty"`
+	// PromQL expression
+	Expr string `json:"expr"`
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h|d|w|y))+$`
+	// +optional
+	For string `json:"for,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DatabaseCredentialsRotationSpec configures the rotation of the credentials
+// of the internal databases managed by the operator
+type DatabaseCredentialsRotationSpec struct {
+	// Interval between automatic rotations. When not set, rotations are only
+	// performed when the `apps.3scale.net/rotate-database-credentials`
+	// annotation value changes
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// MaintenanceSpec configures the maintenance mode of the APIManager
+type MaintenanceSpec struct {
+	// Paused stops the reconciliation of the 3scale components, so manual
+	// changes are not reverted. The APIManager status is still updated
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// ScaleDownComponents scales to zero all the DeploymentConfigs that do
+	// not hold data. Previous replicas are restored when disabled.
+	// It implies paused
+	// +optional
+	ScaleDownComponents bool `json:"scaleDownComponents,omitempty"`
+}
+
+// UpgradePolicySpec configures how the 3scale release is upgraded. The
+// installation is backed up before being upgraded and the previous images
+// are restored when the upgraded components do not become available
+type UpgradePolicySpec struct {
+	// Specification of the APIManagerBackup created before the upgrade
+	BackupTemplate APIManagerBackupSpec `json:"backupTemplate"`
+	// Time the upgraded components have to become available before the
+	// upgrade is rolled back. Defaults to 30m
+	// +optional
+	AvailabilityTimeout *metav1.Duration `json:"availabilityTimeout,omitempty"`
+}
+
+// UpgradeApprovalSpec makes the upgrades of the 3scale release wait for the
+// target release to be approved, so they can be performed in a maintenance
+// window
+type UpgradeApprovalSpec struct {
+	// 3scale release the APIManager is allowed to be upgraded to
+	// +optional
+	ApprovedVersion string `json:"approvedVersion,omitempty"`
+}
+
+// ImageRegistrySpec rewrites the images deployed by the operator so they
+// are pulled from a mirror registry, i.e. in disconnected clusters
+type ImageRegistrySpec struct {
+	// Registry replacing the registry of the images not matched by any
+	// mirror
+	// +optional
+	Override *string `json:"override,omitempty"`
+	// Registries or repositories replaced by a mirror. The longest matching
+	// source is used
+	// +optional
+	Mirrors []ImageRegistryMirrorSpec `json:"mirrors,omitempty"`
+}
+
+type ImageRegistryMirrorSpec struct {
+	// Registry or repository prefix of the source images, i.e. quay.io/3scale.
+	// Docker Hub images are matched as docker.io/<namespace>/<repository>,
+	// with the library namespace for official images
+	Source string `json:"source"`
+	// Registry or repository prefix replacing the source
+	Mirror string `json:"mirror"`
 }
 
 // PersistentVolumeClaimResources defines the resources configuration
@@ -636,6 +1379,45 @@ type APIcastOpenTracingSpec struct {
 	TracingConfigSecretRef *v1.LocalObjectReference `json:"tracingConfigSecretRef,omitempty"`
 }
 
+// OpenTelemetrySpec contains the OpenTelemetry tracing configuration of a
+// component. Traces are exported with the OTLP protocol
+type OpenTelemetrySpec struct {
+	// Enabled controls whether OpenTelemetry tracing is enabled.
+	// By default it is not enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Endpoint is the URL of the OTLP collector traces are exported to,
+	// for instance `http://otel-collector:4317`. `https` endpoints are
+	// reached over TLS.
+	Endpoint string `json:"endpoint"`
+	// Protocol is the OTLP protocol used to export traces. APIcast only
+	// supports `grpc`. If not set, `grpc` is used for APIcast and
+	// `http/protobuf` for system and zync.
+	// +optional
+	// +kubebuilder:validation:Enum=grpc;http/protobuf
+	Protocol *string `json:"protocol,omitempty"`
+	// SamplingRatio is the ratio of new traces that are sampled, between 0 and 1.
+	// Sampling decisions of the parent spans are honored.
+	// If not set, every trace is sampled.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	SamplingRatio *string `json:"samplingRatio,omitempty"`
+	// ResourceAttributes are added to the resource of the exported spans
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+	// TLS contains the settings to verify `https` endpoints
+	// +optional
+	TLS *OpenTelemetryTLSSpec `json:"tls,omitempty"`
+}
+
+type OpenTelemetryTLSSpec struct {
+	// CACertificateSecretRef selects the secret key holding the PEM encoded
+	// CA certificates the endpoint certificate is verified with. If not set,
+	// the system trust store is used.
+	// +optional
+	CACertificateSecretRef *v1.SecretKeySelector `json:"caCertificateSecretRef,omitempty"`
+}
+
 // SetDefaults sets the default values for the APIManager spec and returns true if the spec was changed
 func (apimanager *APIManager) SetDefaults() (bool, error) {
 	var err error
@@ -736,6 +1518,13 @@ func (apimanager *APIManager) setApicastSpecDefaults() bool {
 		changed = true
 	}
 
+	for idx := range spec.Apicast.Gateways {
+		if spec.Apicast.Gateways[idx].Replicas == nil {
+			spec.Apicast.Gateways[idx].Replicas = apimanager.defaultReplicas()
+			changed = true
+		}
+	}
+
 	return changed
 }
 
@@ -945,6 +1734,87 @@ func (apimanager *APIManager) IsZyncExternalDatabaseEnabled() bool {
 		apimanager.Spec.HighAvailability.ExternalZyncDatabaseEnabled != nil &&
 		*apimanager.Spec.HighAvailability.ExternalZyncDatabaseEnabled
 }
+
+func (apimanager *APIManager) IsBackendRedisSpecEnabled() bool {
+	return apimanager.IsExternalDatabaseEnabled() &&
+		apimanager.Spec.Backend != nil &&
+		apimanager.Spec.Backend.Redis != nil
+}
+
+func (apimanager *APIManager) DatabaseCredentialsRotationInterval() *metav1.Duration {
+	if apimanager.Spec.DatabaseCredentialsRotation == nil {
+		return nil
+	}
+	return apimanager.Spec.DatabaseCredentialsRotation.Interval
+}
+
+func (apimanager *APIManager) IsApicastProductionCanaryEnabled() bool {
+	return apimanager.Spec.Apicast != nil &&
+		apimanager.Spec.Apicast.ProductionSpec != nil &&
+		apimanager.Spec.Apicast.ProductionSpec.Canary != nil &&
+		apimanager.Spec.Apicast.ProductionSpec.Canary.Enabled
+}
+
+func (apimanager *APIManager) ApicastProductionCanaryWeight() int32 {
+	if !apimanager.IsApicastProductionCanaryEnabled() || apimanager.Spec.Apicast.ProductionSpec.Canary.Weight == nil {
+		return DefaultApicastCanaryWeight
+	}
+	return *apimanager.Spec.Apicast.ProductionSpec.Canary.Weight
+}
+
+func (apimanager *APIManager) ApicastProductionCanaryAnalysisDuration() time.Duration {
+	if !apimanager.IsApicastProductionCanaryEnabled() || apimanager.Spec.Apicast.ProductionSpec.Canary.AnalysisDuration == nil {
+		return DefaultApicastCanaryAnalysisDuration
+	}
+	return apimanager.Spec.Apicast.ProductionSpec.Canary.AnalysisDuration.Duration
+}
+
+func (apimanager *APIManager) ApicastProductionCanaryMaxErrorRate() int32 {
+	if !apimanager.IsApicastProductionCanaryEnabled() || apimanager.Spec.Apicast.ProductionSpec.Canary.MaxErrorRate == nil {
+		return DefaultApicastCanaryMaxErrorRate
+	}
+	return *apimanager.Spec.Apicast.ProductionSpec.Canary.MaxErrorRate
+}
+
+func (apimanager *APIManager) IsUpgradePolicyEnabled() bool {
+	return apimanager.Spec.UpgradePolicy != nil
+}
+
+func (apimanager *APIManager) UpgradeAvailabilityTimeout() time.Duration {
+	if apimanager.Spec.UpgradePolicy == nil || apimanager.Spec.UpgradePolicy.AvailabilityTimeout == nil {
+		return DefaultUpgradeAvailabilityTimeout
+	}
+	return apimanager.Spec.UpgradePolicy.AvailabilityTimeout.Duration
+}
+
+func (apimanager *APIManager) IsUpgradeFailed() bool {
+	return apimanager.Status.Conditions.IsTrueFor(APIManagerUpgradeFailedConditionType)
+}
+
+// IsUpgradeApproved returns whether the APIManager can be upgraded to the
+// given 3scale release
+func (apimanager *APIManager) IsUpgradeApproved(release string) bool {
+	return apimanager.Spec.UpgradeApproval == nil || apimanager.Spec.UpgradeApproval.ApprovedVersion == release
+}
+
+func (apimanager *APIManager) IsUpgradeAwaitingApproval() bool {
+	return apimanager.Status.Conditions.IsTrueFor(APIManagerUpgradeAvailableConditionType)
+}
+
+func (apimanager *APIManager) IsReconciliationPaused() bool {
+	return apimanager.Spec.Maintenance != nil &&
+		(apimanager.Spec.Maintenance.Paused || apimanager.Spec.Maintenance.ScaleDownComponents)
+}
+
+func (apimanager *APIManager) IsMaintenanceScaleDownEnabled() bool {
+	return apimanager.Spec.Maintenance != nil && apimanager.Spec.Maintenance.ScaleDownComponents
+}
+
+func (apimanager *APIManager) IsSystemDatabaseRestorePending() bool {
+	_, ok := apimanager.Annotations[SystemDatabaseRestorePendingAnnotation]
+	return ok && !apimanager.IsExternalDatabaseEnabled()
+}
+
 func (apimanager *APIManager) IsPDBEnabled() bool {
 	return apimanager.Spec.PodDisruptionBudget != nil && apimanager.Spec.PodDisruptionBudget.Enabled
 }
@@ -970,6 +1840,16 @@ func (apimanager *APIManager) IsPrometheusRulesEnabled() bool {
 		(apimanager.Spec.Monitoring.EnablePrometheusRules == nil || *apimanager.Spec.Monitoring.EnablePrometheusRules))
 }
 
+func (apimanager *APIManager) IsServiceMonitorEnabled() bool {
+	return apimanager.IsMonitoringEnabled() &&
+		apimanager.Spec.Monitoring.MonitorType != nil && *apimanager.Spec.Monitoring.MonitorType == ServiceMonitorType
+}
+
+func (apimanager *APIManager) IsSLOEnabled() bool {
+	return apimanager.IsPrometheusRulesEnabled() &&
+		apimanager.Spec.Monitoring.SLO != nil && apimanager.Spec.Monitoring.SLO.Enabled
+}
+
 func (apimanager *APIManager) IsAPIcastProductionOpenTracingEnabled() bool {
 	return apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil &&
 		apimanager.Spec.Apicast.ProductionSpec.OpenTracing != nil &&
@@ -984,6 +1864,48 @@ func (apimanager *APIManager) IsAPIcastStagingOpenTracingEnabled() bool {
 		*apimanager.Spec.Apicast.StagingSpec.OpenTracing.Enabled
 }
 
+func (apimanager *APIManager) IsAPIcastProductionOpenTelemetryEnabled() bool {
+	return apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil &&
+		apimanager.Spec.Apicast.ProductionSpec.OpenTelemetry.IsEnabled()
+}
+
+func (apimanager *APIManager) IsAPIcastStagingOpenTelemetryEnabled() bool {
+	return apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.StagingSpec != nil &&
+		apimanager.Spec.Apicast.StagingSpec.OpenTelemetry.IsEnabled()
+}
+
+func (apimanager *APIManager) IsSystemAppOpenTelemetryEnabled() bool {
+	return apimanager.Spec.System != nil && apimanager.Spec.System.AppSpec != nil &&
+		apimanager.Spec.System.AppSpec.OpenTelemetry.IsEnabled()
+}
+
+func (apimanager *APIManager) IsZyncOpenTelemetryEnabled() bool {
+	return apimanager.Spec.Zync != nil && apimanager.Spec.Zync.AppSpec != nil &&
+		apimanager.Spec.Zync.AppSpec.OpenTelemetry.IsEnabled()
+}
+
+func (spec *OpenTelemetrySpec) IsEnabled() bool {
+	return spec != nil && spec.Enabled != nil && *spec.Enabled
+}
+
+// ApicastGatewayDeploymentNames returns the DeploymentConfig names of the
+// additional APIcast gateways of the given environment. All the gateways are
+// returned when the environment is empty
+func (apimanager *APIManager) ApicastGatewayDeploymentNames(environment string) []string {
+	names := []string{}
+	if apimanager.Spec.Apicast == nil {
+		return names
+	}
+
+	for idx := range apimanager.Spec.Apicast.Gateways {
+		if environment == "" || apimanager.Spec.Apicast.Gateways[idx].Environment == environment {
+			names = append(names, apimanager.Spec.Apicast.Gateways[idx].DeploymentConfigName())
+		}
+	}
+
+	return names
+}
+
 func (apimanager *APIManager) Validate() field.ErrorList {
 	fieldErrors := field.ErrorList{}
 
@@ -993,147 +1915,279 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 		apicastFldPath := specFldPath.Child("apicast")
 
 		if apimanager.Spec.Apicast.ProductionSpec != nil {
+			prodSpec := apimanager.Spec.Apicast.ProductionSpec
 			prodSpecFldPath := apicastFldPath.Child("productionSpec")
 
-			customPoliciesFldPath := prodSpecFldPath.Child("customPolicies")
-			duplicatePolicyMap := make(map[string]int)
-			for idx, customPolicySpec := range apimanager.Spec.Apicast.ProductionSpec.CustomPolicies {
-				customPoliciesIdxFldPath := customPoliciesFldPath.Index(idx)
-
-				// check custom policy secret is set
-				if customPolicySpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret is mandatory"))
-				} else if customPolicySpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
-				}
-
-				// check duplicated custom policy version name
-				if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name version tuple is duplicated"))
-					break
-				}
-				duplicatePolicyMap[customPolicySpec.VersionName()] = 0
-			}
+			fieldErrors = append(fieldErrors, validateAPIcastCustomPolicies(prodSpec.CustomPolicies, prodSpecFldPath.Child("customPolicies"))...)
 
 			if apimanager.IsAPIcastProductionOpenTracingEnabled() {
-				openTracingConfigSpec := apimanager.Spec.Apicast.ProductionSpec.OpenTracing
-				if openTracingConfigSpec.TracingConfigSecretRef != nil {
-					if openTracingConfigSpec.TracingConfigSecretRef.Name == "" {
-						apicastProductioOpenTracingFldPath := prodSpecFldPath.Child("openTracing")
-						customTracingConfigFldPath := apicastProductioOpenTracingFldPath.Child("tracingConfigSecretRef")
-						fieldErrors = append(fieldErrors, field.Invalid(customTracingConfigFldPath, apimanager.Spec.Apicast.ProductionSpec.OpenTracing, "custom tracing library secret name is empty"))
-					}
-				}
-
-				// For now only "jaeger" is accepted" as the tracing library
-				if openTracingConfigSpec.TracingLibrary != nil && *openTracingConfigSpec.TracingLibrary != component.APIcastDefaultTracingLibrary {
-					tracingLibraryFldPath := field.NewPath("spec").
-						Child("apicast").
-						Child("productionSpec").
-						Child("openTracing").
-						Child("tracingLibrary")
-					fieldErrors = append(fieldErrors, field.Invalid(tracingLibraryFldPath, openTracingConfigSpec, "invalid tracing library specified"))
-				}
+				fieldErrors = append(fieldErrors, validateAPIcastOpenTracingSpec(prodSpec.OpenTracing, prodSpecFldPath.Child("openTracing"))...)
 			}
 
-			customEnvsFldPath := prodSpecFldPath.Child("customEnvironments")
-			duplicateEnvMap := make(map[string]int)
-			// check custom environment secret is set
-			for idx, customEnvSpec := range apimanager.Spec.Apicast.ProductionSpec.CustomEnvironments {
-				customEnvsIdxFldPath := customEnvsFldPath.Index(idx)
-
-				if customEnvSpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret is mandatory"))
-				} else if customEnvSpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret name is empty"))
-				} else {
-					// check duplicated custom env secret
-					if _, ok := duplicateEnvMap[customEnvSpec.SecretRef.Name]; ok {
-						fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec.SecretRef.Name, "custom env secret name is duplicated"))
-						break
-					}
-					duplicateEnvMap[customEnvSpec.SecretRef.Name] = 0
-				}
+			if apimanager.IsAPIcastProductionOpenTelemetryEnabled() {
+				fieldErrors = append(fieldErrors, validateAPIcastOpenTelemetrySpec(
+					prodSpec.OpenTelemetry,
+					apimanager.IsAPIcastProductionOpenTracingEnabled(),
+					prodSpecFldPath.Child("openTelemetry"))...)
 			}
 
-			// check HTTPSPort does not conflict with default HTTPPort
-			httpsPortFldPath := prodSpecFldPath.Child("httpsPort")
-
-			if apimanager.Spec.Apicast.ProductionSpec.HTTPSPort != nil && *apimanager.Spec.Apicast.ProductionSpec.HTTPSPort == DefaultHTTPPort {
-				fieldErrors = append(fieldErrors, field.Invalid(httpsPortFldPath, apimanager.Spec.Apicast.ProductionSpec.HTTPSPort, "HTTPS port conflicts with HTTP port"))
-			}
+			fieldErrors = append(fieldErrors, validateAPIcastCustomEnvironments(prodSpec.CustomEnvironments, prodSpecFldPath.Child("customEnvironments"))...)
+			fieldErrors = append(fieldErrors, validateAPIcastHTTPSPort(prodSpec.HTTPSPort, prodSpecFldPath.Child("httpsPort"))...)
 		}
 
 		if apimanager.Spec.Apicast.StagingSpec != nil {
+			stagingSpec := apimanager.Spec.Apicast.StagingSpec
 			stagingSpecFldPath := apicastFldPath.Child("stagingSpec")
-			customPoliciesFldPath := stagingSpecFldPath.Child("customPolicies")
-			duplicatePolicyMap := make(map[string]int)
-			for idx, customPolicySpec := range apimanager.Spec.Apicast.StagingSpec.CustomPolicies {
-				// TODO(eastizle): DRY!!
-				customPoliciesIdxFldPath := customPoliciesFldPath.Index(idx)
-
-				// check custom policy secret is set
-				if customPolicySpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret is mandatory"))
-				} else if customPolicySpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
-				}
-
-				// check duplicated custom policy version name
-				if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
-					fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name version tuple is duplicated"))
-					break
-				}
-				duplicatePolicyMap[customPolicySpec.VersionName()] = 0
-			}
+
+			fieldErrors = append(fieldErrors, validateAPIcastCustomPolicies(stagingSpec.CustomPolicies, stagingSpecFldPath.Child("customPolicies"))...)
 
 			if apimanager.IsAPIcastStagingOpenTracingEnabled() {
-				openTracingConfigSpec := apimanager.Spec.Apicast.StagingSpec.OpenTracing
-				if openTracingConfigSpec.TracingConfigSecretRef != nil {
-					if openTracingConfigSpec.TracingConfigSecretRef.Name == "" {
-						apicastStagingOpenTracingFldPath := stagingSpecFldPath.Child("openTracing")
-						customTracingConfigFldPath := apicastStagingOpenTracingFldPath.Child("tracingConfigSecretRef")
-						fieldErrors = append(fieldErrors, field.Invalid(customTracingConfigFldPath, openTracingConfigSpec, "custom tracing library secret name is empty"))
-					}
-				}
-				// For now only "jaeger" is accepted" as the tracing library
-				if openTracingConfigSpec.TracingLibrary != nil && *openTracingConfigSpec.TracingLibrary != component.APIcastDefaultTracingLibrary {
-					tracingLibraryFldPath := field.NewPath("spec").
-						Child("apicast").
-						Child("stagingSpec").
-						Child("openTracing").
-						Child("tracingLibrary")
-					fieldErrors = append(fieldErrors, field.Invalid(tracingLibraryFldPath, openTracingConfigSpec, "invalid tracing library specified"))
-				}
+				fieldErrors = append(fieldErrors, validateAPIcastOpenTracingSpec(stagingSpec.OpenTracing, stagingSpecFldPath.Child("openTracing"))...)
 			}
 
-			customEnvsFldPath := stagingSpecFldPath.Child("customEnvironments")
-			duplicateEnvMap := make(map[string]int)
-			// check custom environment secret is set
-			for idx, customEnvSpec := range apimanager.Spec.Apicast.StagingSpec.CustomEnvironments {
-				customEnvsIdxFldPath := customEnvsFldPath.Index(idx)
-
-				if customEnvSpec.SecretRef == nil {
-					fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret is mandatory"))
-				} else if customEnvSpec.SecretRef.Name == "" {
-					fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret name is empty"))
-				} else {
-					// check duplicated custom env secret
-					if _, ok := duplicateEnvMap[customEnvSpec.SecretRef.Name]; ok {
-						fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec.SecretRef.Name, "custom env secret name is duplicated"))
-						break
-					}
-					duplicateEnvMap[customEnvSpec.SecretRef.Name] = 0
-				}
+			if apimanager.IsAPIcastStagingOpenTelemetryEnabled() {
+				fieldErrors = append(fieldErrors, validateAPIcastOpenTelemetrySpec(
+					stagingSpec.OpenTelemetry,
+					apimanager.IsAPIcastStagingOpenTracingEnabled(),
+					stagingSpecFldPath.Child("openTelemetry"))...)
 			}
 
-			// check HTTPSPort does not conflict with default HTTPPort
-			httpsPortFldPath := stagingSpecFldPath.Child("httpsPort")
+			fieldErrors = append(fieldErrors, validateAPIcastCustomEnvironments(stagingSpec.CustomEnvironments, stagingSpecFldPath.Child("customEnvironments"))...)
+			fieldErrors = append(fieldErrors, validateAPIcastHTTPSPort(stagingSpec.HTTPSPort, stagingSpecFldPath.Child("httpsPort"))...)
+		}
+	}
 
-			if apimanager.Spec.Apicast.StagingSpec.HTTPSPort != nil && *apimanager.Spec.Apicast.StagingSpec.HTTPSPort == DefaultHTTPPort {
-				fieldErrors = append(fieldErrors, field.Invalid(httpsPortFldPath, apimanager.Spec.Apicast.StagingSpec.HTTPSPort, "HTTPS port conflicts with HTTP port"))
+	if apimanager.Spec.Apicast != nil {
+		gatewaysFldPath := specFldPath.Child("apicast").Child("gateways")
+		duplicateGatewayMap := make(map[string]int)
+		for idx := range apimanager.Spec.Apicast.Gateways {
+			fieldErrors = append(fieldErrors, validateApicastGatewaySpec(&apimanager.Spec.Apicast.Gateways[idx], gatewaysFldPath.Index(idx))...)
+
+			// check duplicated gateway name
+			gatewayName := apimanager.Spec.Apicast.Gateways[idx].Name
+			if _, ok := duplicateGatewayMap[gatewayName]; ok {
+				fieldErrors = append(fieldErrors, field.Duplicate(gatewaysFldPath.Index(idx).Child("name"), gatewayName))
 			}
+			duplicateGatewayMap[gatewayName] = 0
+		}
+	}
+
+	if apimanager.IsSystemAppOpenTelemetryEnabled() {
+		openTelemetryFldPath := specFldPath.Child("system").Child("appSpec").Child("openTelemetry")
+		fieldErrors = append(fieldErrors, validateOpenTelemetrySpec(apimanager.Spec.System.AppSpec.OpenTelemetry, openTelemetryFldPath)...)
+	}
+
+	if apimanager.IsZyncOpenTelemetryEnabled() {
+		openTelemetryFldPath := specFldPath.Child("zync").Child("appSpec").Child("openTelemetry")
+		fieldErrors = append(fieldErrors, validateOpenTelemetrySpec(apimanager.Spec.Zync.AppSpec.OpenTelemetry, openTelemetryFldPath)...)
+	}
+
+	if apimanager.Spec.Backend != nil && apimanager.Spec.Backend.Redis != nil {
+		backendRedisFldPath := specFldPath.Child("backend").Child("redis")
+		if !apimanager.IsExternalDatabaseEnabled() {
+			fieldErrors = append(fieldErrors, field.Forbidden(backendRedisFldPath, "backend redis spec requires highAvailability to be enabled"))
 		}
+
+		fieldErrors = append(fieldErrors, validateBackendRedisEndpointSpec(&apimanager.Spec.Backend.Redis.Storage, backendRedisFldPath.Child("storage"))...)
+
+		queuesFldPath := backendRedisFldPath.Child("queues")
+		fieldErrors = append(fieldErrors, validateBackendRedisEndpointSpec(&apimanager.Spec.Backend.Redis.Queues, queuesFldPath)...)
+		// Queues rely on multi-key operations that are not available in redis cluster
+		if apimanager.Spec.Backend.Redis.Queues.IsTopology(BackendRedisTopologyCluster) {
+			fieldErrors = append(fieldErrors, field.Invalid(queuesFldPath.Child("topology"), BackendRedisTopologyCluster, "cluster topology not supported for backend queues"))
+		}
+	}
+
+	if interval := apimanager.DatabaseCredentialsRotationInterval(); interval != nil && interval.Duration <= 0 {
+		intervalFldPath := specFldPath.Child("databaseCredentialsRotation").Child("interval")
+		fieldErrors = append(fieldErrors, field.Invalid(intervalFldPath, interval.Duration.String(), "rotation interval must be positive"))
+	}
+
+	return fieldErrors
+}
+
+func validateApicastGatewaySpec(spec *ApicastGatewaySpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	// apicast-staging and apicast-production are already deployed.
+	// apicast-production-canary is the production canary
+	if spec.Name == ApicastGatewayEnvironmentStaging || spec.Name == ApicastGatewayEnvironmentProduction || spec.Name == apicastProductionCanaryGatewayName {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("name"), spec.Name, "gateway name is reserved"))
+	}
+
+	if spec.Workers != nil && spec.Environment != ApicastGatewayEnvironmentProduction {
+		fieldErrors = append(fieldErrors, field.Forbidden(fldPath.Child("workers"), "workers can only be set for production gateways"))
+	}
+
+	if spec.PortalEndpointSecretRef != nil && spec.PortalEndpointSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("portalEndpointSecretRef"), spec.PortalEndpointSecretRef, "portal endpoint secret name is empty"))
+	}
+
+	fieldErrors = append(fieldErrors, validateAPIcastCustomPolicies(spec.CustomPolicies, fldPath.Child("customPolicies"))...)
+
+	if spec.IsOpenTracingEnabled() {
+		fieldErrors = append(fieldErrors, validateAPIcastOpenTracingSpec(spec.OpenTracing, fldPath.Child("openTracing"))...)
+	}
+
+	fieldErrors = append(fieldErrors, validateAPIcastCustomEnvironments(spec.CustomEnvironments, fldPath.Child("customEnvironments"))...)
+	fieldErrors = append(fieldErrors, validateAPIcastHTTPSPort(spec.HTTPSPort, fldPath.Child("httpsPort"))...)
+
+	return fieldErrors
+}
+
+// validateAPIcastCustomPolicies checks the custom policies of an APIcast
+// deployment reference a secret and are not duplicated
+func validateAPIcastCustomPolicies(specs []CustomPolicySpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	duplicatePolicyMap := make(map[string]int)
+	for idx, customPolicySpec := range specs {
+		customPoliciesIdxFldPath := fldPath.Index(idx)
+
+		// check custom policy secret is set
+		if customPolicySpec.SecretRef == nil {
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret is mandatory"))
+		} else if customPolicySpec.SecretRef.Name == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name is empty"))
+		}
+
+		// check duplicated custom policy version name
+		if _, ok := duplicatePolicyMap[customPolicySpec.VersionName()]; ok {
+			fieldErrors = append(fieldErrors, field.Invalid(customPoliciesIdxFldPath, customPolicySpec, "custom policy secret name version tuple is duplicated"))
+			break
+		}
+		duplicatePolicyMap[customPolicySpec.VersionName()] = 0
+	}
+
+	return fieldErrors
+}
+
+// validateAPIcastOpenTracingSpec checks the enabled OpenTracing configuration
+// of an APIcast deployment
+func validateAPIcastOpenTracingSpec(spec *APIcastOpenTracingSpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	if spec.TracingConfigSecretRef != nil && spec.TracingConfigSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("tracingConfigSecretRef"), spec, "custom tracing library secret name is empty"))
+	}
+
+	// For now only "jaeger" is accepted" as the tracing library
+	if spec.TracingLibrary != nil && *spec.TracingLibrary != component.APIcastDefaultTracingLibrary {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("tracingLibrary"), spec, "invalid tracing library specified"))
+	}
+
+	return fieldErrors
+}
+
+// validateAPIcastCustomEnvironments checks the custom environments of an
+// APIcast deployment reference a secret and are not duplicated
+func validateAPIcastCustomEnvironments(specs []CustomEnvironmentSpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	duplicateEnvMap := make(map[string]int)
+	for idx, customEnvSpec := range specs {
+		customEnvsIdxFldPath := fldPath.Index(idx)
+
+		if customEnvSpec.SecretRef == nil {
+			fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret is mandatory"))
+		} else if customEnvSpec.SecretRef.Name == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec, "custom environment secret name is empty"))
+		} else {
+			// check duplicated custom env secret
+			if _, ok := duplicateEnvMap[customEnvSpec.SecretRef.Name]; ok {
+				fieldErrors = append(fieldErrors, field.Invalid(customEnvsIdxFldPath, customEnvSpec.SecretRef.Name, "custom env secret name is duplicated"))
+				break
+			}
+			duplicateEnvMap[customEnvSpec.SecretRef.Name] = 0
+		}
+	}
+
+	return fieldErrors
+}
+
+// validateAPIcastHTTPSPort checks the HTTPS port of an APIcast deployment
+// does not conflict with the default HTTP port
+func validateAPIcastHTTPSPort(httpsPort *int32, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	if httpsPort != nil && *httpsPort == DefaultHTTPPort {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath, httpsPort, "HTTPS port conflicts with HTTP port"))
+	}
+
+	return fieldErrors
+}
+
+func validateOpenTelemetrySpec(spec *OpenTelemetrySpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	endpointURL, err := url.Parse(spec.Endpoint)
+	if err != nil {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("endpoint"), spec.Endpoint, err.Error()))
+	} else if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("endpoint"), spec.Endpoint, "http or https URL scheme expected"))
+	} else if endpointURL.Hostname() == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("endpoint"), spec.Endpoint, "endpoint host is empty"))
+	}
+
+	if spec.TLS != nil && spec.TLS.CACertificateSecretRef != nil && spec.TLS.CACertificateSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("tls").Child("caCertificateSecretRef"), spec.TLS.CACertificateSecretRef, "CA certificate secret name is empty"))
+	}
+
+	return fieldErrors
+}
+
+func validateAPIcastOpenTelemetrySpec(spec *OpenTelemetrySpec, openTracingEnabled bool, fldPath *field.Path) field.ErrorList {
+	fieldErrors := validateOpenTelemetrySpec(spec, fldPath)
+
+	if openTracingEnabled {
+		fieldErrors = append(fieldErrors, field.Forbidden(fldPath, "openTelemetry and openTracing cannot be enabled at the same time"))
+	}
+
+	// The APIcast OpenTelemetry module only exports with gRPC
+	if spec.Protocol != nil && *spec.Protocol != component.OpenTelemetryGRPCProtocol {
+		fieldErrors = append(fieldErrors, field.NotSupported(fldPath.Child("protocol"), *spec.Protocol, []string{component.OpenTelemetryGRPCProtocol}))
+	}
+
+	return fieldErrors
+}
+
+func validateBackendRedisEndpointSpec(spec *BackendRedisEndpointSpec, fldPath *field.Path) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	redisURL, err := url.Parse(spec.URL)
+	if err != nil {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("url"), spec.URL, err.Error()))
+	} else if redisURL.Scheme != "redis" && redisURL.Scheme != "rediss" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("url"), spec.URL, "redis or rediss URL scheme expected"))
+	} else if redisURL.User != nil {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("url"), spec.URL, "credentials should be set using credentialsSecretRef"))
+	}
+
+	if spec.CredentialsSecretRef != nil && spec.CredentialsSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("credentialsSecretRef"), spec.CredentialsSecretRef, "credentials secret name is empty"))
+	}
+
+	sentinelFldPath := fldPath.Child("sentinel")
+	if !spec.IsTopology(BackendRedisTopologySentinel) {
+		if spec.Sentinel != nil {
+			fieldErrors = append(fieldErrors, field.Forbidden(sentinelFldPath, "sentinel can only be set with Sentinel topology"))
+		}
+		return fieldErrors
+	}
+
+	if spec.Sentinel == nil || len(spec.Sentinel.Hosts) == 0 {
+		fieldErrors = append(fieldErrors, field.Required(sentinelFldPath.Child("hosts"), "sentinel hosts are mandatory with Sentinel topology"))
+		return fieldErrors
+	}
+
+	for idx, host := range spec.Sentinel.Hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			fieldErrors = append(fieldErrors, field.Invalid(sentinelFldPath.Child("hosts").Index(idx), host, err.Error()))
+		}
+	}
+
+	if spec.Sentinel.CredentialsSecretRef != nil && spec.Sentinel.CredentialsSecretRef.Name == "" {
+		fieldErrors = append(fieldErrors, field.Invalid(sentinelFldPath.Child("credentialsSecretRef"), spec.Sentinel.CredentialsSecretRef, "credentials secret name is empty"))
 	}
 
 	return fieldErrors
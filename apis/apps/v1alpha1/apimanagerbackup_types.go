@@ -17,9 +17,18 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/3scale/3scale-operator/pkg/common"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// BackupStatusAnnotation is set in the capabilities custom resources
+	// recreated by an APIManagerRestore. Its value is the JSON encoded status,
+	// with the 3scale IDs, the resource had when it was backed up
+	BackupStatusAnnotation = "apps.3scale.net/backup-status"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -30,6 +39,22 @@ type APIManagerBackupSpec struct {
 
 	// Backup data destination configuration
 	BackupDestination APIManagerBackupDestination `json:"backupDestination"`
+
+	// Also back up the capabilities custom resources (Tenant, Backend,
+	// Product, OpenAPI, ActiveDoc, CustomPolicyDefinition, DeveloperAccount
+	// and DeveloperUser) of the namespace and the secrets they reference
+	// +optional
+	IncludeCapabilities *bool `json:"includeCapabilities,omitempty"`
+
+	// Secret containing the BACKUP_ENCRYPTION_KEY base64 encoded 256 bit key
+	// used to encrypt the backup data before it is stored in the backup
+	// destination. The backup data is not encrypted when not set
+	// +optional
+	EncryptionKeySecretRef *v1.LocalObjectReference `json:"encryptionKeySecretRef,omitempty"`
+
+	// Retries and timeout of the Jobs performing the backup steps
+	// +optional
+	Jobs *BackupJobsSpec `json:"jobs,omitempty"`
 }
 
 // APIManagerBackupDestination defines the backup data destination
@@ -39,6 +64,10 @@ type APIManagerBackupDestination struct {
 	// PersistentVolumeClaim as backup data destination configuration
 	// +optional
 	PersistentVolumeClaim *PersistentVolumeClaimBackupDestination `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 API compatible object storage as backup data destination configuration
+	// +optional
+	S3 *S3BackupDestination `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimBackupDestination defines the configuration
@@ -62,6 +91,64 @@ type PersistentVolumeClaimBackupDestination struct {
 	StorageClass *string `json:"storageClass,omitempty"`
 }
 
+type S3BackupDestination struct {
+	S3Location `json:",inline"`
+
+	// Server-side encryption applied to the backup data objects
+	// +optional
+	ServerSideEncryption *S3ServerSideEncryption `json:"serverSideEncryption,omitempty"`
+}
+
+type S3Location struct {
+	// Name of the bucket
+	Bucket string `json:"bucket"`
+
+	// Key prefix of the backup data objects. In backup destinations the name
+	// of the APIManagerBackup is appended to it
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Region of the bucket
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// URL of the S3 API compatible endpoint. AWS S3 is used when not set
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Secret containing the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// credentials used to access the bucket
+	CredentialsSecretRef v1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+type S3ServerSideEncryption struct {
+	// Server-side encryption algorithm
+	// +kubebuilder:validation:Enum=AES256;"aws:kms"
+	Algorithm string `json:"algorithm"`
+
+	// ID of the AWS KMS key. Only used with the aws:kms algorithm. The AWS
+	// managed key is used when not set
+	// +optional
+	KMSKeyID *string `json:"kmsKeyID,omitempty"`
+}
+
+// BackupJobsSpec configures the Kubernetes Jobs performing the steps of a
+// backup or a restore. A step whose Job fails is not retried any further and
+// the backup or restore is marked as failed
+type BackupJobsSpec struct {
+	// Number of retries of the Job of a step before the step is considered
+	// failed. Defaults to 3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// Duration in seconds the Job of a step can run, retries included,
+	// before the step is considered failed. Not limited when not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
 // APIManagerBackupStatus defines the observed state of APIManagerBackup
 type APIManagerBackupStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -92,6 +179,84 @@ type APIManagerBackupStatus struct {
 	// PersistentVolumeClaim is used as the backup data destination
 	// +optional
 	BackupPersistentVolumeClaimName *string `json:"backupPersistentVolumeClaimName,omitempty"`
+
+	// Location of the backup data objects. Only set when S3 is used as the
+	// backup data destination
+	// +optional
+	BackupS3Location *string `json:"backupS3Location,omitempty"`
+
+	// Summary of the manifest describing the content of the backup
+	// +optional
+	Manifest *BackupManifestSummary `json:"manifest,omitempty"`
+
+	// Set to true when a backup step has failed. Failed backups are not
+	// retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
+	// Progress of the backup steps. The condition of a failed step contains
+	// the last log lines of its Job
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// Per step conditions
+	APIManagerBackupSecretsAndConfigMapsConditionType common.ConditionType = "SecretsAndConfigMapsBackedUp"
+	APIManagerBackupAPIManagerConditionType           common.ConditionType = "APIManagerBackedUp"
+	APIManagerBackupSystemFileStorageConditionType    common.ConditionType = "SystemFileStorageBackedUp"
+	APIManagerBackupSystemDatabaseConditionType       common.ConditionType = "SystemDatabaseBackedUp"
+	APIManagerBackupRedisConditionType                common.ConditionType = "RedisBackedUp"
+	APIManagerBackupCapabilitiesConditionType         common.ConditionType = "CapabilitiesBackedUp"
+	APIManagerBackupManifestConditionType             common.ConditionType = "ManifestWritten"
+
+	// APIManagerBackupFailedConditionType is true when a backup step has
+	// failed
+	APIManagerBackupFailedConditionType common.ConditionType = "Failed"
+)
+
+const (
+	// Reasons of the backup and restore step conditions
+	BackupJobRunningReason   common.ConditionReason = "JobRunning"
+	BackupJobSucceededReason common.ConditionReason = "JobSucceeded"
+	BackupJobFailedReason    common.ConditionReason = "JobFailed"
+)
+
+// BackupManifestSummary summarizes the manifest written at the root of the
+// backup data. The manifest lists every backed up file with its size and
+// SHA-256 checksum
+type BackupManifestSummary struct {
+	// 3scale release the backup was performed from
+	ThreescaleRelease string `json:"threescaleRelease"`
+
+	// Version of the 3scale operator that performed the backup
+	OperatorVersion string `json:"operatorVersion"`
+
+	// SHA-256 hash of the spec of the backed up APIManager
+	APIManagerSpecHash string `json:"apiManagerSpecHash"`
+
+	// Number of files listed in the manifest
+	FileCount int64 `json:"fileCount"`
+
+	// Total size in bytes of the files listed in the manifest
+	TotalSize int64 `json:"totalSize"`
+
+	// SHA-256 checksum of the manifest file
+	ManifestSHA256 string `json:"manifestSHA256"`
+
+	// Set to true when the backed up files are encrypted
+	// +optional
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// Namespace of the backed up APIManager
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Wildcard domain of the backed up APIManager
+	// +optional
+	WildcardDomain string `json:"wildcardDomain,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -112,6 +277,10 @@ func (a *APIManagerBackup) SetDefaults() (bool, error) {
 	return false, nil
 }
 
+func (a *APIManagerBackup) CapabilitiesIncluded() bool {
+	return a.Spec.IncludeCapabilities != nil && *a.Spec.IncludeCapabilities
+}
+
 func (a *APIManagerBackup) BackupCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
@@ -120,6 +289,10 @@ func (a *APIManagerBackup) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
 
+func (a *APIManagerBackup) BackupFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
 // +kubebuilder:object:root=true
 
 // APIManagerBackupList contains a list of APIManagerBackup
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIManagerBackupScheduleLabel is set in the APIManagerBackups created
+	// by an APIManagerBackupSchedule. Its value is the name of the schedule
+	APIManagerBackupScheduleLabel = "apps.3scale.net/apimanagerbackupschedule"
+
+	// APIManagerBackupScheduledTimeAnnotation is set in the APIManagerBackups
+	// created by an APIManagerBackupSchedule. Its value is the time, in
+	// RFC3339 form, for which the backup was scheduled
+	APIManagerBackupScheduledTimeAnnotation = "apps.3scale.net/apimanagerbackup-scheduled-time"
+)
+
+// APIManagerBackupScheduleSpec defines the desired state of APIManagerBackupSchedule
+type APIManagerBackupScheduleSpec struct {
+	// Schedule in cron format ("minute hour day-of-month month day-of-week").
+	// Times are evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// Suspend the creation of new backups. Retention rules are still applied
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Specification of the APIManagerBackups created by the schedule
+	BackupTemplate APIManagerBackupSpec `json:"backupTemplate"`
+
+	// Retention rules of the completed APIManagerBackups created by the
+	// schedule. All backups are kept when not set
+	// +optional
+	Retention *APIManagerBackupRetention `json:"retention,omitempty"`
+}
+
+// APIManagerBackupRetention defines which completed backups are kept. A
+// backup is kept when any of the rules keeps it. Backups not kept by any rule
+// are deleted together with their backup data
+type APIManagerBackupRetention struct {
+	// Number of most recent backups to keep
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Number of days for which the most recent backup of the day is kept.
+	// Days are evaluated in UTC
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// Number of weeks for which the most recent backup of the week is kept.
+	// Weeks are ISO 8601 weeks evaluated in UTC
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+}
+
+// APIManagerBackupScheduleStatus defines the observed state of APIManagerBackupSchedule
+type APIManagerBackupScheduleStatus struct {
+	// Last time a backup was scheduled. It is represented in RFC3339 form and
+	// is in UTC.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Name of the last APIManagerBackup created by the schedule
+	// +optional
+	LastBackupName *string `json:"lastBackupName,omitempty"`
+
+	// Next time a backup is scheduled. It is represented in RFC3339 form and
+	// is in UTC.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIManagerBackupSchedule represents a schedule of APIManager backups
+// +kubebuilder:resource:path=apimanagerbackupschedules,scope=Namespaced
+// +kubebuilder:printcolumn:JSONPath=".spec.schedule",name=Schedule,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.lastBackupName",name="Last Backup",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.lastScheduleTime",name="Last Schedule",type=date
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIManagerBackupSchedule"
+type APIManagerBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerBackupScheduleSpec   `json:"spec,omitempty"`
+	Status APIManagerBackupScheduleStatus `json:"status,omitempty"`
+}
+
+func (a *APIManagerBackupSchedule) IsSuspended() bool {
+	return a.Spec.Suspend != nil && *a.Spec.Suspend
+}
+
+// +kubebuilder:object:root=true
+
+// APIManagerBackupScheduleList contains a list of APIManagerBackupSchedule
+type APIManagerBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerBackupSchedule{}, &APIManagerBackupScheduleList{})
+}
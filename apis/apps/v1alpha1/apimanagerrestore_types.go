@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/3scale/3scale-operator/pkg/common"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -30,6 +31,84 @@ type APIManagerRestoreSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	RestoreSource APIManagerRestoreSource `json:"restoreSource"`
+
+	// Secret containing the BACKUP_ENCRYPTION_KEY key the backup data was
+	// encrypted with. Required when the backup data is encrypted
+	// +optional
+	EncryptionKeySecretRef *v1.LocalObjectReference `json:"encryptionKeySecretRef,omitempty"`
+
+	// Overrides applied to the backed up APIManager when it is restored.
+	// The APIManager is restored in the namespace of the APIManagerRestore
+	// unless the namespace is overridden
+	// +optional
+	Overrides *APIManagerRestoreOverrides `json:"overrides,omitempty"`
+
+	// Retries and timeout of the Jobs performing the restore steps
+	// +optional
+	Jobs *BackupJobsSpec `json:"jobs,omitempty"`
+
+	// Restore backups without manifest, performed before manifests were
+	// written. Their backup data cannot be verified. Defaults to false
+	// +optional
+	AllowBackupWithoutManifest *bool `json:"allowBackupWithoutManifest,omitempty"`
+}
+
+// APIManagerRestoreOverrides defines the attributes of the backed up
+// APIManager replaced when it is restored, i.e. when restoring it into
+// another cluster
+type APIManagerRestoreOverrides struct {
+	// Namespace the APIManager is restored into. The restored secrets,
+	// configmaps and capabilities custom resources, and the secret
+	// references of the latter, are moved to it too. It has to be watched
+	// by the operator. The persistentVolumeClaim restore source cannot be
+	// used with it, as claims cannot be mounted from other namespaces
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Wildcard domain of the restored APIManager. The tenant and
+	// APIcast domains stored in the system database are moved to the new
+	// wildcard domain before the zync domains are resynchronized
+	// +optional
+	WildcardDomain *string `json:"wildcardDomain,omitempty"`
+
+	// Storage class of the PersistentVolumeClaims of the restored
+	// APIManager
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Images of the restored APIManager
+	// +optional
+	Images *APIManagerRestoreImagesOverrides `json:"images,omitempty"`
+
+	// Image registry of the restored APIManager. It is also used to pull
+	// the images of the restore Jobs
+	// +optional
+	ImageRegistry *ImageRegistrySpec `json:"imageRegistry,omitempty"`
+}
+
+// APIManagerRestoreImagesOverrides defines the images of the restored
+// APIManager replacing the backed up ones
+type APIManagerRestoreImagesOverrides struct {
+	// +optional
+	Apicast *string `json:"apicast,omitempty"`
+	// +optional
+	Backend *string `json:"backend,omitempty"`
+	// +optional
+	BackendRedis *string `json:"backendRedis,omitempty"`
+	// +optional
+	System *string `json:"system,omitempty"`
+	// +optional
+	SystemMemcached *string `json:"systemMemcached,omitempty"`
+	// +optional
+	SystemRedis *string `json:"systemRedis,omitempty"`
+	// +optional
+	SystemMySQL *string `json:"systemMySQL,omitempty"`
+	// +optional
+	SystemPostgreSQL *string `json:"systemPostgreSQL,omitempty"`
+	// +optional
+	Zync *string `json:"zync,omitempty"`
+	// +optional
+	ZyncPostgreSQL *string `json:"zyncPostgreSQL,omitempty"`
 }
 
 // APIManagerRestoreSource defines the backup data restore source
@@ -39,6 +118,12 @@ type APIManagerRestoreSource struct {
 	// +optional
 	// Restore data soure configuration
 	PersistentVolumeClaim *PersistentVolumeClaimRestoreSource `json:"persistentVolumeClaim,omitempty"`
+
+	// S3 API compatible object storage as restore data source configuration.
+	// Prefix is the location of the backup data objects, as reported in the
+	// APIManagerBackup status
+	// +optional
+	S3 *S3Location `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimRestoreSource defines the configuration
@@ -55,7 +140,8 @@ type APIManagerRestoreStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// Name of the APIManager to be restored
+	// Name of the APIManager to be restored. It is restored in the namespace
+	// of the APIManagerRestore, or in the overridden one
 	// +optional
 	APIManagerToRestoreRef *v1.LocalObjectReference `json:"apiManagerToRestoreRef,omitempty"`
 
@@ -75,8 +161,61 @@ type APIManagerRestoreStatus struct {
 	// Restore completion time. It is represented in RFC3339 form and is in UTC.
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Set to true when the backup data has been verified against its
+	// manifest. Backups without manifest are only considered verified when
+	// allowBackupWithoutManifest is set
+	// +optional
+	BackupVerified *bool `json:"backupVerified,omitempty"`
+
+	// Reason why the backup data could not be verified. When set, the
+	// restore is refused and nothing is restored
+	// +optional
+	BackupVerificationError *string `json:"backupVerificationError,omitempty"`
+
+	// Summary of the manifest of the restored backup
+	// +optional
+	BackupManifest *BackupManifestSummary `json:"backupManifest,omitempty"`
+
+	// Set to true when a restore step has failed. Failed restores are not
+	// retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
+	// Progress of the restore steps. The condition of a failed step contains
+	// the last log lines of its Job
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+const (
+	// Per step conditions
+	APIManagerRestoreBackupVerifiedConditionType       common.ConditionType = "BackupVerified"
+	APIManagerRestoreSecretsAndConfigMapsConditionType common.ConditionType = "SecretsAndConfigMapsRestored"
+	APIManagerRestoreAPIManagerConditionType           common.ConditionType = "APIManagerRestored"
+	APIManagerRestoreSystemFileStorageConditionType    common.ConditionType = "SystemFileStorageRestored"
+	APIManagerRestoreRedisConditionType                common.ConditionType = "RedisRestored"
+	APIManagerRestoreSystemDatabaseConditionType       common.ConditionType = "SystemDatabaseRestored"
+	APIManagerRestoreZyncDomainsConditionType          common.ConditionType = "ZyncDomainsResynchronized"
+	APIManagerRestoreCapabilitiesConditionType         common.ConditionType = "CapabilitiesRestored"
+
+	// APIManagerRestoreFailedConditionType is true when a restore step has
+	// failed
+	APIManagerRestoreFailedConditionType common.ConditionType = "Failed"
+)
+
+const (
+	// BackupManifestNotFoundReason is set on the BackupVerified condition of
+	// the restores of backups without manifest
+	BackupManifestNotFoundReason common.ConditionReason = "ManifestNotFound"
+
+	// RestoreNamespaceInvalidReason is set on the Failed condition of the
+	// restores whose overridden namespace cannot be restored into
+	RestoreNamespaceInvalidReason common.ConditionReason = "InvalidNamespace"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -103,6 +242,36 @@ func (a *APIManagerRestore) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
 
+func (a *APIManagerRestore) BackupVerified() bool {
+	return a.Status.BackupVerified != nil && *a.Status.BackupVerified
+}
+
+func (a *APIManagerRestore) BackupVerificationFailed() bool {
+	return a.Status.BackupVerificationError != nil
+}
+
+func (a *APIManagerRestore) BackupWithoutManifestAllowed() bool {
+	return a.Spec.AllowBackupWithoutManifest != nil && *a.Spec.AllowBackupWithoutManifest
+}
+
+func (a *APIManagerRestore) RestoreFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
+// TargetNamespace returns the namespace the APIManager is restored into
+func (a *APIManagerRestore) TargetNamespace() string {
+	if a.Spec.Overrides != nil && a.Spec.Overrides.Namespace != nil {
+		return *a.Spec.Overrides.Namespace
+	}
+	return a.Namespace
+}
+
+// NamespaceOverridden returns whether the APIManager is restored into
+// another namespace than the one of the APIManagerRestore
+func (a *APIManagerRestore) NamespaceOverridden() bool {
+	return a.TargetNamespace() != a.Namespace
+}
+
 // +kubebuilder:object:root=true
 
 // APIManagerRestoreList contains a list of APIManagerRestore
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"github.com/3scale/3scale-operator/pkg/common"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -88,6 +89,11 @@ func (in *APIManagerBackupDestination) DeepCopyInto(out *APIManagerBackupDestina
 		*out = new(PersistentVolumeClaimBackupDestination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupDestination)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupDestination.
@@ -132,10 +138,168 @@ func (in *APIManagerBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupRetention) DeepCopyInto(out *APIManagerBackupRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupRetention.
+func (in *APIManagerBackupRetention) DeepCopy() *APIManagerBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupSchedule) DeepCopyInto(out *APIManagerBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSchedule.
+func (in *APIManagerBackupSchedule) DeepCopy() *APIManagerBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleList) DeepCopyInto(out *APIManagerBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIManagerBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleList.
+func (in *APIManagerBackupScheduleList) DeepCopy() *APIManagerBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleSpec) DeepCopyInto(out *APIManagerBackupScheduleSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(APIManagerBackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleSpec.
+func (in *APIManagerBackupScheduleSpec) DeepCopy() *APIManagerBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleStatus) DeepCopyInto(out *APIManagerBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackupName != nil {
+		in, out := &in.LastBackupName, &out.LastBackupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleStatus.
+func (in *APIManagerBackupScheduleStatus) DeepCopy() *APIManagerBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerBackupSpec) DeepCopyInto(out *APIManagerBackupSpec) {
 	*out = *in
 	in.BackupDestination.DeepCopyInto(&out.BackupDestination)
+	if in.IncludeCapabilities != nil {
+		in, out := &in.IncludeCapabilities, &out.IncludeCapabilities
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EncryptionKeySecretRef != nil {
+		in, out := &in.EncryptionKeySecretRef, &out.EncryptionKeySecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(BackupJobsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSpec.
@@ -179,6 +343,28 @@ func (in *APIManagerBackupStatus) DeepCopyInto(out *APIManagerBackupStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackupS3Location != nil {
+		in, out := &in.BackupS3Location, &out.BackupS3Location
+		*out = new(string)
+		**out = **in
+	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(BackupManifestSummary)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupStatus.
@@ -219,6 +405,11 @@ func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ImageRegistry != nil {
+		in, out := &in.ImageRegistry, &out.ImageRegistry
+		*out = new(ImageRegistrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerCommonSpec.
@@ -290,6 +481,71 @@ func (in *APIManagerRestore) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreImagesOverrides) DeepCopyInto(out *APIManagerRestoreImagesOverrides) {
+	*out = *in
+	if in.Apicast != nil {
+		in, out := &in.Apicast, &out.Apicast
+		*out = new(string)
+		**out = **in
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackendRedis != nil {
+		in, out := &in.BackendRedis, &out.BackendRedis
+		*out = new(string)
+		**out = **in
+	}
+	if in.System != nil {
+		in, out := &in.System, &out.System
+		*out = new(string)
+		**out = **in
+	}
+	if in.SystemMemcached != nil {
+		in, out := &in.SystemMemcached, &out.SystemMemcached
+		*out = new(string)
+		**out = **in
+	}
+	if in.SystemRedis != nil {
+		in, out := &in.SystemRedis, &out.SystemRedis
+		*out = new(string)
+		**out = **in
+	}
+	if in.SystemMySQL != nil {
+		in, out := &in.SystemMySQL, &out.SystemMySQL
+		*out = new(string)
+		**out = **in
+	}
+	if in.SystemPostgreSQL != nil {
+		in, out := &in.SystemPostgreSQL, &out.SystemPostgreSQL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zync != nil {
+		in, out := &in.Zync, &out.Zync
+		*out = new(string)
+		**out = **in
+	}
+	if in.ZyncPostgreSQL != nil {
+		in, out := &in.ZyncPostgreSQL, &out.ZyncPostgreSQL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreImagesOverrides.
+func (in *APIManagerRestoreImagesOverrides) DeepCopy() *APIManagerRestoreImagesOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreImagesOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerRestoreList) DeepCopyInto(out *APIManagerRestoreList) {
 	*out = *in
@@ -322,6 +578,46 @@ func (in *APIManagerRestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreOverrides) DeepCopyInto(out *APIManagerRestoreOverrides) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.WildcardDomain != nil {
+		in, out := &in.WildcardDomain, &out.WildcardDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(APIManagerRestoreImagesOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageRegistry != nil {
+		in, out := &in.ImageRegistry, &out.ImageRegistry
+		*out = new(ImageRegistrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreOverrides.
+func (in *APIManagerRestoreOverrides) DeepCopy() *APIManagerRestoreOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerRestoreSource) DeepCopyInto(out *APIManagerRestoreSource) {
 	*out = *in
@@ -330,6 +626,11 @@ func (in *APIManagerRestoreSource) DeepCopyInto(out *APIManagerRestoreSource) {
 		*out = new(PersistentVolumeClaimRestoreSource)
 		**out = **in
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Location)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSource.
@@ -346,6 +647,26 @@ func (in *APIManagerRestoreSource) DeepCopy() *APIManagerRestoreSource {
 func (in *APIManagerRestoreSpec) DeepCopyInto(out *APIManagerRestoreSpec) {
 	*out = *in
 	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	if in.EncryptionKeySecretRef != nil {
+		in, out := &in.EncryptionKeySecretRef, &out.EncryptionKeySecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(APIManagerRestoreOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(BackupJobsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowBackupWithoutManifest != nil {
+		in, out := &in.AllowBackupWithoutManifest, &out.AllowBackupWithoutManifest
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSpec.
@@ -384,6 +705,33 @@ func (in *APIManagerRestoreStatus) DeepCopyInto(out *APIManagerRestoreStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.BackupVerified != nil {
+		in, out := &in.BackupVerified, &out.BackupVerified
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupVerificationError != nil {
+		in, out := &in.BackupVerificationError, &out.BackupVerificationError
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackupManifest != nil {
+		in, out := &in.BackupManifest, &out.BackupManifest
+		*out = new(BackupManifestSummary)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreStatus.
@@ -435,6 +783,26 @@ func (in *APIManagerSpec) DeepCopyInto(out *APIManagerSpec) {
 		*out = new(MonitoringSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DatabaseCredentialsRotation != nil {
+		in, out := &in.DatabaseCredentialsRotation, &out.DatabaseCredentialsRotation
+		*out = new(DatabaseCredentialsRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		**out = **in
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeApproval != nil {
+		in, out := &in.UpgradeApproval, &out.UpgradeApproval
+		*out = new(UpgradeApprovalSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerSpec.
@@ -458,6 +826,31 @@ func (in *APIManagerStatus) DeepCopyInto(out *APIManagerStatus) {
 		}
 	}
 	in.Deployments.DeepCopyInto(&out.Deployments)
+	if in.DeploymentReplicas != nil {
+		in, out := &in.DeploymentReplicas, &out.DeploymentReplicas
+		*out = make([]DeploymentConfigReplicasStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseCredentialsRotation != nil {
+		in, out := &in.DatabaseCredentialsRotation, &out.DatabaseCredentialsRotation
+		*out = new(DatabaseCredentialsRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApicastCanary != nil {
+		in, out := &in.ApicastCanary, &out.ApicastCanary
+		*out = new(ApicastCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerStatus.
@@ -500,6 +893,185 @@ func (in *APIcastOpenTracingSpec) DeepCopy() *APIcastOpenTracingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicastCanaryPodSample) DeepCopyInto(out *ApicastCanaryPodSample) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastCanaryPodSample.
+func (in *ApicastCanaryPodSample) DeepCopy() *ApicastCanaryPodSample {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicastCanaryPodSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicastCanarySpec) DeepCopyInto(out *ApicastCanarySpec) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AnalysisDuration != nil {
+		in, out := &in.AnalysisDuration, &out.AnalysisDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastCanarySpec.
+func (in *ApicastCanarySpec) DeepCopy() *ApicastCanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicastCanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicastCanaryStatus) DeepCopyInto(out *ApicastCanaryStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]ApicastCanaryPodSample, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastCanaryStatus.
+func (in *ApicastCanaryStatus) DeepCopy() *ApicastCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicastCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApicastGatewaySpec) DeepCopyInto(out *ApicastGatewaySpec) {
+	*out = *in
+	if in.PortalEndpointSecretRef != nil {
+		in, out := &in.PortalEndpointSecretRef, &out.PortalEndpointSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomPolicies != nil {
+		in, out := &in.CustomPolicies, &out.CustomPolicies
+		*out = make([]CustomPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OpenTracing != nil {
+		in, out := &in.OpenTracing, &out.OpenTracing
+		*out = new(APIcastOpenTracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomEnvironments != nil {
+		in, out := &in.CustomEnvironments, &out.CustomEnvironments
+		*out = make([]CustomEnvironmentSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HTTPSPort != nil {
+		in, out := &in.HTTPSPort, &out.HTTPSPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HTTPSVerifyDepth != nil {
+		in, out := &in.HTTPSVerifyDepth, &out.HTTPSVerifyDepth
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HTTPSCertificateSecretRef != nil {
+		in, out := &in.HTTPSCertificateSecretRef, &out.HTTPSCertificateSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AllProxy != nil {
+		in, out := &in.AllProxy, &out.AllProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastGatewaySpec.
+func (in *ApicastGatewaySpec) DeepCopy() *ApicastGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApicastGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 	*out = *in
@@ -547,6 +1119,11 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 		*out = new(APIcastOpenTracingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomEnvironments != nil {
 		in, out := &in.CustomEnvironments, &out.CustomEnvironments
 		*out = make([]CustomEnvironmentSpec, len(*in))
@@ -589,6 +1166,11 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ApicastCanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastProductionSpec.
@@ -639,6 +1221,13 @@ func (in *ApicastSpec) DeepCopyInto(out *ApicastSpec) {
 		*out = new(ApicastStagingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]ApicastGatewaySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastSpec.
@@ -693,6 +1282,11 @@ func (in *ApicastStagingSpec) DeepCopyInto(out *ApicastStagingSpec) {
 		*out = new(APIcastOpenTracingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomEnvironments != nil {
 		in, out := &in.CustomEnvironments, &out.CustomEnvironments
 		*out = make([]CustomEnvironmentSpec, len(*in))
@@ -821,6 +1415,36 @@ func (in *BackendListenerSpec) DeepCopy() *BackendListenerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendRedisEndpointSpec) DeepCopyInto(out *BackendRedisEndpointSpec) {
+	*out = *in
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(string)
+		**out = **in
+	}
+	if in.Sentinel != nil {
+		in, out := &in.Sentinel, &out.Sentinel
+		*out = new(BackendRedisSentinelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendRedisEndpointSpec.
+func (in *BackendRedisEndpointSpec) DeepCopy() *BackendRedisEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendRedisEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendRedisPersistentVolumeClaimSpec) DeepCopyInto(out *BackendRedisPersistentVolumeClaimSpec) {
 	*out = *in
@@ -836,279 +1460,965 @@ func (in *BackendRedisPersistentVolumeClaimSpec) DeepCopy() *BackendRedisPersist
 	if in == nil {
 		return nil
 	}
-	out := new(BackendRedisPersistentVolumeClaimSpec)
+	out := new(BackendRedisPersistentVolumeClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendRedisSentinelSpec) DeepCopyInto(out *BackendRedisSentinelSpec) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendRedisSentinelSpec.
+func (in *BackendRedisSentinelSpec) DeepCopy() *BackendRedisSentinelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendRedisSentinelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendRedisSpec) DeepCopyInto(out *BackendRedisSpec) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Queues.DeepCopyInto(&out.Queues)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendRedisSpec.
+func (in *BackendRedisSpec) DeepCopy() *BackendRedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendRedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.RedisImage != nil {
+		in, out := &in.RedisImage, &out.RedisImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.RedisPersistentVolumeClaimSpec != nil {
+		in, out := &in.RedisPersistentVolumeClaimSpec, &out.RedisPersistentVolumeClaimSpec
+		*out = new(BackendRedisPersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisAffinity != nil {
+		in, out := &in.RedisAffinity, &out.RedisAffinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisTolerations != nil {
+		in, out := &in.RedisTolerations, &out.RedisTolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RedisResources != nil {
+		in, out := &in.RedisResources, &out.RedisResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ListenerSpec != nil {
+		in, out := &in.ListenerSpec, &out.ListenerSpec
+		*out = new(BackendListenerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkerSpec != nil {
+		in, out := &in.WorkerSpec, &out.WorkerSpec
+		*out = new(BackendWorkerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CronSpec != nil {
+		in, out := &in.CronSpec, &out.CronSpec
+		*out = new(BackendCronSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(BackendRedisSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
+func (in *BackendSpec) DeepCopy() *BackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendWorkerSpec) DeepCopyInto(out *BackendWorkerSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendWorkerSpec.
+func (in *BackendWorkerSpec) DeepCopy() *BackendWorkerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendWorkerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupJobsSpec) DeepCopyInto(out *BackupJobsSpec) {
+	*out = *in
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobsSpec.
+func (in *BackupJobsSpec) DeepCopy() *BackupJobsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupJobsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupManifestSummary) DeepCopyInto(out *BackupManifestSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupManifestSummary.
+func (in *BackupManifestSummary) DeepCopy() *BackupManifestSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupManifestSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomEnvironmentSpec) DeepCopyInto(out *CustomEnvironmentSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomEnvironmentSpec.
+func (in *CustomEnvironmentSpec) DeepCopy() *CustomEnvironmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomEnvironmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomPolicySpec) DeepCopyInto(out *CustomPolicySpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicySpec.
+func (in *CustomPolicySpec) DeepCopy() *CustomPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseCredentialsRotationSpec) DeepCopyInto(out *DatabaseCredentialsRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseCredentialsRotationSpec.
+func (in *DatabaseCredentialsRotationSpec) DeepCopy() *DatabaseCredentialsRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseCredentialsRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseCredentialsRotationStatus) DeepCopyInto(out *DatabaseCredentialsRotationStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseCredentialsRotationStatus.
+func (in *DatabaseCredentialsRotationStatus) DeepCopy() *DatabaseCredentialsRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseCredentialsRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentConfigImageStatus) DeepCopyInto(out *DeploymentConfigImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentConfigImageStatus.
+func (in *DeploymentConfigImageStatus) DeepCopy() *DeploymentConfigImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentConfigImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentConfigReplicasStatus) DeepCopyInto(out *DeploymentConfigReplicasStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentConfigReplicasStatus.
+func (in *DeploymentConfigReplicasStatus) DeepCopy() *DeploymentConfigReplicasStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentConfigReplicasStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecatedSystemS3Spec) DeepCopyInto(out *DeprecatedSystemS3Spec) {
+	*out = *in
+	out.AWSCredentials = in.AWSCredentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedSystemS3Spec.
+func (in *DeprecatedSystemS3Spec) DeepCopy() *DeprecatedSystemS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecatedSystemS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
+	*out = *in
+	if in.ExternalZyncDatabaseEnabled != nil {
+		in, out := &in.ExternalZyncDatabaseEnabled, &out.ExternalZyncDatabaseEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilitySpec.
+func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryMirrorSpec) DeepCopyInto(out *ImageRegistryMirrorSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryMirrorSpec.
+func (in *ImageRegistryMirrorSpec) DeepCopy() *ImageRegistryMirrorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryMirrorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistrySpec) DeepCopyInto(out *ImageRegistrySpec) {
+	*out = *in
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(string)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ImageRegistryMirrorSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistrySpec.
+func (in *ImageRegistrySpec) DeepCopy() *ImageRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.EnablePrometheusRules != nil {
+		in, out := &in.EnablePrometheusRules, &out.EnablePrometheusRules
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrometheusRules != nil {
+		in, out := &in.PrometheusRules, &out.PrometheusRules
+		*out = new(PrometheusRulesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = new(SLOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitorType != nil {
+		in, out := &in.MonitorType, &out.MonitorType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scrape != nil {
+		in, out := &in.Scrape, &out.Scrape
+		*out = new(ScrapeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetrySpec) DeepCopyInto(out *OpenTelemetrySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.SamplingRatio != nil {
+		in, out := &in.SamplingRatio, &out.SamplingRatio
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OpenTelemetryTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetrySpec.
+func (in *OpenTelemetrySpec) DeepCopy() *OpenTelemetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryTLSSpec) DeepCopyInto(out *OpenTelemetryTLSSpec) {
+	*out = *in
+	if in.CACertificateSecretRef != nil {
+		in, out := &in.CACertificateSecretRef, &out.CACertificateSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryTLSSpec.
+func (in *OpenTelemetryTLSSpec) DeepCopy() *OpenTelemetryTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimBackupDestination) DeepCopyInto(out *PersistentVolumeClaimBackupDestination) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(PersistentVolumeClaimResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeName != nil {
+		in, out := &in.VolumeName, &out.VolumeName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimBackupDestination.
+func (in *PersistentVolumeClaimBackupDestination) DeepCopy() *PersistentVolumeClaimBackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimBackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimResources) DeepCopyInto(out *PersistentVolumeClaimResources) {
+	*out = *in
+	out.Requests = in.Requests.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimResources.
+func (in *PersistentVolumeClaimResources) DeepCopy() *PersistentVolumeClaimResources {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimRestoreSource) DeepCopyInto(out *PersistentVolumeClaimRestoreSource) {
+	*out = *in
+	out.ClaimSource = in.ClaimSource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimRestoreSource.
+func (in *PersistentVolumeClaimRestoreSource) DeepCopy() *PersistentVolumeClaimRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAdditionalRulesSpec) DeepCopyInto(out *PrometheusAdditionalRulesSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PrometheusRuleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAdditionalRulesSpec.
+func (in *PrometheusAdditionalRulesSpec) DeepCopy() *PrometheusAdditionalRulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAdditionalRulesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
+func (in *PrometheusAlertSpec) DeepCopyInto(out *PrometheusAlertSpec) {
 	*out = *in
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
 		*out = new(string)
 		**out = **in
 	}
-	if in.RedisImage != nil {
-		in, out := &in.RedisImage, &out.RedisImage
+	if in.For != nil {
+		in, out := &in.For, &out.For
 		*out = new(string)
 		**out = **in
 	}
-	if in.RedisPersistentVolumeClaimSpec != nil {
-		in, out := &in.RedisPersistentVolumeClaimSpec, &out.RedisPersistentVolumeClaimSpec
-		*out = new(BackendRedisPersistentVolumeClaimSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RedisAffinity != nil {
-		in, out := &in.RedisAffinity, &out.RedisAffinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RedisTolerations != nil {
-		in, out := &in.RedisTolerations, &out.RedisTolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.RedisResources != nil {
-		in, out := &in.RedisResources, &out.RedisResources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ListenerSpec != nil {
-		in, out := &in.ListenerSpec, &out.ListenerSpec
-		*out = new(BackendListenerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WorkerSpec != nil {
-		in, out := &in.WorkerSpec, &out.WorkerSpec
-		*out = new(BackendWorkerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CronSpec != nil {
-		in, out := &in.CronSpec, &out.CronSpec
-		*out = new(BackendCronSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
-func (in *BackendSpec) DeepCopy() *BackendSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAlertSpec.
+func (in *PrometheusAlertSpec) DeepCopy() *PrometheusAlertSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BackendSpec)
+	out := new(PrometheusAlertSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackendWorkerSpec) DeepCopyInto(out *BackendWorkerSpec) {
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendWorkerSpec.
-func (in *BackendWorkerSpec) DeepCopy() *BackendWorkerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BackendWorkerSpec)
+	out := new(PrometheusRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomEnvironmentSpec) DeepCopyInto(out *CustomEnvironmentSpec) {
+func (in *PrometheusRulesSpec) DeepCopyInto(out *PrometheusRulesSpec) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]PrometheusAlertSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalRules != nil {
+		in, out := &in.AdditionalRules, &out.AdditionalRules
+		*out = make([]PrometheusAdditionalRulesSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomEnvironmentSpec.
-func (in *CustomEnvironmentSpec) DeepCopy() *CustomEnvironmentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRulesSpec.
+func (in *PrometheusRulesSpec) DeepCopy() *PrometheusRulesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomEnvironmentSpec)
+	out := new(PrometheusRulesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomPolicySpec) DeepCopyInto(out *CustomPolicySpec) {
+func (in *RelabelingSpec) DeepCopyInto(out *RelabelingSpec) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Separator != nil {
+		in, out := &in.Separator, &out.Separator
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetLabel != nil {
+		in, out := &in.TargetLabel, &out.TargetLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Regex != nil {
+		in, out := &in.Regex, &out.Regex
+		*out = new(string)
+		**out = **in
+	}
+	if in.Replacement != nil {
+		in, out := &in.Replacement, &out.Replacement
+		*out = new(string)
+		**out = **in
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicySpec.
-func (in *CustomPolicySpec) DeepCopy() *CustomPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelabelingSpec.
+func (in *RelabelingSpec) DeepCopy() *RelabelingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomPolicySpec)
+	out := new(RelabelingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeprecatedSystemS3Spec) DeepCopyInto(out *DeprecatedSystemS3Spec) {
+func (in *S3BackupDestination) DeepCopyInto(out *S3BackupDestination) {
 	*out = *in
-	out.AWSCredentials = in.AWSCredentials
+	in.S3Location.DeepCopyInto(&out.S3Location)
+	if in.ServerSideEncryption != nil {
+		in, out := &in.ServerSideEncryption, &out.ServerSideEncryption
+		*out = new(S3ServerSideEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedSystemS3Spec.
-func (in *DeprecatedSystemS3Spec) DeepCopy() *DeprecatedSystemS3Spec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupDestination.
+func (in *S3BackupDestination) DeepCopy() *S3BackupDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(DeprecatedSystemS3Spec)
+	out := new(S3BackupDestination)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
+func (in *S3Location) DeepCopyInto(out *S3Location) {
 	*out = *in
-	if in.ExternalZyncDatabaseEnabled != nil {
-		in, out := &in.ExternalZyncDatabaseEnabled, &out.ExternalZyncDatabaseEnabled
-		*out = new(bool)
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
 		**out = **in
 	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilitySpec.
-func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Location.
+func (in *S3Location) DeepCopy() *S3Location {
 	if in == nil {
 		return nil
 	}
-	out := new(HighAvailabilitySpec)
+	out := new(S3Location)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+func (in *S3ServerSideEncryption) DeepCopyInto(out *S3ServerSideEncryption) {
 	*out = *in
-	if in.EnablePrometheusRules != nil {
-		in, out := &in.EnablePrometheusRules, &out.EnablePrometheusRules
-		*out = new(bool)
+	if in.KMSKeyID != nil {
+		in, out := &in.KMSKeyID, &out.KMSKeyID
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
-func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ServerSideEncryption.
+func (in *S3ServerSideEncryption) DeepCopy() *S3ServerSideEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringSpec)
+	out := new(S3ServerSideEncryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistentVolumeClaimBackupDestination) DeepCopyInto(out *PersistentVolumeClaimBackupDestination) {
+func (in *SLOSpec) DeepCopyInto(out *SLOSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(PersistentVolumeClaimResources)
+	if in.ApicastProduction != nil {
+		in, out := &in.ApicastProduction, &out.ApicastProduction
+		*out = new(ServiceLevelObjectiveSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.VolumeName != nil {
-		in, out := &in.VolumeName, &out.VolumeName
-		*out = new(string)
-		**out = **in
-	}
-	if in.StorageClass != nil {
-		in, out := &in.StorageClass, &out.StorageClass
-		*out = new(string)
-		**out = **in
+	if in.BackendListener != nil {
+		in, out := &in.BackendListener, &out.BackendListener
+		*out = new(ServiceLevelObjectiveSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimBackupDestination.
-func (in *PersistentVolumeClaimBackupDestination) DeepCopy() *PersistentVolumeClaimBackupDestination {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLOSpec.
+func (in *SLOSpec) DeepCopy() *SLOSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistentVolumeClaimBackupDestination)
+	out := new(SLOSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistentVolumeClaimResources) DeepCopyInto(out *PersistentVolumeClaimResources) {
+func (in *ScrapeSpec) DeepCopyInto(out *ScrapeSpec) {
 	*out = *in
-	out.Requests = in.Requests.DeepCopy()
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]RelabelingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DropMetrics != nil {
+		in, out := &in.DropMetrics, &out.DropMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(ScrapeTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimResources.
-func (in *PersistentVolumeClaimResources) DeepCopy() *PersistentVolumeClaimResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeSpec.
+func (in *ScrapeSpec) DeepCopy() *ScrapeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistentVolumeClaimResources)
+	out := new(ScrapeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistentVolumeClaimRestoreSource) DeepCopyInto(out *PersistentVolumeClaimRestoreSource) {
+func (in *ScrapeTLSSpec) DeepCopyInto(out *ScrapeTLSSpec) {
 	*out = *in
-	out.ClaimSource = in.ClaimSource
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertSecret != nil {
+		in, out := &in.CertSecret, &out.CertSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerName != nil {
+		in, out := &in.ServerName, &out.ServerName
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimRestoreSource.
-func (in *PersistentVolumeClaimRestoreSource) DeepCopy() *PersistentVolumeClaimRestoreSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeTLSSpec.
+func (in *ScrapeTLSSpec) DeepCopy() *ScrapeTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistentVolumeClaimRestoreSource)
+	out := new(ScrapeTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+func (in *ServiceLevelObjectiveSpec) DeepCopyInto(out *ServiceLevelObjectiveSpec) {
 	*out = *in
+	if in.AvailabilityTarget != nil {
+		in, out := &in.AvailabilityTarget, &out.AvailabilityTarget
+		*out = new(string)
+		**out = **in
+	}
+	if in.LatencyTarget != nil {
+		in, out := &in.LatencyTarget, &out.LatencyTarget
+		*out = new(string)
+		**out = **in
+	}
+	if in.LatencyThreshold != nil {
+		in, out := &in.LatencyThreshold, &out.LatencyThreshold
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
-func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveSpec.
+func (in *ServiceLevelObjectiveSpec) DeepCopy() *ServiceLevelObjectiveSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodDisruptionBudgetSpec)
+	out := new(ServiceLevelObjectiveSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1148,6 +2458,11 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAppSpec.
@@ -1588,6 +2903,84 @@ func (in *SystemSphinxSpec) DeepCopy() *SystemSphinxSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeApprovalSpec) DeepCopyInto(out *UpgradeApprovalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeApprovalSpec.
+func (in *UpgradeApprovalSpec) DeepCopy() *UpgradeApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicySpec) DeepCopyInto(out *UpgradePolicySpec) {
+	*out = *in
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	if in.AvailabilityTimeout != nil {
+		in, out := &in.AvailabilityTimeout, &out.AvailabilityTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicySpec.
+func (in *UpgradePolicySpec) DeepCopy() *UpgradePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedSteps != nil {
+		in, out := &in.CompletedSteps, &out.CompletedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousImages != nil {
+		in, out := &in.PreviousImages, &out.PreviousImages
+		*out = make([]DeploymentConfigImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityDeadline != nil {
+		in, out := &in.AvailabilityDeadline, &out.AvailabilityDeadline
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZyncAppSpec) DeepCopyInto(out *ZyncAppSpec) {
 	*out = *in
@@ -1613,6 +3006,11 @@ func (in *ZyncAppSpec) DeepCopyInto(out *ZyncAppSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncAppSpec.
@@ -27,11 +27,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/pkg/handlers"
@@ -62,8 +64,10 @@ var _ reconcile.Reconciler = &APIManagerReconciler{}
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/status,verbs=get
 // +kubebuilder:rbac:groups=apps.openshift.io,namespace=placeholder,resources=deploymentconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=podmonitors;servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=products,verbs=get;list;watch
 
 func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	_ = context.Background()
@@ -97,20 +101,43 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		return res, nil
 	}
 
+	res, err = r.reconcileMaintenance(instance)
+	if err != nil {
+		logger.Error(err, "Error reconciling maintenance mode")
+		return ctrl.Result{}, err
+	}
+	if res.Requeue || res.RequeueAfter > 0 {
+		logger.Info("Replicas restored from maintenance mode. Requeueing.")
+		return res, nil
+	}
+
+	if instance.IsReconciliationPaused() {
+		logger.Info("Reconciliation paused by maintenance mode. Only status is reconciled")
+		return r.reconcileAPIManagerStatus(instance)
+	}
+
 	if instance.Annotations[appsv1alpha1.OperatorVersionAnnotation] != version.Version {
 		logger.Info(fmt.Sprintf("Upgrade %s -> %s", instance.Annotations[appsv1alpha1.OperatorVersionAnnotation], version.Version))
-		// TODO add logic to check that only immediate consecutive installs
-		// are possible?
 		res, err := r.upgradeAPIManager(instance)
 		if err != nil {
 			logger.Error(err, "Error upgrading APIManager")
 			return ctrl.Result{}, err
 		}
-		if res.Requeue {
+		if res.Requeue || res.RequeueAfter > 0 {
 			logger.Info("Upgrading not finished. Requeueing.")
 			return res, nil
 		}
 
+		if instance.IsUpgradeAwaitingApproval() {
+			logger.Info(fmt.Sprintf("Upgrade to 3scale release %s waiting for approval. Only status is reconciled", product.ThreescaleRelease))
+			return r.reconcileAPIManagerStatus(instance)
+		}
+
+		if instance.IsUpgradeFailed() {
+			logger.Info(fmt.Sprintf("Upgrade failed. Only status is reconciled until the %s annotation changes", appsv1alpha1.UpgradeRetryAnnotation))
+			return r.reconcileAPIManagerStatus(instance)
+		}
+
 		err = r.updateVersionAnnotations(instance)
 		if err != nil {
 			logger.Error(err, "Error updating annotations")
@@ -140,6 +167,11 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		return statusResult, nil
 	}
 
+	if specResult.Requeue || specResult.RequeueAfter > 0 {
+		logger.Info("Reconciling not finished. Requeueing.")
+		return specResult, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -154,6 +186,12 @@ func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				Logger:    r.Logger().WithName("APIManagerRoutesHandler"),
 			},
 		}).
+		Watches(&source.Kind{Type: &capabilitiesv1beta1.Product{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: &handlers.APIManagerProductsEventMapper{
+				K8sClient: r.Client(),
+				Logger:    r.Logger().WithName("APIManagerProductsHandler"),
+			},
+		}, builder.WithPredicates(handlers.APIManagerProductsPredicate())).
 		Complete(r)
 }
 
@@ -181,8 +219,8 @@ func (r *APIManagerReconciler) updateVersionAnnotations(cr *appsv1alpha1.APIMana
 }
 
 func (r *APIManagerReconciler) upgradeAPIManager(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
-	// The object to instantiate would change in every release of the operator
-	// that upgrades the threescale version
+	// The upgrade paths applied are selected from the 3scale release
+	// annotation of the APIManager
 	upgradeAPIManager := operator.NewUpgradeApiManager(r.BaseReconciler, cr)
 	return upgradeAPIManager.Upgrade()
 }
@@ -217,6 +255,14 @@ func (r *APIManagerReconciler) setAPIManagerDefaults(cr *appsv1alpha1.APIManager
 	return ctrl.Result{Requeue: changed}, err
 }
 
+// reconcileMaintenance scales the non-data components down while the
+// maintenance scale down is enabled and restores them afterwards
+func (r *APIManagerReconciler) reconcileMaintenance(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
+	baseAPIManagerLogicReconciler := operator.NewBaseAPIManagerLogicReconciler(r.BaseReconciler, cr)
+	maintenanceReconciler := operator.NewMaintenanceReconciler(baseAPIManagerLogicReconciler)
+	return maintenanceReconciler.Reconcile()
+}
+
 func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
 	baseAPIManagerLogicReconciler := operator.NewBaseAPIManagerLogicReconciler(r.BaseReconciler, cr)
 	imageReconciler := operator.NewAMPImagesReconciler(baseAPIManagerLogicReconciler)
@@ -236,6 +282,12 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		if err != nil || result.Requeue {
 			return result, err
 		}
+
+		if cr.IsSystemDatabaseRestorePending() {
+			// The rest of the components are deployed once the
+			// APIManagerRestore has loaded the system database dump
+			return reconcile.Result{}, nil
+		}
 	} else {
 		// External databases
 		haReconciler := operator.NewHighAvailabilityReconciler(baseAPIManagerLogicReconciler)
@@ -269,10 +321,11 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
+	// Requeues after a while when the apicast-production canary is analyzed
 	apicastReconciler := operator.NewApicastReconciler(baseAPIManagerLogicReconciler)
-	result, err = apicastReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
+	apicastResult, err := apicastReconciler.Reconcile()
+	if err != nil || apicastResult.Requeue {
+		return apicastResult, err
 	}
 
 	genericMonitoringReconciler := operator.NewGenericMonitoringReconciler(baseAPIManagerLogicReconciler)
@@ -281,7 +334,18 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
-	return ctrl.Result{}, nil
+	// Requeues while a rotation is in progress, or until the next scheduled one
+	databaseCredentialsRotationReconciler := operator.NewDatabaseCredentialsRotationReconciler(baseAPIManagerLogicReconciler)
+	result, err = databaseCredentialsRotationReconciler.Reconcile()
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
+	if apicastResult.RequeueAfter > 0 && (result.RequeueAfter == 0 || apicastResult.RequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = apicastResult.RequeueAfter
+	}
+
+	return result, nil
 }
 
 func (r *APIManagerReconciler) reconcileSystemDatabaseLogic(cr *appsv1alpha1.APIManager, baseAPIManagerLogicReconciler *operator.BaseAPIManagerLogicReconciler) (reconcile.Result, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
@@ -13,6 +14,7 @@ import (
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
 	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -75,19 +77,56 @@ func (s *APIManagerStatusReconciler) calculateStatus() (*appsv1alpha1.APIManager
 
 	newStatus.Conditions = s.apimanagerResource.Status.Conditions.Copy()
 
-	availableCondition, err := s.apimanagerAvailableCondition(deployments)
+	missingRoutes, notAdmittedRoutes, err := s.defaultRoutesStatus()
 	if err != nil {
 		return nil, err
 	}
+
+	availableCondition := s.apimanagerAvailableCondition(deployments, len(missingRoutes) == 0 && len(notAdmittedRoutes) == 0)
 	newStatus.Conditions.SetCondition(availableCondition)
 
+	componentConditions, err := s.componentAvailableConditions(deployments)
+	if err != nil {
+		return nil, err
+	}
+	for _, componentCondition := range componentConditions {
+		newStatus.Conditions.SetCondition(componentCondition)
+	}
+	newStatus.Conditions.SetCondition(s.routesReadyCondition(missingRoutes, notAdmittedRoutes))
+	newStatus.Conditions.SetCondition(s.pausedCondition())
+
 	deploymentStatus := olm.GetDeploymentConfigStatus(deployments)
 	newStatus.Deployments = deploymentStatus
+	newStatus.DeploymentReplicas = deploymentReplicasStatus(deployments)
+
+	// The release is known to be running only when everything is available
+	newStatus.ThreescaleVersion = s.apimanagerResource.Status.ThreescaleVersion
+	if availableCondition.IsTrue() {
+		newStatus.ThreescaleVersion = s.apimanagerResource.Annotations[appsv1alpha1.ThreescaleVersionAnnotation]
+	}
+
+	// Owned by the database credentials rotation reconciler
+	newStatus.DatabaseCredentialsRotation = s.apimanagerResource.Status.DatabaseCredentialsRotation.DeepCopy()
+
+	// Owned by the upgrade
+	newStatus.Upgrade = s.apimanagerResource.Status.Upgrade.DeepCopy()
+	newStatus.AvailableThreescaleVersion = s.apimanagerResource.Status.AvailableThreescaleVersion
+
+	// Owned by the apicast reconciler
+	newStatus.ApicastCanary = s.apimanagerResource.Status.ApicastCanary.DeepCopy()
+
+	images, err := s.imagesStatus()
+	if err != nil {
+		return nil, err
+	}
+	newStatus.Images = images
 
 	return newStatus, nil
 }
 
-func (s *APIManagerStatusReconciler) expectedDeploymentNames(instance *appsv1alpha1.APIManager) []string {
+// apiManagerExpectedDeploymentNames returns the DeploymentConfigs the given
+// APIManager deploys
+func apiManagerExpectedDeploymentNames(instance *appsv1alpha1.APIManager) []string {
 	var systemDatabaseType component.SystemDatabaseType
 	var externalRedisDatabases bool
 	var externalZyncDatabase bool
@@ -109,13 +148,14 @@ func (s *APIManagerStatusReconciler) expectedDeploymentNames(instance *appsv1alp
 		SystemDatabaseType:     systemDatabaseType,
 		ExternalRedisDatabases: externalRedisDatabases,
 		ExternalZyncDatabase:   externalZyncDatabase,
+		ApicastGatewayNames:    instance.ApicastGatewayDeploymentNames(""),
 	}
 
 	return deploymentLister.DeploymentNames()
 }
 
 func (s *APIManagerStatusReconciler) deploymentsAvailable(existingDeployments []appsv1.DeploymentConfig) bool {
-	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
+	expectedDeploymentNames := apiManagerExpectedDeploymentNames(s.apimanagerResource)
 	for _, deploymentName := range expectedDeploymentNames {
 		foundExistingDCIdx := -1
 		for idx, existingDC := range existingDeployments {
@@ -133,7 +173,7 @@ func (s *APIManagerStatusReconciler) deploymentsAvailable(existingDeployments []
 }
 
 func (s *APIManagerStatusReconciler) existingDeployments() ([]appsv1.DeploymentConfig, error) {
-	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
+	expectedDeploymentNames := apiManagerExpectedDeploymentNames(s.apimanagerResource)
 
 	var dcs []appsv1.DeploymentConfig
 	for _, dcName := range expectedDeploymentNames {
@@ -158,14 +198,9 @@ func (s *APIManagerStatusReconciler) existingDeployments() ([]appsv1.DeploymentC
 	return dcs, nil
 }
 
-func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(existingDeployments []appsv1.DeploymentConfig) (common.Condition, error) {
+func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(existingDeployments []appsv1.DeploymentConfig, defaultRoutesReady bool) common.Condition {
 	deploymentsAvailable := s.deploymentsAvailable(existingDeployments)
 
-	defaultRoutesReady, err := s.defaultRoutesReady()
-	if err != nil {
-		return common.Condition{}, err
-	}
-
 	newAvailableCondition := common.Condition{
 		Type:   appsv1alpha1.APIManagerAvailableConditionType,
 		Status: v1.ConditionFalse,
@@ -175,10 +210,191 @@ func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(existingDeploy
 		newAvailableCondition.Status = v1.ConditionTrue
 	}
 
-	return newAvailableCondition, nil
+	return newAvailableCondition
+}
+
+// componentAvailableConditions returns one condition per 3scale component
+// explaining which of its expected DeploymentConfigs are missing or unavailable
+func (s *APIManagerStatusReconciler) componentAvailableConditions(existingDeployments []appsv1.DeploymentConfig) ([]common.Condition, error) {
+	expectedDeploymentNames := apiManagerExpectedDeploymentNames(s.apimanagerResource)
+
+	components := []struct {
+		conditionType   common.ConditionType
+		deploymentNames []string
+	}{
+		{appsv1alpha1.APIManagerApicastStagingAvailableConditionType, append(
+			[]string{component.ApicastStagingName},
+			s.apimanagerResource.ApicastGatewayDeploymentNames(appsv1alpha1.ApicastGatewayEnvironmentStaging)...,
+		)},
+		{appsv1alpha1.APIManagerApicastProductionAvailableConditionType, append(
+			[]string{component.ApicastProductionName},
+			s.apimanagerResource.ApicastGatewayDeploymentNames(appsv1alpha1.ApicastGatewayEnvironmentProduction)...,
+		)},
+		{appsv1alpha1.APIManagerBackendAvailableConditionType, []string{
+			component.BackendListenerName, component.BackendWorkerName, component.BackendCronName,
+		}},
+		{appsv1alpha1.APIManagerSystemAvailableConditionType, []string{
+			component.SystemAppDeploymentName, component.SystemSidekiqName, component.SystemSphinxDeploymentName, component.SystemMemcachedDeploymentName,
+		}},
+		{appsv1alpha1.APIManagerZyncAvailableConditionType, []string{component.ZyncName, component.ZyncQueDeploymentName}},
+		{appsv1alpha1.APIManagerDatabasesAvailableConditionType, []string{
+			component.SystemMySQLDeploymentName, component.SystemPostgreSQLDeploymentName,
+			component.BackendRedisDeploymentName, component.SystemRedisDeploymentName, component.ZyncDatabaseDeploymentName,
+		}},
+	}
+
+	conditions := []common.Condition{}
+	for _, c := range components {
+		// external databases are not deployed
+		deploymentNames := helper.ArrayStringIntersection(c.deploymentNames, expectedDeploymentNames)
+		condition, err := s.componentAvailableCondition(c.conditionType, deploymentNames, existingDeployments)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
 }
 
-func (s *APIManagerStatusReconciler) defaultRoutesReady() (bool, error) {
+func (s *APIManagerStatusReconciler) componentAvailableCondition(conditionType common.ConditionType, deploymentNames []string, existingDeployments []appsv1.DeploymentConfig) (common.Condition, error) {
+	missing := []string{}
+	unavailable := []string{}
+	for _, deploymentName := range deploymentNames {
+		foundExistingDCIdx := -1
+		for idx, existingDC := range existingDeployments {
+			if existingDC.Name == deploymentName {
+				foundExistingDCIdx = idx
+				break
+			}
+		}
+
+		if foundExistingDCIdx == -1 {
+			missing = append(missing, deploymentName)
+			continue
+		}
+
+		existingDC := &existingDeployments[foundExistingDCIdx]
+		if !helper.IsDeploymentConfigAvailable(existingDC) {
+			message, err := s.deploymentUnavailableMessage(existingDC)
+			if err != nil {
+				return common.Condition{}, err
+			}
+			unavailable = append(unavailable, message)
+		}
+	}
+
+	condition := common.Condition{
+		Type:   conditionType,
+		Status: v1.ConditionTrue,
+		Reason: appsv1alpha1.DeploymentsAvailableReason,
+	}
+
+	if len(missing) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.DeploymentsMissingReason
+		condition.Message = fmt.Sprintf("missing DeploymentConfigs: %s", strings.Join(missing, ", "))
+	} else if len(unavailable) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.DeploymentsUnavailableReason
+		condition.Message = strings.Join(unavailable, "; ")
+	}
+
+	return condition, nil
+}
+
+// deploymentUnavailableMessage describes the available replicas of the
+// DeploymentConfig and why its pods are not running, e.g. CrashLoopBackOff
+func (s *APIManagerStatusReconciler) deploymentUnavailableMessage(dc *appsv1.DeploymentConfig) (string, error) {
+	message := fmt.Sprintf("%s: %d/%d replicas available", dc.Name, dc.Status.AvailableReplicas, dc.Spec.Replicas)
+
+	podList := &v1.PodList{}
+	listOps := []client.ListOption{
+		client.InNamespace(s.apimanagerResource.Namespace),
+		client.MatchingLabels{"deploymentConfig": dc.Name},
+	}
+	err := s.Client().List(context.TODO(), podList, listOps...)
+	if err != nil {
+		return "", fmt.Errorf("Failed to list pods: %w", err)
+	}
+
+	reasons := []string{}
+	for _, pod := range podList.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason != "" &&
+				!helper.ArrayContains(reasons, containerStatus.State.Waiting.Reason) {
+				reasons = append(reasons, containerStatus.State.Waiting.Reason)
+			}
+		}
+	}
+
+	if len(reasons) > 0 {
+		sort.Strings(reasons)
+		message = fmt.Sprintf("%s (%s)", message, strings.Join(reasons, ", "))
+	}
+
+	return message, nil
+}
+
+func (s *APIManagerStatusReconciler) routesReadyCondition(missingRoutes, notAdmittedRoutes []string) common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerRoutesReadyConditionType,
+		Status: v1.ConditionTrue,
+		Reason: appsv1alpha1.RoutesAdmittedReason,
+	}
+
+	if len(missingRoutes) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.RoutesMissingReason
+		condition.Message = fmt.Sprintf("missing routes for hosts: %s", strings.Join(missingRoutes, ", "))
+	} else if len(notAdmittedRoutes) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.RoutesNotAdmittedReason
+		condition.Message = fmt.Sprintf("routes not admitted for hosts: %s", strings.Join(notAdmittedRoutes, ", "))
+	}
+
+	return condition
+}
+
+func (s *APIManagerStatusReconciler) pausedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerPausedConditionType,
+		Status: v1.ConditionFalse,
+		Reason: appsv1alpha1.ReconciliationActiveReason,
+	}
+
+	if s.apimanagerResource.IsMaintenanceScaleDownEnabled() {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.ComponentsScaledDownReason
+		condition.Message = "components reconciliation is paused and non-data components are scaled down"
+	} else if s.apimanagerResource.IsReconciliationPaused() {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.ReconciliationPausedReason
+		condition.Message = "components reconciliation is paused"
+	}
+
+	return condition
+}
+
+func deploymentReplicasStatus(deployments []appsv1.DeploymentConfig) []appsv1alpha1.DeploymentConfigReplicasStatus {
+	var result []appsv1alpha1.DeploymentConfigReplicasStatus
+	for _, dc := range deployments {
+		result = append(result, appsv1alpha1.DeploymentConfigReplicasStatus{
+			Name:                dc.Name,
+			Replicas:            dc.Spec.Replicas,
+			UpdatedReplicas:     dc.Status.UpdatedReplicas,
+			ReadyReplicas:       dc.Status.ReadyReplicas,
+			AvailableReplicas:   dc.Status.AvailableReplicas,
+			UnavailableReplicas: dc.Status.UnavailableReplicas,
+		})
+	}
+
+	return result
+}
+
+// defaultRoutesStatus returns the hosts of the default routes that do not
+// exist and the ones that have not been admitted yet
+func (s *APIManagerStatusReconciler) defaultRoutesStatus() ([]string, []string, error) {
 	wildcardDomain := s.apimanagerResource.Spec.WildcardDomain
 	expectedRouteHosts := []string{
 		fmt.Sprintf("backend-%s.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),                // Backend Listener route
@@ -196,25 +412,81 @@ func (s *APIManagerStatusReconciler) defaultRoutesReady() (bool, error) {
 	routeList := &routev1.RouteList{}
 	err := s.Client().List(context.TODO(), routeList, listOps...)
 	if err != nil {
-		return false, fmt.Errorf("Failed to list routes: %w", err)
+		return nil, nil, fmt.Errorf("Failed to list routes: %w", err)
 	}
 
 	routes := append([]routev1.Route(nil), routeList.Items...)
 	sort.Slice(routes, func(i, j int) bool { return routes[i].Name < routes[j].Name })
 
-	allDefaultRoutesReady := true
+	missingRoutes := []string{}
+	notAdmittedRoutes := []string{}
 	for _, expectedRouteHost := range expectedRouteHosts {
 		routeIdx := helper.RouteFindByHost(routes, expectedRouteHost)
 		if routeIdx == -1 {
-			allDefaultRoutesReady = false
+			missingRoutes = append(missingRoutes, expectedRouteHost)
 		} else {
 			matchedRoute := &routes[routeIdx]
 			routeReady := helper.IsRouteReady(matchedRoute)
 			if !routeReady {
-				allDefaultRoutesReady = false
+				notAdmittedRoutes = append(notAdmittedRoutes, expectedRouteHost)
 			}
 		}
 	}
 
-	return allDefaultRoutesReady, nil
+	return missingRoutes, notAdmittedRoutes, nil
+}
+
+// imagesStatus returns the images imported by the ImageStreams of the
+// APIManager, with the digest each of them has been resolved to
+func (s *APIManagerStatusReconciler) imagesStatus() ([]appsv1alpha1.ImageStatus, error) {
+	imageStreamList := &imagev1.ImageStreamList{}
+	err := s.Client().List(context.TODO(), imageStreamList, client.InNamespace(s.apimanagerResource.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list imagestreams: %w", err)
+	}
+
+	var result []appsv1alpha1.ImageStatus
+	for idx := range imageStreamList.Items {
+		imageStream := &imageStreamList.Items[idx]
+		owned := false
+		for _, ownerRef := range imageStream.GetOwnerReferences() {
+			if ownerRef.UID == s.apimanagerResource.UID {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			continue
+		}
+
+		for _, tag := range imageStream.Spec.Tags {
+			if tag.From == nil || tag.From.Kind != "DockerImage" {
+				continue
+			}
+			result = append(result, appsv1alpha1.ImageStatus{
+				Name:   fmt.Sprintf("%s:%s", imageStream.Name, tag.Name),
+				Image:  tag.From.Name,
+				Digest: importedImageDigest(imageStream, &tag),
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// importedImageDigest returns the digest of the image imported for the
+// given tag. Empty while the current tag generation has not been imported
+func importedImageDigest(imageStream *imagev1.ImageStream, tag *imagev1.TagReference) string {
+	for _, tagEvents := range imageStream.Status.Tags {
+		if tagEvents.Tag != tag.Name || len(tagEvents.Items) == 0 {
+			continue
+		}
+		latest := tagEvents.Items[0]
+		if tag.Generation != nil && latest.Generation < *tag.Generation {
+			return ""
+		}
+		return latest.Image
+	}
+	return ""
 }
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func statusTestDeploymentConfig(apimanager *appsv1alpha1.APIManager, name string, available bool) *appsv1.DeploymentConfig {
+	dc := &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: apimanager.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{UID: apimanager.UID, Name: apimanager.Name},
+			},
+		},
+		Spec: appsv1.DeploymentConfigSpec{Replicas: 1},
+		Status: appsv1.DeploymentConfigStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+			},
+		},
+	}
+
+	if !available {
+		dc.Status.ReadyReplicas = 0
+		dc.Status.AvailableReplicas = 0
+		dc.Status.UnavailableReplicas = 1
+		dc.Status.Conditions[0].Status = v1.ConditionFalse
+	}
+
+	return dc
+}
+
+func TestAPIManagerStatusReconcilerComponentConditions(t *testing.T) {
+	tenantName := "3scale"
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-apimanager",
+			Namespace: "someNS",
+			UID:       types.UID("apimanager-uid"),
+			Annotations: map[string]string{
+				appsv1alpha1.ThreescaleVersionAnnotation: "2.10",
+			},
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: "example.com",
+				TenantName:     &tenantName,
+			},
+			System: &appsv1alpha1.SystemSpec{},
+		},
+	}
+
+	objs := []runtime.Object{apimanager}
+	deploymentLister := component.DeploymentsLister{SystemDatabaseType: component.SystemDatabaseTypeInternalMySQL}
+	for _, dcName := range deploymentLister.DeploymentNames() {
+		switch dcName {
+		case component.SystemSidekiqName:
+			objs = append(objs, statusTestDeploymentConfig(apimanager, dcName, false))
+		case component.ZyncQueDeploymentName:
+			// missing
+		default:
+			objs = append(objs, statusTestDeploymentConfig(apimanager, dcName, true))
+		}
+	}
+
+	objs = append(objs, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "system-sidekiq-1-abcde",
+			Namespace: apimanager.Namespace,
+			Labels:    map[string]string{"deploymentConfig": component.SystemSidekiqName},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "system-sidekiq",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				},
+			},
+		},
+	})
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, logf.Log.WithName("status_test"), clientset.Discovery(), record.NewFakeRecorder(100))
+
+	statusReconciler := NewAPIManagerStatusReconciler(baseReconciler, apimanager)
+	newStatus, err := statusReconciler.calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		conditionType   common.ConditionType
+		expectedStatus  v1.ConditionStatus
+		expectedReason  common.ConditionReason
+		expectedMessage string
+	}{
+		{appsv1alpha1.APIManagerAvailableConditionType, v1.ConditionFalse, "", ""},
+		{appsv1alpha1.APIManagerApicastStagingAvailableConditionType, v1.ConditionTrue, appsv1alpha1.DeploymentsAvailableReason, ""},
+		{appsv1alpha1.APIManagerBackendAvailableConditionType, v1.ConditionTrue, appsv1alpha1.DeploymentsAvailableReason, ""},
+		{appsv1alpha1.APIManagerDatabasesAvailableConditionType, v1.ConditionTrue, appsv1alpha1.DeploymentsAvailableReason, ""},
+		{appsv1alpha1.APIManagerSystemAvailableConditionType, v1.ConditionFalse, appsv1alpha1.DeploymentsUnavailableReason,
+			"system-sidekiq: 0/1 replicas available (CrashLoopBackOff)"},
+		{appsv1alpha1.APIManagerZyncAvailableConditionType, v1.ConditionFalse, appsv1alpha1.DeploymentsMissingReason,
+			"missing DeploymentConfigs: zync-que"},
+		{appsv1alpha1.APIManagerRoutesReadyConditionType, v1.ConditionFalse, appsv1alpha1.RoutesMissingReason, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.conditionType), func(subT *testing.T) {
+			condition := newStatus.Conditions.GetCondition(tc.conditionType)
+			if condition == nil {
+				subT.Fatalf("condition %s not found", tc.conditionType)
+			}
+			if condition.Status != tc.expectedStatus {
+				subT.Errorf("expected status %s, got %s", tc.expectedStatus, condition.Status)
+			}
+			if condition.Reason != tc.expectedReason {
+				subT.Errorf("expected reason %s, got %s", tc.expectedReason, condition.Reason)
+			}
+			if tc.expectedMessage != "" && condition.Message != tc.expectedMessage {
+				subT.Errorf("expected message '%s', got '%s'", tc.expectedMessage, condition.Message)
+			}
+		})
+	}
+
+	if len(newStatus.DeploymentReplicas) != len(deploymentLister.DeploymentNames())-1 {
+		t.Errorf("unexpected deployment replicas: %v", newStatus.DeploymentReplicas)
+	}
+	if newStatus.ThreescaleVersion != "" {
+		t.Errorf("threescale version reported while unavailable: %s", newStatus.ThreescaleVersion)
+	}
+}
+
+func TestAPIManagerStatusReconcilerImages(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "someNS", UID: types.UID("apimanager-uid")},
+	}
+	ownerReferences := []metav1.OwnerReference{{UID: apimanager.UID, Name: apimanager.Name}}
+	generation := int64(2)
+	imageStreams := []runtime.Object{
+		&imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "amp-apicast", Namespace: apimanager.Namespace, OwnerReferences: ownerReferences},
+			Spec: imagev1.ImageStreamSpec{
+				Tags: []imagev1.TagReference{
+					{Name: "2.10", From: &v1.ObjectReference{Kind: "DockerImage", Name: "mirror.example.com/3scale/apicast:2.10"}},
+					{Name: "latest", From: &v1.ObjectReference{Kind: "ImageStreamTag", Name: "amp-apicast:2.10"}},
+				},
+			},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{
+					{Tag: "2.10", Items: []imagev1.TagEvent{{Image: "sha256:apicast"}}},
+				},
+			},
+		},
+		&imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "amp-backend", Namespace: apimanager.Namespace, OwnerReferences: ownerReferences},
+			Spec: imagev1.ImageStreamSpec{
+				Tags: []imagev1.TagReference{
+					{Name: "2.10", Generation: &generation, From: &v1.ObjectReference{Kind: "DockerImage", Name: "mirror.example.com/3scale/apisonator:2.10"}},
+				},
+			},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{
+					{Tag: "2.10", Items: []imagev1.TagEvent{{Image: "sha256:previous", Generation: 1}}},
+				},
+			},
+		},
+		&imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: apimanager.Namespace},
+			Spec: imagev1.ImageStreamSpec{
+				Tags: []imagev1.TagReference{
+					{Name: "latest", From: &v1.ObjectReference{Kind: "DockerImage", Name: "quay.io/other:latest"}},
+				},
+			},
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(append(imageStreams, apimanager)...)
+	clientset := fakeclientset.NewSimpleClientset()
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, logf.Log.WithName("status_test"), clientset.Discovery(), record.NewFakeRecorder(100))
+
+	images, err := NewAPIManagerStatusReconciler(baseReconciler, apimanager).imagesStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []appsv1alpha1.ImageStatus{
+		{Name: "amp-apicast:2.10", Image: "mirror.example.com/3scale/apicast:2.10", Digest: "sha256:apicast"},
+		{Name: "amp-backend:2.10", Image: "mirror.example.com/3scale/apisonator:2.10"},
+	}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("unexpected images status: %v", images)
+	}
+}
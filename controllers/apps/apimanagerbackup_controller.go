@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -30,6 +31,8 @@ import (
 // APIManagerBackupReconciler reconciles a APIManagerBackup object
 type APIManagerBackupReconciler struct {
 	*reconcilers.BaseReconciler
+	// PodsGetter reads the logs of the failed backup Jobs
+	PodsGetter typedcorev1.PodsGetter
 }
 
 // blank assignment to verify that ReconcileAPIManagerBackup implements reconcile.Reconciler
@@ -39,6 +42,7 @@ var _ reconcile.Reconciler = &APIManagerBackupReconciler{}
 // +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackups/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 func (r *APIManagerBackupReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -114,5 +118,5 @@ func (r *APIManagerBackupReconciler) setAPIManagerBackupDefaults(cr *appsv1alpha
 }
 
 func (r *APIManagerBackupReconciler) apiManagerBackupLogicReconciler(cr *appsv1alpha1.APIManagerBackup) (*APIManagerBackupLogicReconciler, error) {
-	return NewAPIManagerBackupLogicReconciler(r.BaseReconciler, cr)
+	return NewAPIManagerBackupLogicReconciler(r.BaseReconciler, cr, r.PodsGetter)
 }
@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"fmt"
 	"time"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -14,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubeclock "k8s.io/apimachinery/pkg/util/clock"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -25,16 +28,18 @@ type APIManagerBackupLogicReconciler struct {
 	logger           logr.Logger
 	apiManagerBackup *backup.APIManagerBackup
 	cr               *appsv1alpha1.APIManagerBackup // TODO we use the cr to access and update status fields. Is there an alternative to not depend on status fields?
+	podsGetter       typedcorev1.PodsGetter         // Reads the logs of the failed Jobs
 }
 
-func NewAPIManagerBackupLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerBackup) (*APIManagerBackupLogicReconciler, error) {
+func NewAPIManagerBackupLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerBackup, podsGetter typedcorev1.PodsGetter) (*APIManagerBackupLogicReconciler, error) {
 	res := &APIManagerBackupLogicReconciler{
 		BaseReconciler: b,
 		logger:         b.Logger().WithValues("APIManagerBackup Controller", cr.Name),
 		cr:             cr,
+		podsGetter:     podsGetter,
 	}
 
-	if cr.BackupCompleted() {
+	if cr.BackupCompleted() || cr.BackupFailed() {
 		return res, nil
 	}
 
@@ -68,6 +73,12 @@ func (r *APIManagerBackupLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.BackupFailed() {
+		// Not requeued. Failed backups are not retried
+		r.Logger().Info("Backup failed. End of reconciliation")
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling backup steps")
 		result, err := r.reconcileMainSteps()
@@ -97,7 +108,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileMainSteps() (reconcile.Result
 		return result, err
 	}
 
-	result, err = r.reconcileBackupInPVCDestination()
+	result, err = r.reconcileBackupInDestination()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -138,7 +149,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileSetMainStepsCompleted() (reco
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (reconcile.Result, error) {
+func (r *APIManagerBackupLogicReconciler) reconcileBackupInDestination() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -152,6 +163,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupDestinationS3Status()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileBackupSecretsAndConfigMapsToPVCJob()
 	if res.Requeue || err != nil {
 		return res, err
@@ -167,6 +183,27 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupSystemDatabaseToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupRedisToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupCapabilitiesToPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	// The manifest is written once all the backup data is in the destination
+	res, err = r.reconcileBackupManifestJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	return res, err
 }
 
@@ -194,7 +231,7 @@ func (r *APIManagerBackupLogicReconciler) setOwnerReference(obj common.Kubernete
 	return err
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (reconcile.Result, error) {
+func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job, conditionType common.ConditionType) (reconcile.Result, error) {
 	if err := r.setOwnerReference(desired); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -210,28 +247,80 @@ func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (re
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		r.cr.Status.Conditions.SetCondition(common.Condition{
+			Type:   conditionType,
+			Status: v1.ConditionFalse,
+			Reason: appsv1alpha1.BackupJobRunningReason,
+		})
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 	}
 
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	if failedCondition := jobFailedCondition(existing); failedCondition != nil {
+		return reconcile.Result{Requeue: true}, r.setBackupFailed(existing, failedCondition, conditionType)
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 	}
 
 	r.Logger().Info("Job finished successfully", "Job Name", desired.Name)
+	changed := r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:   conditionType,
+		Status: v1.ConditionTrue,
+		Reason: appsv1alpha1.BackupJobSucceededReason,
+	})
+	if changed {
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
 	return reconcile.Result{}, nil
 }
 
+// setBackupFailed marks the backup and the step performed by the given job
+// as failed. The condition of the step contains the last log lines of the
+// job
+func (r *APIManagerBackupLogicReconciler) setBackupFailed(job *batchv1.Job, failedCondition *batchv1.JobCondition, conditionType common.ConditionType) error {
+	summary := jobFailureSummary(job, failedCondition)
+	message := summary
+	logs, err := jobFailedContainerLogs(r.podsGetter, r.Client(), job)
+	if err != nil {
+		r.Logger().Error(err, "Error reading the logs of the failed Job", "Job Name", job.Name)
+	} else if logs != "" {
+		message = fmt.Sprintf("%s\n%s", summary, logs)
+	}
+
+	r.Logger().Info("Job failed. Backup failed", "Job Name", job.Name, "Reason", failedCondition.Reason)
+	r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "BackupFailed", "%s", summary)
+
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    conditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: message,
+	})
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerBackupFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: summary,
+	})
+	failed := true
+	r.cr.Status.Failed = &failed
+	return r.UpdateResourceStatus(r.cr)
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileBackupSecretsAndConfigMapsToPVCJob() (reconcile.Result, error) {
 	desired := r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupSecretsAndConfigMapsConditionType)
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBackupToPVCJob() (reconcile.Result, error) {
@@ -240,7 +329,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBacku
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupAPIManagerConditionType)
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToPVCJob() (reconcile.Result, error) {
@@ -249,7 +338,67 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToP
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupSystemFileStorageConditionType)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemDatabaseToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemDatabaseToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupSystemDatabaseConditionType)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupRedisToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupRedisToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupRedisConditionType)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupCapabilitiesToPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupCapabilitiesToPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerBackupCapabilitiesConditionType)
+}
+
+func (r *APIManagerBackupLogicReconciler) reconcileBackupManifestJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupManifestJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	res, err := r.reconcileJob(desired, appsv1alpha1.APIManagerBackupManifestConditionType)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	if r.cr.Status.Manifest != nil {
+		return reconcile.Result{}, nil
+	}
+
+	message, err := helper.JobContainerTerminationMessage(r.Client(), desired, backup.BackupManifestContainerName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	summary, err := backup.ParseBackupManifestSummary(message)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if summary == nil {
+		return reconcile.Result{}, fmt.Errorf("Job '%s' did not report the backup manifest summary", desired.Name)
+	}
+
+	r.cr.Status.Manifest = summary
+	err = r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile.Result, error) {
@@ -315,6 +464,20 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationPVCStatus()
 	return reconcile.Result{}, nil
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationS3Status() (reconcile.Result, error) {
+	if r.cr.Spec.BackupDestination.S3 == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if r.cr.Status.BackupS3Location == nil {
+		backupS3Location := r.apiManagerBackup.BackupS3Location()
+		r.cr.Status.BackupS3Location = &backupS3Location
+		err := r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 // Delete all K8s jobs created during the backup. The reason for this is that
 // some PVCs are referenced in the K8s Jobs and those PVCs cannot be deleted
 // while some pods reference them, even if in state Completed. By deleting the
@@ -324,10 +487,17 @@ func (r *APIManagerBackupLogicReconciler) reconcileJobsCleanup() (reconcile.Resu
 		r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob(),
 		r.apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob(),
 		r.apiManagerBackup.BackupSystemFileStoragePVCToPVCJob(),
+		r.apiManagerBackup.BackupSystemDatabaseToPVCJob(),
+		r.apiManagerBackup.BackupRedisToPVCJob(),
+		r.apiManagerBackup.BackupCapabilitiesToPVCJob(),
+		r.apiManagerBackup.BackupManifestJob(),
 	}
 
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclock "k8s.io/apimachinery/pkg/util/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var apimanagerbackupscheduleClock kubeclock.Clock = &kubeclock.RealClock{}
+
+type APIManagerBackupScheduleLogicReconciler struct {
+	*reconcilers.BaseReconciler
+	logger logr.Logger
+	cr     *appsv1alpha1.APIManagerBackupSchedule
+}
+
+func NewAPIManagerBackupScheduleLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerBackupSchedule) *APIManagerBackupScheduleLogicReconciler {
+	return &APIManagerBackupScheduleLogicReconciler{
+		BaseReconciler: b,
+		logger:         b.Logger().WithValues("APIManagerBackupSchedule Controller", cr.Name),
+		cr:             cr,
+	}
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) Logger() logr.Logger {
+	return r.logger
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) Reconcile() (reconcile.Result, error) {
+	schedule, err := helper.ParseCronSchedule(r.cr.Spec.Schedule)
+	if err != nil {
+		// Not requeued. A change in the spec triggers a new reconciliation
+		r.Logger().Error(err, "Invalid schedule")
+		r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "Invalid APIManagerBackupSchedule Spec", "%v", err)
+		return reconcile.Result{}, nil
+	}
+
+	backups, err := r.scheduledBackups()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result, err := r.reconcileExpiredBackups(backups)
+	if result.Requeue || err != nil {
+		return result, err
+	}
+
+	result, err = r.reconcileScheduledBackup(schedule, backups)
+	if result.Requeue || err != nil {
+		return result, err
+	}
+
+	return r.reconcileNextScheduleTime(schedule)
+}
+
+// scheduledBackups returns the APIManagerBackups created by the schedule
+func (r *APIManagerBackupScheduleLogicReconciler) scheduledBackups() ([]appsv1alpha1.APIManagerBackup, error) {
+	backupList := &appsv1alpha1.APIManagerBackupList{}
+	err := r.Client().List(context.TODO(), backupList,
+		client.InNamespace(r.cr.Namespace),
+		client.MatchingLabels{appsv1alpha1.APIManagerBackupScheduleLabel: r.cr.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return backupList.Items, nil
+}
+
+// reconcileScheduledBackup creates the APIManagerBackup of the most recent
+// schedule time that has been reached since the last scheduled backup.
+// Earlier missed schedule times are skipped
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileScheduledBackup(schedule *helper.CronSchedule, backups []appsv1alpha1.APIManagerBackup) (reconcile.Result, error) {
+	if r.cr.IsSuspended() {
+		r.Logger().Info("Schedule suspended. No backups are created")
+		return reconcile.Result{}, nil
+	}
+
+	lastScheduleTime := r.cr.CreationTimestamp.Time
+	if r.cr.Status.LastScheduleTime != nil {
+		lastScheduleTime = r.cr.Status.LastScheduleTime.Time
+	}
+
+	now := apimanagerbackupscheduleClock.Now()
+	var scheduledTime time.Time
+	for t := schedule.Next(lastScheduleTime); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		scheduledTime = t
+	}
+	if scheduledTime.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Backups of the same APIManager are not run concurrently
+	var runningBackupName *string
+	for idx := range backups {
+		if !backups[idx].BackupCompleted() && !backups[idx].BackupFailed() {
+			runningBackupName = &backups[idx].Name
+			break
+		}
+	}
+
+	if runningBackupName != nil {
+		r.Logger().Info("Previous backup has still not finished. Skipping scheduled backup", "Running backup", *runningBackupName, "Scheduled time", scheduledTime)
+		r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "BackupSkipped", "Backup scheduled at %s skipped: backup '%s' has still not finished", scheduledTime.Format(time.RFC3339), *runningBackupName)
+	} else {
+		desired := r.scheduledBackup(scheduledTime)
+		err := controllerutil.SetControllerReference(r.cr, desired, r.Scheme())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		err = r.CreateResource(desired)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return reconcile.Result{}, err
+		}
+		r.Logger().Info("Backup created", "Backup", desired.Name, "Scheduled time", scheduledTime)
+		r.cr.Status.LastBackupName = &desired.Name
+	}
+
+	r.cr.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	err := r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) scheduledBackup(scheduledTime time.Time) *appsv1alpha1.APIManagerBackup {
+	return &appsv1alpha1.APIManagerBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "APIManagerBackup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", r.cr.Name, scheduledTime.UTC().Format("20060102-1504")),
+			Namespace: r.cr.Namespace,
+			Labels: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduleLabel: r.cr.Name,
+			},
+			Annotations: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduledTimeAnnotation: scheduledTime.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: *r.cr.Spec.BackupTemplate.DeepCopy(),
+	}
+}
+
+// reconcileExpiredBackups deletes the backups not kept by the retention
+// rules, together with their backup data
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileExpiredBackups(backups []appsv1alpha1.APIManagerBackup) (reconcile.Result, error) {
+	expiredBackups := backup.ExpiredAPIManagerBackups(backups, r.cr.Spec.Retention)
+
+	pendingDataDeletion := false
+	for idx := range expiredBackups {
+		expired := &expiredBackups[idx]
+		if expired.DeletionTimestamp != nil {
+			continue
+		}
+
+		deleted, err := r.reconcileBackupDataDeletion(expired)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !deleted {
+			pendingDataDeletion = true
+			continue
+		}
+
+		// The Jobs of the backup, including the data deletion one, are
+		// owned by it and are garbage collected with it
+		r.Logger().Info("Deleting expired backup", "Backup", expired.Name)
+		err = r.DeleteResource(expired, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if pendingDataDeletion {
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileBackupDataDeletion deletes the backup data of the given backup.
+// It returns true when the backup data has been deleted
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileBackupDataDeletion(expired *appsv1alpha1.APIManagerBackup) (bool, error) {
+	if expired.Status.BackupPersistentVolumeClaimName != nil {
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      *expired.Status.BackupPersistentVolumeClaimName,
+				Namespace: expired.Namespace,
+			},
+		}
+		r.Logger().Info("Deleting expired backup data", "Backup", expired.Name, "PersistentVolumeClaim", pvc.Name)
+		err := r.DeleteResource(pvc)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	if expired.Spec.BackupDestination.S3 != nil {
+		return r.reconcileS3BackupDataDeletionJob(expired)
+	}
+
+	return true, nil
+}
+
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileS3BackupDataDeletionJob(expired *appsv1alpha1.APIManagerBackup) (bool, error) {
+	s3Options, err := backup.NewAPIManagerBackupOptionsProvider(expired, r.Client()).S3BackupOptions()
+	if err != nil {
+		return false, err
+	}
+
+	desired := backup.DeleteS3BackupDataJob(expired, s3Options)
+	err = controllerutil.SetControllerReference(expired, desired, r.Scheme())
+	if err != nil {
+		return false, err
+	}
+
+	existing := &batchv1.Job{}
+	err = r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if errors.IsNotFound(err) {
+		r.Logger().Info("Deleting expired backup data", "Backup", expired.Name, "S3 location", s3Options.URL())
+		return false, r.CreateResource(desired)
+	}
+
+	if existing.Status.Succeeded != *desired.Spec.Completions {
+		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// reconcileNextScheduleTime keeps the next schedule time in the status and
+// requeues the reconciliation for that time
+func (r *APIManagerBackupScheduleLogicReconciler) reconcileNextScheduleTime(schedule *helper.CronSchedule) (reconcile.Result, error) {
+	now := apimanagerbackupscheduleClock.Now()
+	next := schedule.Next(now)
+	if next.IsZero() || r.cr.IsSuspended() {
+		if r.cr.Status.NextScheduleTime != nil {
+			r.cr.Status.NextScheduleTime = nil
+			return reconcile.Result{}, r.UpdateResourceStatus(r.cr)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if r.cr.Status.NextScheduleTime == nil || !r.cr.Status.NextScheduleTime.Time.Equal(next) {
+		r.cr.Status.NextScheduleTime = &metav1.Time{Time: next}
+		err := r.UpdateResourceStatus(r.cr)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
@@ -29,12 +30,15 @@ import (
 // APIManagerRestoreReconciler reconciles a APIManagerRestore object
 type APIManagerRestoreReconciler struct {
 	*reconcilers.BaseReconciler
+	// PodsGetter reads the logs of the failed restore Jobs
+	PodsGetter typedcorev1.PodsGetter
 }
 
 // +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerrestores,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerrestores/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerrestores/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 func (r *APIManagerRestoreReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -8,8 +8,10 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/restore"
 	"github.com/go-logr/logr"
@@ -22,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 type APIManagerRestoreLogicReconciler struct {
@@ -29,14 +32,16 @@ type APIManagerRestoreLogicReconciler struct {
 	logger            logr.Logger
 	cr                *appsv1alpha1.APIManagerRestore // TODO we use the cr to access and update status fields. Is there an alternative to not depend on status fields?
 	apiManagerRestore *restore.APIManagerRestore
+	podsGetter        typedcorev1.PodsGetter // Reads the logs of the failed Jobs
 }
 
-func NewAPIManagerRestoreLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerRestore, apiManagerRestore *restore.APIManagerRestore) *APIManagerRestoreLogicReconciler {
+func NewAPIManagerRestoreLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv1alpha1.APIManagerRestore, apiManagerRestore *restore.APIManagerRestore, podsGetter typedcorev1.PodsGetter) *APIManagerRestoreLogicReconciler {
 	return &APIManagerRestoreLogicReconciler{
 		BaseReconciler:    b,
 		logger:            b.Logger().WithValues("APIManagerRestore Controller", cr.Name),
 		cr:                cr,
 		apiManagerRestore: apiManagerRestore,
+		podsGetter:        podsGetter,
 	}
 }
 
@@ -50,6 +55,18 @@ func (r *APIManagerRestoreLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.RestoreFailed() {
+		// Not requeued. Failed restores are not retried
+		r.Logger().Info("Restore failed. End of reconciliation")
+		return reconcile.Result{}, nil
+	}
+
+	if r.cr.BackupVerificationFailed() {
+		// Not requeued. Nothing has been restored and nothing will be
+		r.Logger().Info("Backup verification failed. Restore refused", "Reason", *r.cr.Status.BackupVerificationError)
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling restore steps")
 		result, err := r.reconcileMainSteps()
@@ -75,7 +92,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileMainSteps() (reconcile.Resul
 		return result, err
 	}
 
-	result, err = r.reconcileRestoreFromPVCSource()
+	result, err = r.reconcileRestoreFromSource()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -94,6 +111,11 @@ func (r *APIManagerRestoreLogicReconciler) reconcilePostRestoreSteps() (reconcil
 		return result, err
 	}
 
+	result, err = r.reconcileSourceSecretCopiesCleanup()
+	if result.Requeue || err != nil {
+		return result, err
+	}
+
 	result, err = r.reconcileRestoreCompletion()
 	if result.Requeue || err != nil {
 		return result, err
@@ -126,10 +148,21 @@ func (r *APIManagerRestoreLogicReconciler) reconcileStartTimeField() (reconcile.
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reconcile.Result, error) {
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromSource() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
+	res, err = r.reconcileTargetNamespace()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	// The backup data is verified before anything is restored
+	res, err = r.reconcileVerifyBackupJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileRestoreSecretsAndConfigMapsFromPVCJob()
 	if res.Requeue || err != nil {
 		return res, err
@@ -145,11 +178,21 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreRedisFromPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileRestoreAPIManager()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreSystemDatabase()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileWaitForAPIManagerReady()
 	if res.Requeue || err != nil {
 		return res, err
@@ -160,6 +203,11 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreCapabilitiesFromPVCJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileAPIManagerBackupSharedInSecretCleanup()
 	if res.Requeue || err != nil {
 		return res, err
@@ -180,9 +228,13 @@ func (r *APIManagerRestoreLogicReconciler) setOwnerReference(obj common.Kubernet
 	return err
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileJob(desired *batchv1.Job) (reconcile.Result, error) {
-	if err := r.setOwnerReference(desired); err != nil {
-		return reconcile.Result{}, err
+func (r *APIManagerRestoreLogicReconciler) reconcileJob(desired *batchv1.Job, conditionType common.ConditionType) (reconcile.Result, error) {
+	// Owner references cannot point to objects of other namespaces. The Jobs
+	// created in the overridden namespace are deleted by the Jobs cleanup
+	if desired.Namespace == r.cr.Namespace {
+		if err := r.setOwnerReference(desired); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	existing := &batchv1.Job{}
@@ -196,28 +248,264 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJob(desired *batchv1.Job) (r
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		r.cr.Status.Conditions.SetCondition(common.Condition{
+			Type:   conditionType,
+			Status: v1.ConditionFalse,
+			Reason: appsv1alpha1.BackupJobRunningReason,
+		})
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
 	}
 
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	if failedCondition := jobFailedCondition(existing); failedCondition != nil {
+		return reconcile.Result{Requeue: true}, r.setRestoreFailed(existing, failedCondition, conditionType)
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 	}
 
 	r.Logger().Info("Job finished successfully", "Job Name", desired.Name)
+	changed := r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:   conditionType,
+		Status: v1.ConditionTrue,
+		Reason: appsv1alpha1.BackupJobSucceededReason,
+	})
+	if changed {
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
 	return reconcile.Result{}, nil
 }
 
+// setRestoreFailed marks the restore and the step performed by the given job
+// as failed. The condition of the step contains the last log lines of the
+// job
+func (r *APIManagerRestoreLogicReconciler) setRestoreFailed(job *batchv1.Job, failedCondition *batchv1.JobCondition, conditionType common.ConditionType) error {
+	summary := jobFailureSummary(job, failedCondition)
+	message := summary
+	logs, err := jobFailedContainerLogs(r.podsGetter, r.Client(), job)
+	if err != nil {
+		r.Logger().Error(err, "Error reading the logs of the failed Job", "Job Name", job.Name)
+	} else if logs != "" {
+		message = fmt.Sprintf("%s\n%s", summary, logs)
+	}
+
+	r.Logger().Info("Job failed. Restore failed", "Job Name", job.Name, "Reason", failedCondition.Reason)
+	r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "RestoreFailed", "%s", summary)
+
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    conditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: message,
+	})
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerRestoreFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: summary,
+	})
+	failed := true
+	r.cr.Status.Failed = &failed
+	return r.UpdateResourceStatus(r.cr)
+}
+
+// reconcileTargetNamespace verifies the APIManager can be restored into the
+// overridden namespace, and copies into it the restore source secrets read
+// by the restore Jobs created in it. The restore fails, before anything is
+// restored, when the namespace cannot be restored into
+func (r *APIManagerRestoreLogicReconciler) reconcileTargetNamespace() (reconcile.Result, error) {
+	if !r.cr.NamespaceOverridden() {
+		return reconcile.Result{}, nil
+	}
+
+	err := restore.ValidateTargetNamespace(r.cr, helper.GetEnvVar("WATCH_NAMESPACE", ""))
+	if err != nil {
+		r.Logger().Info("Namespace cannot be restored into. Restore failed", "Namespace", r.cr.TargetNamespace(), "Reason", err.Error())
+		r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "RestoreFailed", "%s", err.Error())
+		r.cr.Status.Conditions.SetCondition(common.Condition{
+			Type:    appsv1alpha1.APIManagerRestoreFailedConditionType,
+			Status:  v1.ConditionTrue,
+			Reason:  appsv1alpha1.RestoreNamespaceInvalidReason,
+			Message: err.Error(),
+		})
+		failed := true
+		r.cr.Status.Failed = &failed
+		return reconcile.Result{Requeue: true}, r.UpdateResourceStatus(r.cr)
+	}
+
+	for _, secretName := range restore.SourceSecretNames(r.cr) {
+		secret := &v1.Secret{}
+		err := r.GetResource(types.NamespacedName{Name: secretName, Namespace: r.cr.Namespace}, secret)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		err = r.ReconcileResource(&v1.Secret{}, r.sourceSecretCopy(secretName, secret.Data), reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// sourceSecretCopy returns the copy of the given restore source secret in
+// the namespace the APIManager is restored into
+func (r *APIManagerRestoreLogicReconciler) sourceSecretCopy(secretName string, data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restore.SourceSecretCopyName(r.cr.Name, secretName),
+			Namespace: r.cr.TargetNamespace(),
+			Labels:    map[string]string{restore.SourceSecretCopyLabel: r.cr.Name},
+		},
+		Data: data,
+		Type: v1.SecretTypeOpaque,
+	}
+}
+
+// reconcileSourceSecretCopiesCleanup deletes the copies of the restore
+// source secrets once the restore Jobs have been deleted
+func (r *APIManagerRestoreLogicReconciler) reconcileSourceSecretCopiesCleanup() (reconcile.Result, error) {
+	if !r.cr.NamespaceOverridden() {
+		return reconcile.Result{}, nil
+	}
+
+	for _, secretName := range restore.SourceSecretNames(r.cr) {
+		desired := r.sourceSecretCopy(secretName, nil)
+		common.TagObjectToDelete(desired)
+		err := r.ReconcileResource(&v1.Secret{}, desired, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileVerifyBackupJob() (reconcile.Result, error) {
+	if r.cr.BackupVerified() {
+		return reconcile.Result{}, nil
+	}
+
+	desired := r.apiManagerRestore.VerifyBackupJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.setOwnerReference(desired); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	existing := &batchv1.Job{}
+	err := r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	if errors.IsNotFound(err) {
+		err := r.CreateResource(desired)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		r.cr.Status.Conditions.SetCondition(common.Condition{
+			Type:   appsv1alpha1.APIManagerRestoreBackupVerifiedConditionType,
+			Status: v1.ConditionFalse,
+			Reason: appsv1alpha1.BackupJobRunningReason,
+		})
+		err = r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
+	}
+
+	// The verification job is not retried. A failure means the backup cannot
+	// be restored
+	if failedCondition := jobFailedCondition(existing); existing.Status.Failed > 0 || failedCondition != nil {
+		message, err := helper.JobContainerTerminationMessage(r.Client(), desired, restore.VerifyBackupContainerName)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if message == "" {
+			message = fmt.Sprintf("Job '%s' failed", desired.Name)
+		}
+		err = r.failBackupVerification(message)
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	if existing.Status.Succeeded != *desired.Spec.Completions {
+		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	message, err := helper.JobContainerTerminationMessage(r.Client(), desired, restore.VerifyBackupContainerName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	summary, err := backup.ParseBackupManifestSummary(message)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	verifiedCondition := common.Condition{
+		Type:   appsv1alpha1.APIManagerRestoreBackupVerifiedConditionType,
+		Status: v1.ConditionTrue,
+		Reason: appsv1alpha1.BackupJobSucceededReason,
+	}
+	// Backups without manifest are only accepted when they are allowed
+	if summary == nil {
+		if !r.cr.BackupWithoutManifestAllowed() {
+			err = r.failBackupVerification(backup.ErrBackupManifestNotFound.Error())
+			return reconcile.Result{Requeue: true}, err
+		}
+		r.Logger().Info("Backup manifest not found. Backup data not verified")
+		verifiedCondition.Reason = appsv1alpha1.BackupManifestNotFoundReason
+		verifiedCondition.Message = "Backup manifest not found. Backup data not verified"
+	}
+
+	backupVerified := true
+	r.cr.Status.BackupVerified = &backupVerified
+	r.cr.Status.BackupManifest = summary
+	r.cr.Status.Conditions.SetCondition(verifiedCondition)
+	err = r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
+// failBackupVerification refuses the restore. Nothing has been restored yet
+func (r *APIManagerRestoreLogicReconciler) failBackupVerification(message string) error {
+	r.EventRecorder().Eventf(r.cr, v1.EventTypeWarning, "BackupVerificationFailed", "%s", message)
+	r.cr.Status.BackupVerificationError = &message
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerRestoreBackupVerifiedConditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: message,
+	})
+	r.cr.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerRestoreFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.BackupJobFailedReason,
+		Message: message,
+	})
+	failed := true
+	r.cr.Status.Failed = &failed
+	return r.UpdateResourceStatus(r.cr)
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSecretsAndConfigMapsFromPVCJob() (reconcile.Result, error) {
 	desired := r.apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreSecretsAndConfigMapsConditionType)
 }
 
 func (r *APIManagerRestoreLogicReconciler) reconcileSystemStoragePVC() (reconcile.Result, error) {
@@ -251,7 +539,10 @@ func (r *APIManagerRestoreLogicReconciler) runtimeRestoreInfoFromAPIManager(apim
 		storageClass = apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName
 	}
 	restoreInfo := &restore.RuntimeAPIManagerRestoreInfo{
-		PVCStorageClass: storageClass,
+		PVCStorageClass:        storageClass,
+		SystemDatabaseType:     backup.SystemDatabaseType(apimanager),
+		SystemDatabaseImageURL: backup.SystemDatabaseImageURL(apimanager),
+		InternalRedisDatabases: !apimanager.IsExternalDatabaseEnabled(),
 	}
 	return restoreInfo, nil
 }
@@ -267,12 +558,54 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemFileStoragePVCF
 		return res, err
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreSystemFileStorageConditionType)
+}
+
+// The Redis PVCs are created and populated before the APIManager is restored
+// so the Redis DeploymentConfigs load the backed up data when they are
+// deployed for the first time
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreRedisFromPVCJob() (reconcile.Result, error) {
+	apiManagerToRestoreName := r.cr.Status.APIManagerToRestoreRef.Name
+	err := r.GetResource(types.NamespacedName{Name: apiManagerToRestoreName, Namespace: r.cr.TargetNamespace()}, &appsv1alpha1.APIManager{})
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil {
+		return reconcile.Result{}, nil
+	}
+
+	apimanager, err := r.apiManagerFromSharedBackupSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(apimanager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desired := r.apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo)
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	redis, err := operator.Redis(apimanager, r.Client())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, pvc := range []*v1.PersistentVolumeClaim{redis.BackendPVC(), redis.SystemPVC()} {
+		pvc.Namespace = r.cr.TargetNamespace()
+		err = r.ReconcileResource(&v1.PersistentVolumeClaim{}, pvc, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreRedisConditionType)
 }
 
 func (r *APIManagerRestoreLogicReconciler) systemStoragePVCExists() (bool, error) {
 	pvc := &v1.PersistentVolumeClaim{}
-	err := r.GetResource(types.NamespacedName{Name: component.SystemFileStoragePVCName, Namespace: r.cr.Namespace}, pvc)
+	err := r.GetResource(types.NamespacedName{Name: component.SystemFileStoragePVCName, Namespace: r.cr.TargetNamespace()}, pvc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
@@ -305,7 +638,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManagerInSharedSec
 		return reconcile.Result{}, nil
 	}
 
-	res, err := r.reconcileJob(desired)
+	res, err := r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreAPIManagerConditionType)
 	if res.Requeue || err != nil {
 		return res, err
 	}
@@ -383,7 +716,8 @@ func (r *APIManagerRestoreLogicReconciler) apiManagerFromSharedBackupSecret() (*
 		return nil, fmt.Errorf("%T is not a *appsv1alpha1.APIManager", apimanagerRuntimeObj)
 	}
 
-	apimanager.Namespace = r.cr.Namespace
+	apimanager.Namespace = r.cr.TargetNamespace()
+	restore.ApplyAPIManagerOverrides(apimanager, r.cr.Spec.Overrides)
 
 	return apimanager, nil
 }
@@ -395,7 +729,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManager() (reconci
 	// are after the step that should set this status value
 	apiManagerToRestoreName := r.cr.Status.APIManagerToRestoreRef.Name
 
-	err := r.GetResource(types.NamespacedName{Name: apiManagerToRestoreName, Namespace: r.cr.Namespace}, &appsv1alpha1.APIManager{})
+	err := r.GetResource(types.NamespacedName{Name: apiManagerToRestoreName, Namespace: r.cr.TargetNamespace()}, &appsv1alpha1.APIManager{})
 	if err != nil && !errors.IsNotFound(err) {
 		return reconcile.Result{}, err
 	}
@@ -409,11 +743,71 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManager() (reconci
 		return reconcile.Result{}, err
 	}
 
+	// The internal system database dump has to be loaded before system
+	// starts. Only the databases are deployed until the annotation is removed
+	if !apimanager.IsExternalDatabaseEnabled() {
+		if apimanager.Annotations == nil {
+			apimanager.Annotations = map[string]string{}
+		}
+		apimanager.Annotations[appsv1alpha1.SystemDatabaseRestorePendingAnnotation] = "true"
+	}
+
 	existing := &appsv1alpha1.APIManager{}
 	err = r.ReconcileResource(existing, apimanager, reconcilers.CreateOnlyMutator)
 	return reconcile.Result{}, err
 }
 
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemDatabase() (reconcile.Result, error) {
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.TargetNamespace()}, existingAPIManager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("APIManager not found. Waiting until it exists", "APIManager", r.cr.Status.APIManagerToRestoreRef.Name)
+			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Either the system database is external, the APIManager already existed
+	// or the dump has already been loaded
+	if !existingAPIManager.IsSystemDatabaseRestorePending() {
+		return reconcile.Result{}, nil
+	}
+
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(existingAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desired := r.apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo)
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	systemDatabaseDeploymentName := component.SystemMySQLDeploymentName
+	if restoreInfo.SystemDatabaseType == component.SystemDatabaseTypeInternalPostgreSQL {
+		systemDatabaseDeploymentName = component.SystemPostgreSQLDeploymentName
+	}
+
+	if !helper.ArrayContains(existingAPIManager.Status.Deployments.Ready, systemDatabaseDeploymentName) {
+		r.Logger().Info("System database Deployment not ready. Waiting", "APIManager", existingAPIManager.Name, "Deployment", systemDatabaseDeploymentName)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	res, err := r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreSystemDatabaseConditionType)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	delete(existingAPIManager.Annotations, appsv1alpha1.SystemDatabaseRestorePendingAnnotation)
+	err = r.UpdateResource(existingAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.Logger().Info("System database restored. Resuming APIManager deployment", "APIManager", existingAPIManager.Name)
+	return reconcile.Result{Requeue: true}, nil
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecretCleanup() (reconcile.Result, error) {
 	desiredSecret, err := r.sharedBackupSecret()
 	existingSecret := &v1.Secret{}
@@ -443,7 +837,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecr
 
 func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (reconcile.Result, error) {
 	existingAPIManager := &appsv1alpha1.APIManager{}
-	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.TargetNamespace()}, existingAPIManager)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			r.Logger().Info("APIManager not found. Waiting until it exists", "APIManager", r.cr.Status.APIManagerToRestoreRef.Name)
@@ -452,21 +846,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (re
 		return reconcile.Result{}, err
 	}
 
-	// External databases scenario assumed
-	expectedDeploymentNames := []string{
-		"apicast-production",
-		"apicast-staging",
-		"backend-listener",
-		"backend-worker",
-		"backend-cron",
-		"zync",
-		"zync-que",
-		"zync-database",
-		"system-app",
-		"system-sphinx",
-		"system-sidekiq",
-		"system-memcache",
-	}
+	expectedDeploymentNames := apiManagerExpectedDeploymentNames(existingAPIManager)
 
 	existingReadyDeployments := existingAPIManager.Status.Deployments.Ready
 	sort.Slice(expectedDeploymentNames, func(i, j int) bool { return expectedDeploymentNames[i] < expectedDeploymentNames[j] })
@@ -480,13 +860,24 @@ func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (re
 	return reconcile.Result{}, nil
 }
 
+// Capabilities custom resources are restored once 3scale is ready, so they
+// can be reconciled against the restored 3scale data right away
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreCapabilitiesFromPVCJob() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreCapabilitiesFromPVCJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreCapabilitiesConditionType)
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileResynchronizeZyncDomains() (reconcile.Result, error) {
 	desired := r.apiManagerRestore.ZyncResyncDomainsJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcileJob(desired)
+	return r.reconcileJob(desired, appsv1alpha1.APIManagerRestoreZyncDomainsConditionType)
 }
 
 // Delete all K8s jobs created during the backup. The reason for this is that
@@ -495,14 +886,25 @@ func (r *APIManagerRestoreLogicReconciler) reconcileResynchronizeZyncDomains() (
 // K8s jobs we allow the cleanup to be possible
 func (r *APIManagerRestoreLogicReconciler) reconcileJobsCleanup() (reconcile.Result, error) {
 	jobsToDelete := []*batchv1.Job{
+		r.apiManagerRestore.VerifyBackupJob(),
 		r.apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob(),
 		r.apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
 		r.apiManagerRestore.CreateAPIManagerSharedSecretJob(),
 		r.apiManagerRestore.ZyncResyncDomainsJob(),
+		r.apiManagerRestore.RestoreCapabilitiesFromPVCJob(),
+	}
+
+	restoreInfoBasedJobs, err := r.restoreInfoBasedJobsToCleanup()
+	if err != nil {
+		return reconcile.Result{}, err
 	}
+	jobsToDelete = append(jobsToDelete, restoreInfoBasedJobs...)
 
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -525,3 +927,29 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJobsCleanup() (reconcile.Res
 
 	return reconcile.Result{}, nil
 }
+
+// restoreInfoBasedJobsToCleanup returns the jobs that depend on the restored
+// APIManager configuration
+func (r *APIManagerRestoreLogicReconciler) restoreInfoBasedJobsToCleanup() ([]*batchv1.Job, error) {
+	if r.cr.Status.APIManagerToRestoreRef == nil {
+		return nil, nil
+	}
+
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.TargetNamespace()}, existingAPIManager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	restoreInfo, err := r.runtimeRestoreInfoFromAPIManager(existingAPIManager)
+	if err != nil {
+		return nil, err
+	}
+	return []*batchv1.Job{
+		r.apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo),
+		r.apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo),
+	}, nil
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/pkg/restore"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const restoreTestNamespace = "operator-unittest"
+
+// restoreTestReconciler returns the reconciler of an APIManagerRestore whose
+// backup data has been verified, and whose secrets, configmaps, APIManager
+// and system file storage have already been restored
+func restoreTestReconciler(t *testing.T) (*APIManagerRestoreLogicReconciler, client.Client) {
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	backupVerified := true
+	cr := &appsv1alpha1.APIManagerRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-apimanagerrestore",
+			Namespace: restoreTestNamespace,
+			UID:       types.UID("apimanagerrestore-uid"),
+		},
+		Spec: appsv1alpha1.APIManagerRestoreSpec{
+			RestoreSource: appsv1alpha1.APIManagerRestoreSource{
+				PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimRestoreSource{
+					ClaimSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "example-apimanagerbackup"},
+				},
+			},
+		},
+		Status: appsv1alpha1.APIManagerRestoreStatus{
+			BackupVerified:         &backupVerified,
+			APIManagerToRestoreRef: &v1.LocalObjectReference{Name: "example-apimanager"},
+		},
+	}
+
+	backedUpAPIManager := &appsv1alpha1.APIManager{
+		TypeMeta:   metav1.TypeMeta{APIVersion: appsv1alpha1.GroupVersion.String(), Kind: "APIManager"},
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "backed-up-namespace"},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "example.com"},
+		},
+	}
+	if _, err := backedUpAPIManager.SetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	serializedAPIManager, err := json.Marshal(backedUpAPIManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(cr)
+	if err := cl.Get(context.TODO(), common.ObjectKey(cr), cr); err != nil {
+		t.Fatal(err)
+	}
+	options, err := restore.NewAPIManagerRestoreOptionsProvider(cr, cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiManagerRestore := restore.NewAPIManagerRestore(options)
+
+	objs := []runtime.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: apiManagerRestore.SecretToShareName(), Namespace: restoreTestNamespace},
+			Data:       map[string][]byte{backup.APIManagerSerializedBackupFileName: serializedAPIManager},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: component.SystemFileStoragePVCName, Namespace: restoreTestNamespace},
+		},
+	}
+	for _, job := range []*batchv1.Job{
+		apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob(),
+		apiManagerRestore.CreateAPIManagerSharedSecretJob(),
+		apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
+	} {
+		job.Status.Succeeded = 1
+		objs = append(objs, job)
+	}
+	for _, obj := range objs {
+		if err := cl.Create(context.TODO(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clientset := fakeclientset.NewSimpleClientset()
+	log := logf.Log.WithName("apimanagerrestore_test")
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), record.NewFakeRecorder(10000))
+	return NewAPIManagerRestoreLogicReconciler(baseReconciler, cr, apiManagerRestore, nil), cl
+}
+
+// reconcileRestoreSteps runs the restore steps until they wait for a Job or
+// a Deployment, or until they have all been performed
+func reconcileRestoreSteps(t *testing.T, r *APIManagerRestoreLogicReconciler) reconcile.Result {
+	for i := 0; i < 20; i++ {
+		res, err := r.reconcileRestoreFromSource()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Requeue || res.RequeueAfter > 0 {
+			return res
+		}
+	}
+	t.Fatal("restore steps requeued without waiting")
+	return reconcile.Result{}
+}
+
+func setRestoreTestJobSucceeded(t *testing.T, cl client.Client, job *batchv1.Job) {
+	existing := &batchv1.Job{}
+	if err := cl.Get(context.TODO(), common.ObjectKey(job), existing); err != nil {
+		t.Fatal(err)
+	}
+	existing.Status.Succeeded = 1
+	if err := cl.Update(context.TODO(), existing); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func restoreTestAPIManager(t *testing.T, cl client.Client) *appsv1alpha1.APIManager {
+	apimanager := &appsv1alpha1.APIManager{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: "example-apimanager", Namespace: restoreTestNamespace}, apimanager)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return apimanager
+}
+
+// The Redis DeploymentConfigs load the append only files once, when they are
+// first deployed. The APIManager must not be created before the Redis claims
+// have been restored
+func TestAPIManagerRestoreRedisBeforeAPIManager(t *testing.T) {
+	r, cl := restoreTestReconciler(t)
+	redisJob := r.apiManagerRestore.RestoreRedisFromPVCJob(&restore.RuntimeAPIManagerRestoreInfo{InternalRedisDatabases: true})
+
+	for i := 0; i < 2; i++ {
+		if res := reconcileRestoreSteps(t, r); res.RequeueAfter == 0 {
+			t.Fatalf("restore steps not waiting for the Redis restore: %v", res)
+		}
+
+		for _, pvcName := range []string{component.BackendRedisPVCName, component.SystemRedisPVCName} {
+			pvc := &v1.PersistentVolumeClaim{}
+			if err := cl.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: restoreTestNamespace}, pvc); err != nil {
+				t.Errorf("Redis claim '%s' not created: %v", pvcName, err)
+			}
+		}
+		if err := cl.Get(context.TODO(), common.ObjectKey(redisJob), &batchv1.Job{}); err != nil {
+			t.Fatalf("Redis restore job not created: %v", err)
+		}
+		if apimanager := restoreTestAPIManager(t, cl); apimanager != nil {
+			t.Fatal("APIManager created before the Redis claims have been restored")
+		}
+	}
+
+	setRestoreTestJobSucceeded(t, cl, redisJob)
+	reconcileRestoreSteps(t, r)
+
+	if !r.cr.Status.Conditions.IsTrueFor(appsv1alpha1.APIManagerRestoreRedisConditionType) {
+		t.Errorf("Redis restore condition not set: %v", r.cr.Status.Conditions)
+	}
+	apimanager := restoreTestAPIManager(t, cl)
+	if apimanager == nil {
+		t.Fatal("APIManager not created once the Redis claims have been restored")
+	}
+	if !apimanager.IsSystemDatabaseRestorePending() {
+		t.Error("APIManager created without the system database restore pending")
+	}
+}
+
+// Only the databases of the restored APIManager are deployed while the
+// system database restore is pending. The dump is loaded once the system
+// database is ready, and the rest of the APIManager is deployed afterwards
+func TestAPIManagerRestoreSystemDatabase(t *testing.T) {
+	r, cl := restoreTestReconciler(t)
+	restoreInfo := &restore.RuntimeAPIManagerRestoreInfo{
+		SystemDatabaseType:     component.SystemDatabaseTypeInternalMySQL,
+		InternalRedisDatabases: true,
+	}
+	redisJob := r.apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo)
+	redisJob.Status.Succeeded = 1
+	if err := cl.Create(context.TODO(), redisJob); err != nil {
+		t.Fatal(err)
+	}
+	systemDatabaseJob := r.apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo)
+
+	// The job is not created until the system database is ready
+	if res := reconcileRestoreSteps(t, r); res.RequeueAfter == 0 {
+		t.Fatalf("restore steps not waiting for the system database: %v", res)
+	}
+	if err := cl.Get(context.TODO(), common.ObjectKey(systemDatabaseJob), &batchv1.Job{}); !errors.IsNotFound(err) {
+		t.Fatalf("system database restore job created before the system database is ready: %v", err)
+	}
+
+	apimanager := restoreTestAPIManager(t, cl)
+	apimanager.Status.Deployments.Ready = []string{component.SystemMySQLDeploymentName}
+	if err := cl.Update(context.TODO(), apimanager); err != nil {
+		t.Fatal(err)
+	}
+
+	// The restore stays pending while the job runs
+	if res := reconcileRestoreSteps(t, r); res.RequeueAfter == 0 {
+		t.Fatalf("restore steps not waiting for the system database restore: %v", res)
+	}
+	if err := cl.Get(context.TODO(), common.ObjectKey(systemDatabaseJob), &batchv1.Job{}); err != nil {
+		t.Fatalf("system database restore job not created: %v", err)
+	}
+	if !restoreTestAPIManager(t, cl).IsSystemDatabaseRestorePending() {
+		t.Fatal("system database restore done before the job has finished")
+	}
+
+	setRestoreTestJobSucceeded(t, cl, systemDatabaseJob)
+	// The restore waits for the whole APIManager to be ready afterwards
+	if res := reconcileRestoreSteps(t, r); res.RequeueAfter == 0 {
+		t.Fatalf("restore steps not waiting for the APIManager: %v", res)
+	}
+	if restoreTestAPIManager(t, cl).IsSystemDatabaseRestorePending() {
+		t.Error("system database restore still pending once the job has finished")
+	}
+	if !r.cr.Status.Conditions.IsTrueFor(appsv1alpha1.APIManagerRestoreSystemDatabaseConditionType) {
+		t.Errorf("system database restore condition not set: %v", r.cr.Status.Conditions)
+	}
+}
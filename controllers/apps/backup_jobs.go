@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// jobFailureLogTailLines is the number of log lines of a failed backup or
+// restore Job reported in the condition of its step
+const jobFailureLogTailLines int64 = 20
+
+// jobFailedCondition returns the Failed condition of the job. Nil while the
+// job has not failed. Jobs fail once their backoff limit or active deadline
+// is exceeded
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for idx := range job.Status.Conditions {
+		condition := &job.Status.Conditions[idx]
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+// jobFailureSummary describes the failure of the job in a single line
+func jobFailureSummary(job *batchv1.Job, failedCondition *batchv1.JobCondition) string {
+	return fmt.Sprintf("Job '%s' failed: %s: %s", job.Name, failedCondition.Reason, failedCondition.Message)
+}
+
+// jobFailedContainerLogs returns the last log lines of the failed container
+// of the most recent failed pod of the job. Empty when there is no failed
+// container, i.e. when the job has been terminated by its active deadline
+func jobFailedContainerLogs(podsGetter typedcorev1.PodsGetter, k8sClient client.Client, job *batchv1.Job) (string, error) {
+	if podsGetter == nil {
+		return "", nil
+	}
+
+	podList := &v1.PodList{}
+	err := k8sClient.List(context.TODO(), podList,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	failedPod, containerName := jobFailedContainer(podList.Items)
+	if failedPod == nil {
+		return "", nil
+	}
+
+	tailLines := jobFailureLogTailLines
+	logs, err := podsGetter.Pods(failedPod.Namespace).GetLogs(failedPod.Name, &v1.PodLogOptions{
+		Container: containerName,
+		TailLines: &tailLines,
+	}).DoRaw(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Last log lines of container '%s' of pod '%s':\n%s", containerName, failedPod.Name, strings.TrimSpace(string(logs))), nil
+}
+
+// jobFailedContainer returns the most recent failed pod of the given job
+// pods and the name of its failed container. Nil when there is no failed
+// container
+func jobFailedContainer(pods []v1.Pod) (*v1.Pod, string) {
+	var failedPod *v1.Pod
+	for idx := range pods {
+		pod := &pods[idx]
+		if pod.Status.Phase != v1.PodFailed {
+			continue
+		}
+		if failedPod == nil || failedPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			failedPod = pod
+		}
+	}
+	if failedPod == nil {
+		return nil, ""
+	}
+
+	// Init containers run first, so the first failed container is the one
+	// that made the pod fail
+	statuses := append(failedPod.Status.InitContainerStatuses, failedPod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return failedPod, status.Name
+		}
+	}
+
+	return nil, ""
+}
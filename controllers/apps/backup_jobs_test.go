@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func backupTestJobPod(name string, creationTime metav1.Time, phase v1.PodPhase, exitCode int32) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "operator-unittest",
+			Labels:            map[string]string{"job-name": "backup-job"},
+			CreationTimestamp: creationTime,
+		},
+		Status: v1.PodStatus{
+			Phase: phase,
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "init", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "backup", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode}}},
+			},
+		},
+	}
+}
+
+func TestBackupJobFailure(t *testing.T) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-job", Namespace: "operator-unittest"},
+	}
+	if jobFailedCondition(job) != nil {
+		t.Fatal("job without conditions reported as failed")
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"},
+	}
+	failedCondition := jobFailedCondition(job)
+	if failedCondition == nil {
+		t.Fatal("failed job not reported as failed")
+	}
+	summary := jobFailureSummary(job, failedCondition)
+	if summary != "Job 'backup-job' failed: BackoffLimitExceeded: Job has reached the specified backoff limit" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+
+	older := metav1.Unix(1000, 0)
+	newer := metav1.Unix(2000, 0)
+	pods := []v1.Pod{
+		*backupTestJobPod("backup-job-old", older, v1.PodFailed, 1),
+		*backupTestJobPod("backup-job-new", newer, v1.PodFailed, 2),
+		*backupTestJobPod("backup-job-running", metav1.Unix(3000, 0), v1.PodRunning, 0),
+	}
+	failedPod, containerName := jobFailedContainer(pods)
+	if failedPod == nil || failedPod.Name != "backup-job-new" || containerName != "backup" {
+		t.Errorf("unexpected failed container: %v, %s", failedPod, containerName)
+	}
+
+	// Jobs terminated by their active deadline have no failed container
+	failedPod, _ = jobFailedContainer([]v1.Pod{*backupTestJobPod("backup-job", newer, v1.PodFailed, 0)})
+	if failedPod != nil {
+		t.Errorf("unexpected failed pod: %s", failedPod.Name)
+	}
+
+	// Logs are not read without pods getter
+	logs, err := jobFailedContainerLogs(nil, fake.NewFakeClient(), job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs != "" {
+		t.Errorf("unexpected logs: %s", logs)
+	}
+}
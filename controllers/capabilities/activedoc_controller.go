@@ -80,6 +80,11 @@ func (r *ActiveDocReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, activeDocCR, &activeDocCR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	if activeDocCR.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), activeDocCR)
 		if err != nil {
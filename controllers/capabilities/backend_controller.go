@@ -80,6 +80,11 @@ func (r *BackendReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, backend, &backend.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	if backend.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), backend)
 		if err != nil {
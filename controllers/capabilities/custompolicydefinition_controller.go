@@ -79,6 +79,11 @@ func (r *CustomPolicyDefinitionReconciler) Reconcile(req ctrl.Request) (ctrl.Res
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, customPolicyDefinitionCR, &customPolicyDefinitionCR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	statusReconciler, reconcileErr := r.reconcileSpec(customPolicyDefinitionCR, reqLogger)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
@@ -80,6 +80,11 @@ func (r *DeveloperAccountReconciler) Reconcile(req ctrl.Request) (ctrl.Result, e
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, developerAccountCR, &developerAccountCR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	statusReconciler, reconcileErr := r.reconcileSpec(developerAccountCR, reqLogger)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
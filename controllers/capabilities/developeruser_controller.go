@@ -80,6 +80,11 @@ func (r *DeveloperUserReconciler) Reconcile(req ctrl.Request) (ctrl.Result, erro
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, developerUserCR, &developerUserCR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	statusReconciler, reconcileErr := r.reconcileSpec(developerUserCR, reqLogger)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
@@ -84,6 +84,11 @@ func (r *OpenAPIReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, openapiCR, &openapiCR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	if openapiCR.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), openapiCR)
 		if err != nil {
@@ -80,6 +80,11 @@ func (r *ProductReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client(), reqLogger, product, &product.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	if product.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), product)
 		if err != nil {
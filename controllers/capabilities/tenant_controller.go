@@ -73,6 +73,11 @@ func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
+	// Restore the 3scale IDs of resources recreated by an APIManagerRestore
+	if res, err := controllerhelper.ReconcileBackupStatus(r.Client, reqLogger, tenantR, &tenantR.Status); err != nil || res.Requeue {
+		return res, err
+	}
+
 	changed := tenantR.SetDefaults()
 	if changed {
 		err = r.Client.Update(context.TODO(), tenantR)
@@ -33,6 +33,7 @@ import (
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -45,6 +46,7 @@ import (
 	appscontroller "github.com/3scale/3scale-operator/controllers/apps"
 	capabilitiescontroller "github.com/3scale/3scale-operator/controllers/capabilities"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	backupagent "github.com/3scale/3scale-operator/pkg/backup/agent"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	// +kubebuilder:scaffold:imports
@@ -72,6 +74,15 @@ func init() {
 }
 
 func main() {
+	// The backup and restore Jobs run the backup agent using the operator image
+	if len(os.Args) > 1 && os.Args[1] == backupagent.CommandName {
+		if err := backupagent.Run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 
@@ -131,12 +142,19 @@ func main() {
 		setupLog.Error(err, "unable to create discovery client")
 		os.Exit(1)
 	}
+	// The logs of the failed backup and restore Jobs are reported in their status
+	kubernetesClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes client")
+		os.Exit(1)
+	}
 	if err = (&appscontroller.APIManagerBackupReconciler{
 		BaseReconciler: reconcilers.NewBaseReconciler(
 			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
 			ctrl.Log.WithName("controllers").WithName("APIManagerBackup"),
 			discoveryClientAPIManagerBackup,
 			mgr.GetEventRecorderFor("APIManagerBackup")),
+		PodsGetter: kubernetesClient.CoreV1(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "APIManagerBackup")
 		os.Exit(1)
@@ -153,11 +171,28 @@ func main() {
 			ctrl.Log.WithName("controllers").WithName("APIManagerRestore"),
 			discoveryClientAPIManagerRestore,
 			mgr.GetEventRecorderFor("APIManagerRestore")),
+		PodsGetter: kubernetesClient.CoreV1(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "APIManagerRestore")
 		os.Exit(1)
 	}
 
+	discoveryClientAPIManagerBackupSchedule, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&appscontroller.APIManagerBackupScheduleReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+			discoveryClientAPIManagerBackupSchedule,
+			mgr.GetEventRecorderFor("APIManagerBackupSchedule")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "APIManagerBackupSchedule")
+		os.Exit(1)
+	}
+
 	if err = (&capabilitiescontroller.TenantReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("Tenant"),
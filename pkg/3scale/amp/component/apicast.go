@@ -20,6 +20,10 @@ import (
 const (
 	ApicastStagingName    = "apicast-staging"
 	ApicastProductionName = "apicast-production"
+	// ApicastCanaryLabelKey labels the apicast-production canary pods. They
+	// keep the apicast-production deploymentConfig label to receive traffic
+	// from its service
+	ApicastCanaryLabelKey = "threescale_component_canary"
 
 	CustomPoliciesMountBasePath               = "/opt/app-root/src/policies"
 	CustomPoliciesAnnotationNameSegmentPrefix = "apicast-policy-volume"
@@ -31,6 +35,8 @@ const (
 
 	HTTPSCertificatesMountPath  = "/var/run/secrets/tls"
 	HTTPSCertificatesVolumeName = "https-certificates"
+
+	ApicastMetricsPort = 9421
 )
 
 const (
@@ -41,6 +47,11 @@ const (
 	APIcastTracingConfigAnnotationPartialKey        = "apps.3scale.net/" + APIcastTracingConfigAnnotationNameSegmentPrefix
 )
 
+const (
+	APIcastStagingOpenTelemetryConfigSecretName    = "apicast-staging-opentelemetry"
+	APIcastProductionOpenTelemetryConfigSecretName = "apicast-production-opentelemetry"
+)
+
 type Apicast struct {
 	Options *ApicastOptions
 }
@@ -326,6 +337,8 @@ func (apicast *Apicast) buildApicastStagingEnv() []v1.EnvVar {
 		}
 	}
 
+	result = append(result, apicast.openTelemetryEnv(ApicastStagingName, apicast.Options.StagingOpenTelemetry)...)
+
 	var customEnvPaths []string
 	for _, customEnvSecret := range apicast.Options.StagingCustomEnvironments {
 		for fileKey := range customEnvSecret.Data {
@@ -397,6 +410,8 @@ func (apicast *Apicast) buildApicastProductionEnv() []v1.EnvVar {
 		}
 	}
 
+	result = append(result, apicast.openTelemetryEnv(ApicastProductionName, apicast.Options.ProductionOpenTelemetry)...)
+
 	var customEnvPaths []string
 	for _, customEnvSecret := range apicast.Options.ProductionCustomEnvironments {
 		for fileKey := range customEnvSecret.Data {
@@ -462,6 +477,96 @@ func (apicast *Apicast) EnvironmentConfigMap() *v1.ConfigMap {
 	}
 }
 
+// StagingOpenTelemetryConfigSecret returns the secret holding the
+// OpenTelemetry configuration file of apicast-staging
+func (apicast *Apicast) StagingOpenTelemetryConfigSecret() *v1.Secret {
+	return apicast.openTelemetryConfigSecret(APIcastStagingOpenTelemetryConfigSecretName, ApicastStagingName,
+		apicast.Options.StagingOpenTelemetry, apicast.Options.CommonStagingLabels)
+}
+
+// ProductionOpenTelemetryConfigSecret returns the secret holding the
+// OpenTelemetry configuration file of apicast-production
+func (apicast *Apicast) ProductionOpenTelemetryConfigSecret() *v1.Secret {
+	return apicast.openTelemetryConfigSecret(APIcastProductionOpenTelemetryConfigSecretName, ApicastProductionName,
+		apicast.Options.ProductionOpenTelemetry, apicast.Options.CommonProductionLabels)
+}
+
+// openTelemetryConfigSecret returns the configuration secret of the given
+// OpenTelemetry configuration. The secret has no data when the configuration
+// is disabled
+func (apicast *Apicast) openTelemetryConfigSecret(name, serviceName string, config *OpenTelemetryConfig, labels map[string]string) *v1.Secret {
+	stringData := map[string]string{}
+	if config != nil {
+		stringData[APIcastOpenTelemetryConfigSecretKey] = apicastOpenTelemetryConfig(serviceName, config)
+	}
+
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		StringData: stringData,
+		Type:       v1.SecretTypeOpaque,
+	}
+}
+
+func (apicast *Apicast) openTelemetryEnv(serviceName string, config *OpenTelemetryConfig) []v1.EnvVar {
+	if config == nil {
+		return nil
+	}
+
+	result := []v1.EnvVar{
+		helper.EnvVarFromValue("OPENTELEMETRY", "1"),
+		helper.EnvVarFromValue("OPENTELEMETRY_CONFIG", path.Join(APIcastOpenTelemetryConfigMountPath, APIcastOpenTelemetryConfigSecretKey)),
+	}
+	// The OTEL_* env vars mirror the configuration file, so that changing the
+	// configuration rolls out the gateway
+	result = append(result, openTelemetryEnv(serviceName, config)...)
+
+	return result
+}
+
+func (apicast *Apicast) openTelemetryVolumeMounts(config *OpenTelemetryConfig) []v1.VolumeMount {
+	if config == nil {
+		return nil
+	}
+
+	volumeMounts := []v1.VolumeMount{
+		{
+			Name:      APIcastOpenTelemetryConfigVolumeName,
+			MountPath: APIcastOpenTelemetryConfigMountPath,
+			ReadOnly:  true,
+		},
+	}
+	volumeMounts = append(volumeMounts, openTelemetryTLSVolumeMounts(config)...)
+
+	return volumeMounts
+}
+
+func (apicast *Apicast) openTelemetryVolumes(secretName string, config *OpenTelemetryConfig) []v1.Volume {
+	if config == nil {
+		return nil
+	}
+
+	volumes := []v1.Volume{
+		{
+			Name: APIcastOpenTelemetryConfigVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		},
+	}
+	volumes = append(volumes, openTelemetryTLSVolumes(config)...)
+
+	return volumes
+}
+
 func (apicast *Apicast) StagingPodDisruptionBudget() *v1beta1.PodDisruptionBudget {
 	return &v1beta1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -492,8 +597,13 @@ func (apicast *Apicast) ProductionPodDisruptionBudget() *v1beta1.PodDisruptionBu
 			Labels: apicast.Options.CommonProductionLabels,
 		},
 		Spec: v1beta1.PodDisruptionBudgetSpec{
+			// The canary pods are not part of the budget. Evicting them only
+			// delays the canary analysis
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{"deploymentConfig": ApicastProductionName},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: ApicastCanaryLabelKey, Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
 			},
 			MaxUnavailable: &intstr.IntOrString{IntVal: PDB_MAX_UNAVAILABLE_POD_NUMBER},
 		},
@@ -518,6 +628,8 @@ func (apicast *Apicast) productionVolumeMounts() []v1.VolumeMount {
 		})
 	}
 
+	volumeMounts = append(volumeMounts, apicast.openTelemetryVolumeMounts(apicast.Options.ProductionOpenTelemetry)...)
+
 	for _, customEnvSecret := range apicast.Options.ProductionCustomEnvironments {
 		volumeMounts = append(volumeMounts, v1.VolumeMount{
 			Name:      customEnvVolumeName(customEnvSecret),
@@ -555,6 +667,8 @@ func (apicast *Apicast) stagingVolumeMounts() []v1.VolumeMount {
 		})
 	}
 
+	volumeMounts = append(volumeMounts, apicast.openTelemetryVolumeMounts(apicast.Options.StagingOpenTelemetry)...)
+
 	for _, customEnvSecret := range apicast.Options.StagingCustomEnvironments {
 		volumeMounts = append(volumeMounts, v1.VolumeMount{
 			Name:      customEnvVolumeName(customEnvSecret),
@@ -621,6 +735,8 @@ func (apicast *Apicast) productionVolumes() []v1.Volume {
 		})
 	}
 
+	volumes = append(volumes, apicast.openTelemetryVolumes(APIcastProductionOpenTelemetryConfigSecretName, apicast.Options.ProductionOpenTelemetry)...)
+
 	for _, customEnvSecret := range apicast.Options.ProductionCustomEnvironments {
 		volumes = append(volumes, v1.Volume{
 			Name: customEnvVolumeName(customEnvSecret),
@@ -677,6 +793,8 @@ func (apicast *Apicast) stagingVolumes() []v1.Volume {
 		})
 	}
 
+	volumes = append(volumes, apicast.openTelemetryVolumes(APIcastStagingOpenTelemetryConfigSecretName, apicast.Options.StagingOpenTelemetry)...)
+
 	for _, customEnvSecret := range apicast.Options.StagingCustomEnvironments {
 		volumes = append(volumes, v1.Volume{
 			Name: customEnvVolumeName(customEnvSecret),
@@ -754,7 +872,7 @@ func (apicast *Apicast) productionContainerPorts() []v1.ContainerPort {
 	ports := []v1.ContainerPort{
 		v1.ContainerPort{ContainerPort: 8080, Protocol: v1.ProtocolTCP},
 		v1.ContainerPort{ContainerPort: 8090, Protocol: v1.ProtocolTCP},
-		v1.ContainerPort{ContainerPort: 9421, Protocol: v1.ProtocolTCP, Name: "metrics"},
+		v1.ContainerPort{ContainerPort: ApicastMetricsPort, Protocol: v1.ProtocolTCP, Name: "metrics"},
 	}
 
 	if apicast.Options.ProductionHTTPSPort != nil {
@@ -784,7 +902,7 @@ func (apicast *Apicast) stagingContainerPorts() []v1.ContainerPort {
 	ports := []v1.ContainerPort{
 		v1.ContainerPort{ContainerPort: 8080, Protocol: v1.ProtocolTCP},
 		v1.ContainerPort{ContainerPort: 8090, Protocol: v1.ProtocolTCP},
-		v1.ContainerPort{ContainerPort: 9421, Protocol: v1.ProtocolTCP, Name: "metrics"},
+		v1.ContainerPort{ContainerPort: ApicastMetricsPort, Protocol: v1.ProtocolTCP, Name: "metrics"},
 	}
 
 	if apicast.Options.StagingHTTPSPort != nil {
@@ -0,0 +1,411 @@
+package component
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ApicastGatewayLabelKey labels the objects of the additional APIcast gateways
+	ApicastGatewayLabelKey = "threescale_component_gateway"
+	// ApicastGatewayPortalEndpointSecretKey is the key of the gateway portal endpoint secret
+	ApicastGatewayPortalEndpointSecretKey = "THREESCALE_PORTAL_ENDPOINT"
+)
+
+// ApicastGatewayName returns the DeploymentConfig and Service name of the additional APIcast gateway
+func ApicastGatewayName(name string) string {
+	return fmt.Sprintf("apicast-%s", name)
+}
+
+func (apicast *Apicast) GatewayService(gateway *ApicastGatewayOptions) *v1.Service {
+	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   gateway.DeploymentConfigName(),
+			Labels: gateway.CommonLabels,
+		},
+		Spec: v1.ServiceSpec{
+			Ports:    apicastServicePorts(gateway.HTTPSPort),
+			Selector: map[string]string{"deploymentConfig": gateway.DeploymentConfigName()},
+		},
+	}
+}
+
+func (apicast *Apicast) GatewayDeploymentConfig(gateway *ApicastGatewayOptions) *appsv1.DeploymentConfig {
+	containerNames := []string{gateway.DeploymentConfigName()}
+	var initContainers []v1.Container
+	// production gateways wait for system-master to be able to boot the configuration
+	if gateway.IsProduction() {
+		containerNames = append([]string{"system-master-svc"}, containerNames...)
+		initContainers = []v1.Container{
+			v1.Container{
+				Name:    "system-master-svc",
+				Image:   "amp-apicast:latest",
+				Command: []string{"sh", "-c", "until $(curl --output /dev/null --silent --fail --head http://system-master:3000/status); do sleep $SLEEP_SECONDS; done"},
+				Env: []v1.EnvVar{
+					v1.EnvVar{
+						Name:  "SLEEP_SECONDS",
+						Value: "1",
+					},
+				},
+			},
+		}
+	}
+
+	return &appsv1.DeploymentConfig{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps.openshift.io/v1", Kind: "DeploymentConfig"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        gateway.DeploymentConfigName(),
+			Labels:      gateway.CommonLabels,
+			Annotations: apicastDeploymentConfigAnnotations(gateway.CustomPolicies, gateway.TracingConfig, gateway.CustomEnvironments),
+		},
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: gateway.Replicas,
+			Selector: map[string]string{
+				"deploymentConfig": gateway.DeploymentConfigName(),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				RollingParams: &appsv1.RollingDeploymentStrategyParams{
+					IntervalSeconds: &[]int64{1}[0],
+					MaxSurge: &intstr.IntOrString{
+						Type:   intstr.Type(intstr.String),
+						StrVal: "25%",
+					},
+					MaxUnavailable: &intstr.IntOrString{
+						Type:   intstr.Type(intstr.String),
+						StrVal: "25%",
+					},
+					TimeoutSeconds:      &[]int64{1800}[0],
+					UpdatePeriodSeconds: &[]int64{1}[0],
+				},
+				Type: appsv1.DeploymentStrategyTypeRolling,
+			},
+			Triggers: appsv1.DeploymentTriggerPolicies{
+				appsv1.DeploymentTriggerPolicy{
+					Type: appsv1.DeploymentTriggerOnConfigChange,
+				},
+				appsv1.DeploymentTriggerPolicy{
+					Type: appsv1.DeploymentTriggerOnImageChange,
+					ImageChangeParams: &appsv1.DeploymentTriggerImageChangeParams{
+						Automatic:      true,
+						ContainerNames: containerNames,
+						From: v1.ObjectReference{
+							Kind: "ImageStreamTag",
+							Name: fmt.Sprintf("amp-apicast:%s", apicast.Options.ImageTag),
+						},
+					},
+				},
+			},
+			Template: &v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: gateway.PodTemplateLabels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "9421",
+					},
+				},
+				Spec: v1.PodSpec{
+					Affinity:           gateway.Affinity,
+					Tolerations:        gateway.Tolerations,
+					ServiceAccountName: "amp",
+					Volumes:            apicastVolumes(gateway.CustomPolicies, gateway.TracingConfig, gateway.CustomEnvironments, gateway.HTTPSCertificateSecretName),
+					InitContainers:     initContainers,
+					Containers: []v1.Container{
+						v1.Container{
+							Ports:           apicastContainerPorts(gateway.HTTPSPort),
+							Env:             apicast.buildApicastGatewayEnv(gateway),
+							Image:           "amp-apicast:latest",
+							ImagePullPolicy: v1.PullIfNotPresent,
+							Name:            gateway.DeploymentConfigName(),
+							Resources:       gateway.ResourceRequirements,
+							VolumeMounts:    apicastVolumeMounts(gateway.CustomPolicies, gateway.TracingConfig, gateway.CustomEnvironments, gateway.HTTPSCertificateSecretName),
+							LivenessProbe: &v1.Probe{
+								Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{
+									Path: "/status/live",
+									Port: intstr.FromInt(8090),
+								}},
+								InitialDelaySeconds: 10,
+								TimeoutSeconds:      5,
+								PeriodSeconds:       10,
+							},
+							ReadinessProbe: &v1.Probe{
+								Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{
+									Path: "/status/ready",
+									Port: intstr.FromInt(8090),
+								}},
+								InitialDelaySeconds: 15,
+								TimeoutSeconds:      5,
+								PeriodSeconds:       30,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (apicast *Apicast) GatewayPodDisruptionBudget(gateway *ApicastGatewayOptions) *v1beta1.PodDisruptionBudget {
+	return &v1beta1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   gateway.DeploymentConfigName(),
+			Labels: gateway.CommonLabels,
+		},
+		Spec: v1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"deploymentConfig": gateway.DeploymentConfigName()},
+			},
+			MaxUnavailable: &intstr.IntOrString{IntVal: PDB_MAX_UNAVAILABLE_POD_NUMBER},
+		},
+	}
+}
+
+func (apicast *Apicast) buildApicastGatewayEnv(gateway *ApicastGatewayOptions) []v1.EnvVar {
+	result := []v1.EnvVar{}
+	result = append(result, apicast.buildApicastCommonEnv()...)
+
+	// The gateway may load the configuration from a specific portal endpoint
+	if gateway.PortalEndpointSecretName != nil {
+		idx := helper.FindEnvVar(result, "THREESCALE_PORTAL_ENDPOINT")
+		result[idx] = helper.EnvVarFromSecret("THREESCALE_PORTAL_ENDPOINT", *gateway.PortalEndpointSecretName, ApicastGatewayPortalEndpointSecretKey)
+	}
+
+	if gateway.IsProduction() {
+		result = append(result,
+			helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", "boot"),
+			helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "300"),
+			helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "production"),
+		)
+	} else {
+		result = append(result,
+			helper.EnvVarFromValue("APICAST_CONFIGURATION_LOADER", "lazy"),
+			helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "0"),
+			helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "staging"),
+		)
+	}
+
+	if gateway.Workers != nil {
+		result = append(result, helper.EnvVarFromValue("APICAST_WORKERS", strconv.Itoa(int(*gateway.Workers))))
+	}
+	if gateway.LogLevel != nil {
+		result = append(result, helper.EnvVarFromValue("APICAST_LOG_LEVEL", *gateway.LogLevel))
+	}
+
+	if gateway.TracingConfig.Enabled {
+		result = append(result, helper.EnvVarFromValue("OPENTRACING_TRACER", gateway.TracingConfig.TracingLibrary))
+
+		if gateway.TracingConfig.TracingConfigSecretName != nil {
+			result = append(result,
+				helper.EnvVarFromValue("OPENTRACING_CONFIG", path.Join(APIcastTracingConfigMountBasePath, gateway.TracingConfig.VolumeName())))
+		}
+	}
+
+	var customEnvPaths []string
+	for _, customEnvSecret := range gateway.CustomEnvironments {
+		for fileKey := range customEnvSecret.Data {
+			customEnvPaths = append(customEnvPaths, path.Join(CustomEnvironmentsMountBasePath, customEnvSecret.GetName(), fileKey))
+		}
+	}
+
+	if len(customEnvPaths) > 0 {
+		// Sort customenvPaths to ensure deterministic reconciliation
+		sort.Strings(customEnvPaths)
+		result = append(result, helper.EnvVarFromValue("APICAST_ENVIRONMENT", strings.Join(customEnvPaths, ":")))
+	}
+
+	if gateway.HTTPSPort != nil {
+		result = append(result, helper.EnvVarFromValue("APICAST_HTTPS_PORT", strconv.FormatInt(int64(*gateway.HTTPSPort), 10)))
+	}
+
+	if gateway.HTTPSVerifyDepth != nil {
+		result = append(result, helper.EnvVarFromValue("APICAST_HTTPS_VERIFY_DEPTH", strconv.FormatInt(*gateway.HTTPSVerifyDepth, 10)))
+	}
+
+	if gateway.HTTPSCertificateSecretName != nil {
+		result = append(result,
+			helper.EnvVarFromValue("APICAST_HTTPS_CERTIFICATE", path.Join(HTTPSCertificatesMountPath, v1.TLSCertKey)),
+			helper.EnvVarFromValue("APICAST_HTTPS_CERTIFICATE_KEY", path.Join(HTTPSCertificatesMountPath, v1.TLSPrivateKeyKey)),
+		)
+	}
+
+	if gateway.AllProxy != nil {
+		result = append(result, helper.EnvVarFromValue("ALL_PROXY", *gateway.AllProxy))
+	}
+
+	if gateway.HTTPProxy != nil {
+		result = append(result, helper.EnvVarFromValue("HTTP_PROXY", *gateway.HTTPProxy))
+	}
+
+	if gateway.HTTPSProxy != nil {
+		result = append(result, helper.EnvVarFromValue("HTTPS_PROXY", *gateway.HTTPSProxy))
+	}
+
+	if gateway.NoProxy != nil {
+		result = append(result, helper.EnvVarFromValue("NO_PROXY", *gateway.NoProxy))
+	}
+
+	return result
+}
+
+func apicastVolumeMounts(customPolicies []CustomPolicy, tracingConfig *APIcastTracingConfig, customEnvironments []*v1.Secret, httpsCertificateSecretName *string) []v1.VolumeMount {
+	var volumeMounts []v1.VolumeMount
+
+	for _, customPolicy := range customPolicies {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      customPolicy.VolumeName(),
+			MountPath: path.Join(CustomPoliciesMountBasePath, customPolicy.Name, customPolicy.Version),
+			ReadOnly:  true,
+		})
+	}
+
+	if tracingConfig.Enabled && tracingConfig.TracingConfigSecretName != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      tracingConfig.VolumeName(),
+			MountPath: APIcastTracingConfigMountBasePath,
+		})
+	}
+
+	for _, customEnvSecret := range customEnvironments {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      customEnvVolumeName(customEnvSecret),
+			MountPath: path.Join(CustomEnvironmentsMountBasePath, customEnvSecret.GetName()),
+			ReadOnly:  true,
+		})
+	}
+
+	if httpsCertificateSecretName != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      HTTPSCertificatesVolumeName,
+			MountPath: HTTPSCertificatesMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
+
+func apicastVolumes(customPolicies []CustomPolicy, tracingConfig *APIcastTracingConfig, customEnvironments []*v1.Secret, httpsCertificateSecretName *string) []v1.Volume {
+	var volumes []v1.Volume
+
+	for _, customPolicy := range customPolicies {
+		volumes = append(volumes, v1.Volume{
+			Name: customPolicy.VolumeName(),
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: customPolicy.SecretRef.Name,
+				},
+			},
+		})
+	}
+
+	if tracingConfig.Enabled && tracingConfig.TracingConfigSecretName != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: tracingConfig.VolumeName(),
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *tracingConfig.TracingConfigSecretName,
+					Items: []v1.KeyToPath{
+						v1.KeyToPath{
+							Key:  APIcastTracingConfigSecretKey,
+							Path: tracingConfig.VolumeName(),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	for _, customEnvSecret := range customEnvironments {
+		volumes = append(volumes, v1.Volume{
+			Name: customEnvVolumeName(customEnvSecret),
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: customEnvSecret.GetName(),
+				},
+			},
+		})
+	}
+
+	if httpsCertificateSecretName != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: HTTPSCertificatesVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *httpsCertificateSecretName,
+				},
+			},
+		})
+	}
+
+	return volumes
+}
+
+func apicastDeploymentConfigAnnotations(customPolicies []CustomPolicy, tracingConfig *APIcastTracingConfig, customEnvironments []*v1.Secret) map[string]string {
+	annotations := map[string]string{}
+
+	for _, customPolicy := range customPolicies {
+		annotations[customPolicy.AnnotationKey()] = customPolicy.AnnotationValue()
+	}
+
+	if tracingConfig.Enabled && tracingConfig.TracingConfigSecretName != nil {
+		annotations[tracingConfig.AnnotationKey()] = tracingConfig.VolumeName()
+	}
+
+	for _, customEnvSecret := range customEnvironments {
+		annotations[customEnvAnnotationKey(customEnvSecret)] = customEnvAnnotationValue(customEnvSecret)
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
+
+func apicastContainerPorts(httpsPort *int32) []v1.ContainerPort {
+	ports := []v1.ContainerPort{
+		v1.ContainerPort{ContainerPort: 8080, Protocol: v1.ProtocolTCP},
+		v1.ContainerPort{ContainerPort: 8090, Protocol: v1.ProtocolTCP},
+		v1.ContainerPort{ContainerPort: 9421, Protocol: v1.ProtocolTCP, Name: "metrics"},
+	}
+
+	if httpsPort != nil {
+		ports = append(ports,
+			v1.ContainerPort{Name: "httpsproxy", ContainerPort: *httpsPort, Protocol: v1.ProtocolTCP})
+	}
+
+	return ports
+}
+
+func apicastServicePorts(httpsPort *int32) []v1.ServicePort {
+	ports := []v1.ServicePort{
+		v1.ServicePort{Name: "gateway", Protocol: v1.ProtocolTCP, Port: 8080, TargetPort: intstr.FromInt(8080)},
+		v1.ServicePort{Name: "management", Protocol: v1.ProtocolTCP, Port: 8090, TargetPort: intstr.FromInt(8090)},
+	}
+
+	if httpsPort != nil {
+		ports = append(ports,
+			v1.ServicePort{Name: "httpsproxy", Port: *httpsPort, Protocol: v1.ProtocolTCP, TargetPort: intstr.FromString("httpsproxy")},
+		)
+	}
+
+	return ports
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/coreos/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -32,6 +33,14 @@ func (apicast *Apicast) ApicastProductionPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (apicast *Apicast) ApicastProductionServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(apicast.ApicastProductionPodMonitor())
+}
+
+func (apicast *Apicast) ApicastProductionMetricsService() *v1.Service {
+	return metricsService(apicast.ApicastProductionPodMonitor(), metricsServicePort("metrics", ApicastMetricsPort))
+}
+
 func (apicast *Apicast) ApicastStagingPodMonitor() *monitoringv1.PodMonitor {
 	return &monitoringv1.PodMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -51,6 +60,14 @@ func (apicast *Apicast) ApicastStagingPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (apicast *Apicast) ApicastStagingServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(apicast.ApicastStagingPodMonitor())
+}
+
+func (apicast *Apicast) ApicastStagingMetricsService() *v1.Service {
+	return metricsService(apicast.ApicastStagingPodMonitor(), metricsServicePort("metrics", ApicastMetricsPort))
+}
+
 func (apicast *Apicast) ApicastMainAppGrafanaDashboard() *grafanav1alpha1.GrafanaDashboard {
 	data := &struct {
 		Namespace string
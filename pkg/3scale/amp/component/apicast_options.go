@@ -94,6 +94,10 @@ type ApicastOptions struct {
 	ProductionTracingConfig *APIcastTracingConfig `validate:"required"`
 	StagingTracingConfig    *APIcastTracingConfig `validate:"required"`
 
+	// OpenTelemetry is disabled when nil
+	ProductionOpenTelemetry *OpenTelemetryConfig `validate:"omitempty"`
+	StagingOpenTelemetry    *OpenTelemetryConfig `validate:"omitempty"`
+
 	ProductionCustomEnvironments []*v1.Secret `validate:"-"`
 	StagingCustomEnvironments    []*v1.Secret `validate:"-"`
 
@@ -112,6 +116,44 @@ type ApicastOptions struct {
 	StagingHTTPProxy     *string
 	StagingHTTPSProxy    *string
 	StagingNoProxy       *string
+
+	Gateways []ApicastGatewayOptions `validate:"dive"`
+}
+
+// ApicastGatewayOptions contains the options of an additional APIcast gateway
+type ApicastGatewayOptions struct {
+	Name                     string `validate:"required"`
+	Environment              string `validate:"oneof=staging production"`
+	PortalEndpointSecretName *string
+	Replicas                 int32
+	ResourceRequirements     v1.ResourceRequirements `validate:"-"`
+	Affinity                 *v1.Affinity            `validate:"-"`
+	Tolerations              []v1.Toleration         `validate:"-"`
+	Workers                  *int32                  `validate:"-"`
+	LogLevel                 *string                 `validate:"-"`
+	CustomPolicies           []CustomPolicy          `validate:"-"`
+	TracingConfig            *APIcastTracingConfig   `validate:"required"`
+	CustomEnvironments       []*v1.Secret            `validate:"-"`
+	CommonLabels             map[string]string       `validate:"required"`
+	PodTemplateLabels        map[string]string       `validate:"required"`
+
+	HTTPSPort                  *int32  `validate:"-"`
+	HTTPSVerifyDepth           *int64  `validate:"-"`
+	HTTPSCertificateSecretName *string `validate:"-"`
+
+	AllProxy   *string
+	HTTPProxy  *string
+	HTTPSProxy *string
+	NoProxy    *string
+}
+
+// DeploymentConfigName returns the name of the gateway DeploymentConfig and Service
+func (g *ApicastGatewayOptions) DeploymentConfigName() string {
+	return ApicastGatewayName(g.Name)
+}
+
+func (g *ApicastGatewayOptions) IsProduction() bool {
+	return g.Environment == "production"
 }
 
 func NewApicastOptions() *ApicastOptions {
@@ -30,6 +30,11 @@ const (
 	BackendSecretBackendRedisQueuesSentinelRoleFieldName   = "REDIS_QUEUES_SENTINEL_ROLE"
 )
 
+const (
+	BackendRedisCredentialsSecretUsernameFieldName = "username"
+	BackendRedisCredentialsSecretPasswordFieldName = "password"
+)
+
 const (
 	BackendSecretInternalApiSecretName        = "backend-internal-api"
 	BackendSecretInternalApiUsernameFieldName = "username"
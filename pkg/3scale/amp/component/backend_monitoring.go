@@ -8,6 +8,7 @@ import (
 	"github.com/coreos/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -31,6 +32,14 @@ func (backend *Backend) BackendListenerPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (backend *Backend) BackendListenerServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(backend.BackendListenerPodMonitor())
+}
+
+func (backend *Backend) BackendListenerMetricsService() *v1.Service {
+	return metricsService(backend.BackendListenerPodMonitor(), metricsServicePort("metrics", BackendListenerMetricsPort))
+}
+
 func (backend *Backend) BackendWorkerPodMonitor() *monitoringv1.PodMonitor {
 	return &monitoringv1.PodMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -50,6 +59,14 @@ func (backend *Backend) BackendWorkerPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (backend *Backend) BackendWorkerServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(backend.BackendWorkerPodMonitor())
+}
+
+func (backend *Backend) BackendWorkerMetricsService() *v1.Service {
+	return metricsService(backend.BackendWorkerPodMonitor(), metricsServicePort("metrics", BackendWorkerMetricsPort))
+}
+
 func (backend *Backend) BackendGrafanaDashboard() *grafanav1alpha1.GrafanaDashboard {
 	data := &struct {
 		Namespace string
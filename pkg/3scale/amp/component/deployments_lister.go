@@ -12,6 +12,8 @@ type DeploymentsLister struct {
 	SystemDatabaseType     SystemDatabaseType
 	ExternalRedisDatabases bool
 	ExternalZyncDatabase   bool
+	// ApicastGatewayNames are the DeploymentConfig names of the additional APIcast gateways
+	ApicastGatewayNames []string
 }
 
 func (d *DeploymentsLister) DeploymentNames() []string {
@@ -30,6 +32,8 @@ func (d *DeploymentsLister) DeploymentNames() []string {
 		ZyncQueDeploymentName,
 	)
 
+	deployments = append(deployments, d.ApicastGatewayNames...)
+
 	switch d.SystemDatabaseType {
 	case SystemDatabaseTypeInternalMySQL:
 		deployments = append(deployments, SystemMySQLDeploymentName)
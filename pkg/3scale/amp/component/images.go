@@ -43,3 +43,11 @@ func ZyncPostgreSQLImageURL() string {
 func OCCLIImageURL() string {
 	return "quay.io/openshift/origin-cli:4.2"
 }
+
+func AWSCLIImageURL() string {
+	return "amazon/aws-cli:2.0.30"
+}
+
+func BackupAgentImageURL() string {
+	return "quay.io/3scale/3scale-operator:master"
+}
@@ -0,0 +1,174 @@
+package component
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	OpenTelemetryGRPCProtocol = "grpc"
+	OpenTelemetryHTTPProtocol = "http/protobuf"
+
+	OpenTelemetryTLSVolumeName         = "opentelemetry-tls"
+	OpenTelemetryTLSMountPath          = "/var/run/secrets/opentelemetry"
+	OpenTelemetryCACertificateFileName = "ca.crt"
+
+	APIcastOpenTelemetryConfigVolumeName = "opentelemetry-config"
+	APIcastOpenTelemetryConfigMountPath  = "/opt/app-root/src/opentelemetry-config"
+	APIcastOpenTelemetryConfigSecretKey  = "otel.toml"
+)
+
+// OpenTelemetryEnvVarNames are the env vars rendered from the OpenTelemetry
+// configuration of the components
+var OpenTelemetryEnvVarNames = []string{
+	"OPENTELEMETRY",
+	"OPENTELEMETRY_CONFIG",
+	"OPENTELEMETRY_ENABLED",
+	"OTEL_SERVICE_NAME",
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_CERTIFICATE",
+	"OTEL_TRACES_SAMPLER",
+	"OTEL_TRACES_SAMPLER_ARG",
+	"OTEL_RESOURCE_ATTRIBUTES",
+}
+
+// OpenTelemetryConfig contains the OTLP exporter configuration of a component
+type OpenTelemetryConfig struct {
+	Endpoint                string `validate:"required"`
+	Protocol                string `validate:"oneof=grpc http/protobuf"`
+	SamplingRatio           *string
+	ResourceAttributes      map[string]string
+	CACertificateSecretName *string
+	CACertificateSecretKey  *string
+}
+
+func (c *OpenTelemetryConfig) caCertificatePath() string {
+	return path.Join(OpenTelemetryTLSMountPath, OpenTelemetryCACertificateFileName)
+}
+
+// openTelemetryEnv returns the standard OTEL_* env vars of the given
+// configuration. Nil configurations are disabled
+func openTelemetryEnv(serviceName string, config *OpenTelemetryConfig) []v1.EnvVar {
+	if config == nil {
+		return nil
+	}
+
+	result := []v1.EnvVar{
+		helper.EnvVarFromValue("OTEL_SERVICE_NAME", serviceName),
+		helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_ENDPOINT", config.Endpoint),
+		helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_PROTOCOL", config.Protocol),
+	}
+
+	if config.CACertificateSecretName != nil {
+		result = append(result, helper.EnvVarFromValue("OTEL_EXPORTER_OTLP_CERTIFICATE", config.caCertificatePath()))
+	}
+
+	if config.SamplingRatio != nil {
+		result = append(result,
+			helper.EnvVarFromValue("OTEL_TRACES_SAMPLER", "parentbased_traceidratio"),
+			helper.EnvVarFromValue("OTEL_TRACES_SAMPLER_ARG", *config.SamplingRatio),
+		)
+	}
+
+	if len(config.ResourceAttributes) > 0 {
+		attributes := []string{}
+		for key, value := range config.ResourceAttributes {
+			attributes = append(attributes, fmt.Sprintf("%s=%s", key, value))
+		}
+		// Sort attributes to ensure deterministic reconciliation
+		sort.Strings(attributes)
+		result = append(result, helper.EnvVarFromValue("OTEL_RESOURCE_ATTRIBUTES", strings.Join(attributes, ",")))
+	}
+
+	return result
+}
+
+func openTelemetryTLSVolumeMounts(config *OpenTelemetryConfig) []v1.VolumeMount {
+	if config == nil || config.CACertificateSecretName == nil {
+		return nil
+	}
+
+	return []v1.VolumeMount{
+		{
+			Name:      OpenTelemetryTLSVolumeName,
+			MountPath: OpenTelemetryTLSMountPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+func openTelemetryTLSVolumes(config *OpenTelemetryConfig) []v1.Volume {
+	if config == nil || config.CACertificateSecretName == nil {
+		return nil
+	}
+
+	return []v1.Volume{
+		{
+			Name: OpenTelemetryTLSVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *config.CACertificateSecretName,
+					Items: []v1.KeyToPath{
+						{
+							Key:  *config.CACertificateSecretKey,
+							Path: OpenTelemetryCACertificateFileName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// apicastOpenTelemetryConfig renders the configuration file of the
+// OpenTelemetry module loaded by APIcast
+func apicastOpenTelemetryConfig(serviceName string, config *OpenTelemetryConfig) string {
+	var b strings.Builder
+
+	// Endpoint is validated in the APIManager
+	endpointURL, _ := url.Parse(config.Endpoint)
+	port := endpointURL.Port()
+	if port == "" {
+		port = "4317"
+	}
+
+	fmt.Fprintln(&b, `exporter = "otlp"`)
+	fmt.Fprintln(&b, `processor = "batch"`)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[exporters.otlp]")
+	fmt.Fprintf(&b, "host = %q\n", endpointURL.Hostname())
+	fmt.Fprintf(&b, "port = %s\n", port)
+	if endpointURL.Scheme == "https" {
+		fmt.Fprintln(&b, "use_ssl = true")
+		if config.CACertificateSecretName != nil {
+			fmt.Fprintf(&b, "ssl_cert_path = %q\n", config.caCertificatePath())
+		}
+	}
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[processors.batch]")
+	fmt.Fprintln(&b, "max_queue_size = 2048")
+	fmt.Fprintln(&b, "schedule_delay_millis = 5000")
+	fmt.Fprintln(&b, "max_export_batch_size = 512")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[service]")
+	fmt.Fprintf(&b, "name = %q\n", serviceName)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[sampler]")
+	if config.SamplingRatio != nil {
+		fmt.Fprintln(&b, `name = "TraceIdRatioBased"`)
+		fmt.Fprintf(&b, "ratio = %s\n", *config.SamplingRatio)
+	} else {
+		fmt.Fprintln(&b, `name = "AlwaysOn"`)
+	}
+	fmt.Fprintln(&b, "parent_based = true")
+
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/assets"
+	"github.com/3scale/3scale-operator/pkg/common"
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProductGrafanaDashboardLabelKey labels the dashboards generated for the
+// products with the name of the product
+const ProductGrafanaDashboardLabelKey = "threescale_product"
+
+func ProductGrafanaDashboardName(productName string) string {
+	return fmt.Sprintf("threescale-product-%s", productName)
+}
+
+// ProductGrafanaDashboard returns the dashboard of the traffic of the given
+// product through APIcast. APIcast metrics are labelled with the product,
+// but not with the mapping rule or the application plan the request matched,
+// so mapping rules and plan limits are shown as tables
+func ProductGrafanaDashboard(ns string, appLabel string, product *capabilitiesv1beta1.Product) *grafanav1alpha1.GrafanaDashboard {
+	data := &struct {
+		Namespace               string
+		SystemName              string
+		Title                   string
+		MappingRulesContent     string
+		ApplicationPlansContent string
+	}{
+		ns,
+		product.Spec.SystemName,
+		jsonString(fmt.Sprintf("%s / 3scale / Product / %s", ns, product.Spec.Name)),
+		jsonString(productMappingRulesMarkdown(product)),
+		jsonString(productApplicationPlansMarkdown(product)),
+	}
+	return &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ProductGrafanaDashboardName(product.Name),
+			Labels: map[string]string{
+				"monitoring-key":                common.MonitoringKey,
+				"app":                           appLabel,
+				ProductGrafanaDashboardLabelKey: product.Name,
+			},
+		},
+		Spec: grafanav1alpha1.GrafanaDashboardSpec{
+			Json: assets.TemplateAsset("monitoring/product-grafana-dashboard-1.json.tpl", data),
+			Name: fmt.Sprintf("%s/product-%s-grafana-dashboard-1.json", ns, product.Name),
+		},
+	}
+}
+
+func productMappingRulesMarkdown(product *capabilitiesv1beta1.Product) string {
+	if len(product.Spec.MappingRules) == 0 {
+		return "No mapping rules"
+	}
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "| Method | Pattern | Metric or method | Increment | Last |")
+	fmt.Fprintln(&b, "| --- | --- | --- | --- | --- |")
+	for _, rule := range product.Spec.MappingRules {
+		last := rule.Last != nil && *rule.Last
+		fmt.Fprintf(&b, "| %s | `%s` | %s | %d | %t |\n",
+			rule.HTTPMethod, markdownTableCell(rule.Pattern), productMetricMethodName(product, rule.MetricMethodRef), rule.Increment, last)
+	}
+	return b.String()
+}
+
+func productApplicationPlansMarkdown(product *capabilitiesv1beta1.Product) string {
+	if len(product.Spec.ApplicationPlans) == 0 {
+		return "No application plans"
+	}
+
+	// Sort plans to ensure deterministic reconciliation
+	planSystemNames := make([]string, 0, len(product.Spec.ApplicationPlans))
+	for systemName := range product.Spec.ApplicationPlans {
+		planSystemNames = append(planSystemNames, systemName)
+	}
+	sort.Strings(planSystemNames)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "| Application plan | Metric or method | Period | Limit |")
+	fmt.Fprintln(&b, "| --- | --- | --- | --- |")
+	for _, systemName := range planSystemNames {
+		plan := product.Spec.ApplicationPlans[systemName]
+		planName := systemName
+		if plan.Name != nil {
+			planName = fmt.Sprintf("%s (%s)", *plan.Name, systemName)
+		}
+		if len(plan.Limits) == 0 {
+			fmt.Fprintf(&b, "| %s | - | - | - |\n", markdownTableCell(planName))
+		}
+		for _, limit := range plan.Limits {
+			metricName := limit.MetricMethodRef.String()
+			if limit.MetricMethodRef.BackendSystemName == nil {
+				metricName = productMetricMethodName(product, limit.MetricMethodRef.SystemName)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %d |\n", markdownTableCell(planName), metricName, limit.Period, limit.Value)
+		}
+	}
+	return b.String()
+}
+
+// productMetricMethodName returns the friendly name of the given product
+// metric or method along with its system name
+func productMetricMethodName(product *capabilitiesv1beta1.Product, systemName string) string {
+	name := ""
+	if metric, ok := product.Spec.Metrics[systemName]; ok {
+		name = metric.Name
+	} else if method, ok := product.Spec.Methods[systemName]; ok {
+		name = method.Name
+	}
+
+	if name == "" {
+		return systemName
+	}
+	return fmt.Sprintf("%s (%s)", markdownTableCell(name), systemName)
+}
+
+func markdownTableCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// jsonString returns the given value as a JSON string literal
+func jsonString(value string) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -14,6 +14,9 @@ import (
 const (
 	BackendRedisDeploymentName = "backend-redis"
 	SystemRedisDeploymentName  = "system-redis"
+	BackendRedisPVCName        = backendRedisStorageVolumeName
+	SystemRedisPVCName         = "system-redis-storage"
+	RedisDataPath              = "/var/lib/redis/data"
 )
 
 type Redis struct {
@@ -577,7 +580,7 @@ func (redis *Redis) SystemPVC() *v1.PersistentVolumeClaim {
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "system-redis-storage",
+			Name:   SystemRedisPVCName,
 			Labels: redis.Options.SystemRedisLabels,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
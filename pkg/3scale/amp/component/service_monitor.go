@@ -0,0 +1,84 @@
+package component
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// MetricsServiceLabelKey labels the services exposing the metrics ports of
+// the 3scale components, for ServiceMonitors to select them
+const MetricsServiceLabelKey = "threescale_metrics_service"
+
+// metricsService returns the service exposing the given metrics ports of the
+// pods scraped by the given PodMonitor
+func metricsService(podMonitor *monitoringv1.PodMonitor, ports ...v1.ServicePort) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-metrics", podMonitor.Name),
+			Labels: metricsServiceLabels(podMonitor),
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Ports:     ports,
+			Selector:  podMonitor.Spec.Selector.MatchLabels,
+		},
+	}
+}
+
+func metricsServicePort(name string, port int32) v1.ServicePort {
+	return v1.ServicePort{
+		Name:       name,
+		Protocol:   v1.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromString(name),
+	}
+}
+
+// serviceMonitor returns the ServiceMonitor scraping the endpoints of the
+// given PodMonitor through its metrics service. Targets keep the
+// <namespace>/<name> job label of the PodMonitor targets, the prometheus
+// rules and dashboards rely on
+func serviceMonitor(podMonitor *monitoringv1.PodMonitor) *monitoringv1.ServiceMonitor {
+	endpoints := []monitoringv1.Endpoint{}
+	for _, podEndpoint := range podMonitor.Spec.PodMetricsEndpoints {
+		endpoints = append(endpoints, monitoringv1.Endpoint{
+			Port:   podEndpoint.Port,
+			Path:   podEndpoint.Path,
+			Scheme: podEndpoint.Scheme,
+			RelabelConfigs: []*monitoringv1.RelabelConfig{{
+				SourceLabels: []string{"__meta_kubernetes_namespace"},
+				Regex:        "(.*)",
+				TargetLabel:  "job",
+				Replacement:  fmt.Sprintf("${1}/%s", podMonitor.Name),
+				Action:       "replace",
+			}},
+		})
+	}
+
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   podMonitor.Name,
+			Labels: podMonitor.Labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: endpoints,
+			Selector: metav1.LabelSelector{
+				MatchLabels: metricsServiceLabels(podMonitor),
+			},
+		},
+	}
+}
+
+func metricsServiceLabels(podMonitor *monitoringv1.PodMonitor) map[string]string {
+	labels := map[string]string{
+		MetricsServiceLabelKey: podMonitor.Name,
+	}
+	for key, value := range podMonitor.Spec.Selector.MatchLabels {
+		labels[key] = value
+	}
+	return labels
+}
@@ -0,0 +1,303 @@
+package component
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/assets"
+	"github.com/3scale/3scale-operator/pkg/common"
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	DefaultSLOAvailabilityTarget                = "99.5"
+	DefaultSLOLatencyTarget                     = "99"
+	DefaultApicastProductionSLOLatencyThreshold = "0.5"
+	DefaultBackendListenerSLOLatencyThreshold   = "0.1"
+)
+
+// sloWindows are the windows the SLI error ratios are recorded for
+var sloWindows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+// sloBurnRateAlerts are the multiwindow, multi-burn-rate alerts. Each of
+// them fires when the error budget is burnt at the given rate over both the
+// long and the short window of any of its conditions
+var sloBurnRateAlerts = []struct {
+	suffix     string
+	severity   string
+	forPeriod  string
+	conditions []sloBurnRateCondition
+}{
+	{"ErrorBudgetBurnFast", "critical", "2m", []sloBurnRateCondition{{"1h", "5m", 14.4}, {"6h", "30m", 6}}},
+	{"ErrorBudgetBurnSlow", "warning", "15m", []sloBurnRateCondition{{"1d", "2h", 3}, {"3d", "6h", 1}}},
+}
+
+type sloBurnRateCondition struct {
+	longWindow  string
+	shortWindow string
+	burnRate    float64
+}
+
+// ServiceLevelObjective defines the availability and latency objectives of
+// a service, as percentages of requests
+type ServiceLevelObjective struct {
+	AvailabilityTarget float64
+	LatencyTarget      float64
+	// Response time in seconds. It has to be a bucket boundary of the
+	// response time histogram
+	LatencyThreshold string
+}
+
+// SLOOptions defines the service level objectives of the services the SLO
+// alerts and dashboard are generated for
+type SLOOptions struct {
+	ApicastProduction *ServiceLevelObjective
+	BackendListener   *ServiceLevelObjective
+}
+
+func NewServiceLevelObjective(availabilityTarget, latencyTarget, latencyThreshold string) (*ServiceLevelObjective, error) {
+	availability, err := strconv.ParseFloat(availabilityTarget, 64)
+	if err != nil || availability <= 0 || availability >= 100 {
+		return nil, fmt.Errorf("invalid availability target '%s'", availabilityTarget)
+	}
+	latency, err := strconv.ParseFloat(latencyTarget, 64)
+	if err != nil || latency <= 0 || latency >= 100 {
+		return nil, fmt.Errorf("invalid latency target '%s'", latencyTarget)
+	}
+	if _, err := strconv.ParseFloat(latencyThreshold, 64); err != nil {
+		return nil, fmt.Errorf("invalid latency threshold '%s'", latencyThreshold)
+	}
+
+	return &ServiceLevelObjective{
+		AvailabilityTarget: availability,
+		LatencyTarget:      latency,
+		LatencyThreshold:   latencyThreshold,
+	}, nil
+}
+
+func DefaultApicastProductionServiceLevelObjective() *ServiceLevelObjective {
+	slo, _ := NewServiceLevelObjective(DefaultSLOAvailabilityTarget, DefaultSLOLatencyTarget, DefaultApicastProductionSLOLatencyThreshold)
+	return slo
+}
+
+func DefaultBackendListenerServiceLevelObjective() *ServiceLevelObjective {
+	slo, _ := NewServiceLevelObjective(DefaultSLOAvailabilityTarget, DefaultSLOLatencyTarget, DefaultBackendListenerSLOLatencyThreshold)
+	return slo
+}
+
+// availabilityErrorBudget returns the ratio of requests allowed to fail
+func (s *ServiceLevelObjective) availabilityErrorBudget() float64 {
+	return sloErrorBudget(s.AvailabilityTarget)
+}
+
+// latencyErrorBudget returns the ratio of requests allowed to be slower
+// than the latency threshold
+func (s *ServiceLevelObjective) latencyErrorBudget() float64 {
+	return sloErrorBudget(s.LatencyTarget)
+}
+
+func sloErrorBudget(target float64) float64 {
+	return sloRatio((100 - target) / 100)
+}
+
+// sloRatio rounds the given ratio to avoid floating point noise in the
+// generated expressions
+func sloRatio(ratio float64) float64 {
+	return math.Round(ratio*1e9) / 1e9
+}
+
+// sloIndicator defines the queries of the availability and latency service
+// level indicators of a service
+type sloIndicator struct {
+	// Prefix of the recording rules and the alerts
+	recordPrefix string
+	alertPrefix  string
+	service      string
+	// Rate of all the requests, and of the ones failed or faster than the
+	// latency threshold, over the given window
+	requestsRate       func(window string) string
+	errorsRate         func(window string) string
+	latencyRequests    func(window string) string
+	fastRequestsRate   func(window string) string
+	errorBudget        float64
+	latencyErrorBudget float64
+}
+
+func ApicastProductionSLOPrometheusRules(ns string, appLabel string, slo *ServiceLevelObjective) *monitoringv1.PrometheusRule {
+	selector := fmt.Sprintf(`namespace="%s",pod=~"apicast-production.*"`, ns)
+	le := histogramBucketRegexp(slo.LatencyThreshold)
+	indicator := &sloIndicator{
+		recordPrefix: "threescale:apicast_production",
+		alertPrefix:  "ThreescaleApicastProduction",
+		service:      "APIcast production",
+		requestsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apicast_status{%s}[%s]))`, selector, window)
+		},
+		errorsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apicast_status{%s,status=~"5.."}[%s]))`, selector, window)
+		},
+		latencyRequests: func(window string) string {
+			return fmt.Sprintf(`sum(rate(total_response_time_seconds_count{%s}[%s]))`, selector, window)
+		},
+		fastRequestsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(total_response_time_seconds_bucket{%s,le=~"%s"}[%s]))`, selector, le, window)
+		},
+		errorBudget:        slo.availabilityErrorBudget(),
+		latencyErrorBudget: slo.latencyErrorBudget(),
+	}
+
+	return sloPrometheusRule(ns, appLabel, "apicast-production-slo", indicator)
+}
+
+func BackendListenerSLOPrometheusRules(ns string, appLabel string, slo *ServiceLevelObjective) *monitoringv1.PrometheusRule {
+	selector := fmt.Sprintf(`job=~".*backend-listener.*",namespace="%s"`, ns)
+	le := histogramBucketRegexp(slo.LatencyThreshold)
+	indicator := &sloIndicator{
+		recordPrefix: "threescale:backend_listener",
+		alertPrefix:  "ThreescaleBackendListener",
+		service:      "Backend listener",
+		requestsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apisonator_listener_response_codes{%s}[%s]))`, selector, window)
+		},
+		errorsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apisonator_listener_response_codes{%s,resp_code="5xx"}[%s]))`, selector, window)
+		},
+		latencyRequests: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apisonator_listener_response_times_seconds_count{%s}[%s]))`, selector, window)
+		},
+		fastRequestsRate: func(window string) string {
+			return fmt.Sprintf(`sum(rate(apisonator_listener_response_times_seconds_bucket{%s,le=~"%s"}[%s]))`, selector, le, window)
+		},
+		errorBudget:        slo.availabilityErrorBudget(),
+		latencyErrorBudget: slo.latencyErrorBudget(),
+	}
+
+	return sloPrometheusRule(ns, appLabel, "backend-listener-slo", indicator)
+}
+
+func sloPrometheusRule(ns, appLabel, name string, indicator *sloIndicator) *monitoringv1.PrometheusRule {
+	recordingRules := []monitoringv1.Rule{}
+	for _, window := range sloWindows {
+		recordingRules = append(recordingRules,
+			monitoringv1.Rule{
+				Record: fmt.Sprintf("%s_availability:error_ratio_rate%s", indicator.recordPrefix, window),
+				Expr:   intstr.FromString(fmt.Sprintf("%s / %s", indicator.errorsRate(window), indicator.requestsRate(window))),
+			},
+			monitoringv1.Rule{
+				Record: fmt.Sprintf("%s_latency:error_ratio_rate%s", indicator.recordPrefix, window),
+				Expr:   intstr.FromString(fmt.Sprintf("1 - (%s / %s)", indicator.fastRequestsRate(window), indicator.latencyRequests(window))),
+			},
+		)
+	}
+
+	alertingRules := []monitoringv1.Rule{}
+	slis := []struct {
+		name        string
+		description string
+		errorBudget float64
+	}{
+		{"availability", "5xx responses", indicator.errorBudget},
+		{"latency", "responses slower than the latency threshold", indicator.latencyErrorBudget},
+	}
+	for _, sli := range slis {
+		record := fmt.Sprintf("%s_%s:error_ratio_rate", indicator.recordPrefix, sli.name)
+		for _, alert := range sloBurnRateAlerts {
+			conditions := []string{}
+			for _, condition := range alert.conditions {
+				threshold := strconv.FormatFloat(sloRatio(condition.burnRate*sli.errorBudget), 'g', -1, 64)
+				conditions = append(conditions, fmt.Sprintf("(%s%s > %s and %s%s > %s)",
+					record, condition.longWindow, threshold, record, condition.shortWindow, threshold))
+			}
+
+			alertingRules = append(alertingRules, monitoringv1.Rule{
+				Alert: fmt.Sprintf("%s%s%s", indicator.alertPrefix, strings.Title(sli.name), alert.suffix),
+				Annotations: map[string]string{
+					"summary":     fmt.Sprintf("%s %s error budget is being burnt too fast", indicator.service, sli.name),
+					"description": fmt.Sprintf("The rate of %s of %s on {{ $labels.namespace }} is exhausting its %s error budget", sli.description, indicator.service, sli.name),
+				},
+				Expr: intstr.FromString(strings.Join(conditions, " or ")),
+				For:  alert.forPeriod,
+				Labels: map[string]string{
+					"severity": alert.severity,
+				},
+			})
+		}
+	}
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"prometheus": "application-monitoring",
+				"role":       "alert-rules",
+				"app":        appLabel,
+			},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  fmt.Sprintf("%s/%s-recording.rules", ns, name),
+					Rules: recordingRules,
+				},
+				{
+					Name:  fmt.Sprintf("%s/%s.rules", ns, name),
+					Rules: alertingRules,
+				},
+			},
+		},
+	}
+}
+
+// histogramBucketRegexp returns the regular expression matching the le label
+// of the given histogram bucket boundary. Exporters pad the bucket
+// boundaries differently, i.e. 0.5 can be exposed as 00.500
+func histogramBucketRegexp(boundary string) string {
+	parts := strings.SplitN(boundary, ".", 2)
+	integer := strings.TrimLeft(parts[0], "0")
+	if integer == "" {
+		integer = "0"
+	}
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = strings.TrimRight(parts[1], "0")
+	}
+
+	if fraction == "" {
+		return fmt.Sprintf(`0*%s(\\.0*)?`, integer)
+	}
+	return fmt.Sprintf(`0*%s\\.%s0*`, integer, fraction)
+}
+
+func SLOGrafanaDashboard(ns string, appLabel string, opts *SLOOptions) *grafanav1alpha1.GrafanaDashboard {
+	data := &struct {
+		Namespace                                string
+		ApicastProductionAvailabilityErrorBudget string
+		ApicastProductionLatencyErrorBudget      string
+		BackendListenerAvailabilityErrorBudget   string
+		BackendListenerLatencyErrorBudget        string
+	}{
+		ns,
+		strconv.FormatFloat(opts.ApicastProduction.availabilityErrorBudget(), 'g', -1, 64),
+		strconv.FormatFloat(opts.ApicastProduction.latencyErrorBudget(), 'g', -1, 64),
+		strconv.FormatFloat(opts.BackendListener.availabilityErrorBudget(), 'g', -1, 64),
+		strconv.FormatFloat(opts.BackendListener.latencyErrorBudget(), 'g', -1, 64),
+	}
+	return &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "threescale-slo",
+			Labels: map[string]string{
+				"monitoring-key": common.MonitoringKey,
+				"app":            appLabel,
+			},
+		},
+		Spec: grafanav1alpha1.GrafanaDashboardSpec{
+			Json: assets.TemplateAsset("monitoring/slo-grafana-dashboard-1.json.tpl", data),
+			Name: fmt.Sprintf("%s/slo-grafana-dashboard-1.json", ns),
+		},
+	}
+}
@@ -257,6 +257,10 @@ func (system *System) buildSystemBaseEnv() []v1.EnvVar {
 func (system *System) buildAppEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 	result = append(result, helper.EnvVarFromSecret(SystemSecretSystemAppUserSessionTTLFieldName, SystemSecretSystemAppSecretName, SystemSecretSystemAppUserSessionTTLFieldName))
+	if system.Options.AppOpenTelemetry != nil {
+		result = append(result, helper.EnvVarFromValue("OPENTELEMETRY_ENABLED", "true"))
+		result = append(result, openTelemetryEnv(SystemAppDeploymentName, system.Options.AppOpenTelemetry)...)
+	}
 	return result
 }
 
@@ -507,6 +511,7 @@ func (system *System) appPodVolumes() []v1.Volume {
 	}
 
 	res = append(res, systemConfigVolume)
+	res = append(res, openTelemetryTLSVolumes(system.Options.AppOpenTelemetry)...)
 	return res
 }
 
@@ -870,6 +875,7 @@ func (system *System) appCommonContainerVolumeMounts(systemStorageReadonly bool)
 		res = append(res, system.systemStorageVolumeMount(systemStorageReadonly))
 	}
 	res = append(res, system.systemConfigVolumeMount())
+	res = append(res, openTelemetryTLSVolumeMounts(system.Options.AppOpenTelemetry)...)
 
 	return res
 }
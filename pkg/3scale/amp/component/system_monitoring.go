@@ -6,6 +6,7 @@ import (
 	"github.com/coreos/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -32,6 +33,14 @@ func (system *System) SystemSidekiqPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (system *System) SystemSidekiqServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(system.SystemSidekiqPodMonitor())
+}
+
+func (system *System) SystemSidekiqMetricsService() *v1.Service {
+	return metricsService(system.SystemSidekiqPodMonitor(), metricsServicePort("metrics", SystemSidekiqMetricsPort))
+}
+
 func (system *System) SystemAppPodMonitor() *monitoringv1.PodMonitor {
 	return &monitoringv1.PodMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -78,6 +87,18 @@ func (system *System) SystemAppPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (system *System) SystemAppServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(system.SystemAppPodMonitor())
+}
+
+func (system *System) SystemAppMetricsService() *v1.Service {
+	return metricsService(system.SystemAppPodMonitor(),
+		metricsServicePort(SystemAppMasterContainerMetricsPortName, SystemAppMasterContainerPrometheusPort),
+		metricsServicePort(SystemAppProviderContainerMetricsPortName, SystemAppProviderContainerPrometheusPort),
+		metricsServicePort(SystemAppDeveloperContainerMetricsPortName, SystemAppDeveloperContainerPrometheusPort),
+	)
+}
+
 func (system *System) SystemGrafanaDashboard() *grafanav1alpha1.GrafanaDashboard {
 	data := &struct {
 		Namespace string
@@ -92,6 +92,9 @@ type SystemOptions struct {
 
 	IncludeOracleOptionalSettings bool
 
+	// OpenTelemetry is disabled when nil
+	AppOpenTelemetry *OpenTelemetryConfig `validate:"omitempty"`
+
 	BackendServiceEndpoint string `validate:"required"`
 
 	// Used for monitoring objects
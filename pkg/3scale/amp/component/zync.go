@@ -207,6 +207,7 @@ func (zync *Zync) DeploymentConfig() *appsv1.DeploymentConfig {
 					Affinity:           zync.Options.ZyncAffinity,
 					Tolerations:        zync.Options.ZyncTolerations,
 					ServiceAccountName: "amp",
+					Volumes:            openTelemetryTLSVolumes(zync.Options.ZyncOpenTelemetry),
 					InitContainers: []v1.Container{
 						v1.Container{
 							Name:  "zync-db-svc",
@@ -236,10 +237,11 @@ func (zync *Zync) DeploymentConfig() *appsv1.DeploymentConfig {
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  ZyncName,
-							Image: "amp-zync:latest",
-							Ports: zync.zyncPorts(),
-							Env:   zync.commonZyncEnvVars(),
+							Name:         ZyncName,
+							Image:        "amp-zync:latest",
+							Ports:        zync.zyncPorts(),
+							Env:          zync.zyncEnvVars(),
+							VolumeMounts: openTelemetryTLSVolumeMounts(zync.Options.ZyncOpenTelemetry),
 							LivenessProbe: &v1.Probe{
 								Handler: v1.Handler{
 									HTTPGet: &v1.HTTPGetAction{
@@ -277,6 +279,15 @@ func (zync *Zync) DeploymentConfig() *appsv1.DeploymentConfig {
 	}
 }
 
+func (zync *Zync) zyncEnvVars() []v1.EnvVar {
+	result := zync.commonZyncEnvVars()
+	if zync.Options.ZyncOpenTelemetry != nil {
+		result = append(result, helper.EnvVarFromValue("OPENTELEMETRY_ENABLED", "true"))
+		result = append(result, openTelemetryEnv(ZyncName, zync.Options.ZyncOpenTelemetry)...)
+	}
+	return result
+}
+
 func (zync *Zync) commonZyncEnvVars() []v1.EnvVar {
 	return []v1.EnvVar{
 		helper.EnvVarFromValue("RAILS_LOG_TO_STDOUT", "true"),
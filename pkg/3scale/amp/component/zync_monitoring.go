@@ -6,6 +6,7 @@ import (
 	"github.com/coreos/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -32,6 +33,14 @@ func (zync *Zync) ZyncPodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (zync *Zync) ZyncServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(zync.ZyncPodMonitor())
+}
+
+func (zync *Zync) ZyncMetricsService() *v1.Service {
+	return metricsService(zync.ZyncPodMonitor(), metricsServicePort("metrics", ZyncMetricsPort))
+}
+
 func (zync *Zync) ZyncQuePodMonitor() *monitoringv1.PodMonitor {
 	return &monitoringv1.PodMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -51,6 +60,14 @@ func (zync *Zync) ZyncQuePodMonitor() *monitoringv1.PodMonitor {
 	}
 }
 
+func (zync *Zync) ZyncQueServiceMonitor() *monitoringv1.ServiceMonitor {
+	return serviceMonitor(zync.ZyncQuePodMonitor())
+}
+
+func (zync *Zync) ZyncQueMetricsService() *v1.Service {
+	return metricsService(zync.ZyncQuePodMonitor(), metricsServicePort("metrics", ZyncQueMetricsPort))
+}
+
 func (zync *Zync) ZyncGrafanaDashboard() *grafanav1alpha1.GrafanaDashboard {
 	data := &struct {
 		Namespace string
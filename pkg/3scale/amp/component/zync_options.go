@@ -39,6 +39,9 @@ type ZyncOptions struct {
 	ZyncDatabasePodTemplateLabels map[string]string `validate:"required"`
 	ZyncMetrics                   bool
 
+	// OpenTelemetry is disabled when nil
+	ZyncOpenTelemetry *OpenTelemetryConfig `validate:"omitempty"`
+
 	ZyncQueServiceAccountImagePullSecrets []v1.LocalObjectReference `validate:"required"`
 
 	// Used for monitoring objects
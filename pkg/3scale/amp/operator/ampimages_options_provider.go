@@ -53,6 +53,14 @@ func (a *AmpImagesOptionsProvider) GetAmpImagesOptions() (*component.AmpImagesOp
 		a.ampImagesOptions.SystemMemcachedImage = *a.apimanager.Spec.System.MemcachedImage
 	}
 
+	imageRegistry := a.apimanager.Spec.ImageRegistry
+	a.ampImagesOptions.ApicastImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.ApicastImage)
+	a.ampImagesOptions.BackendImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.BackendImage)
+	a.ampImagesOptions.SystemImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.SystemImage)
+	a.ampImagesOptions.ZyncImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.ZyncImage)
+	a.ampImagesOptions.ZyncDatabasePostgreSQLImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.ZyncDatabasePostgreSQLImage)
+	a.ampImagesOptions.SystemMemcachedImage = RegistryImageURL(imageRegistry, a.ampImagesOptions.SystemMemcachedImage)
+
 	a.ampImagesOptions.ImagePullSecrets = component.AmpImagesDefaultImagePullSecrets()
 	if a.apimanager.Spec.ImagePullSecrets != nil {
 		a.ampImagesOptions.ImagePullSecrets = a.apimanager.Spec.ImagePullSecrets
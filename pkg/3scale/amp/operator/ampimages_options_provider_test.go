@@ -44,6 +44,14 @@ func testAmpImagesCustomImagePullSecrets() []v1.LocalObjectReference {
 	}
 }
 
+func testAmpImagesImageRegistry() *appsv1alpha1.ImageRegistrySpec {
+	return &appsv1alpha1.ImageRegistrySpec{
+		Mirrors: []appsv1alpha1.ImageRegistryMirrorSpec{
+			{Source: "quay.io", Mirror: "mirror.example.com"},
+		},
+	}
+}
+
 func TestGetAmpImagesOptionsProvider(t *testing.T) {
 	tmpApicastImage := apicastImage
 	tmpBackendImage := backendImage
@@ -153,6 +161,26 @@ func TestGetAmpImagesOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{
+			"imageRegistry",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Apicast = &appsv1alpha1.ApicastSpec{Image: &tmpApicastImage}
+				apimanager.Spec.ImageRegistry = testAmpImagesImageRegistry()
+				return apimanager
+			},
+			func() *component.AmpImagesOptions {
+				imageRegistry := testAmpImagesImageRegistry()
+				opts := defaultAmpImageOptions()
+				opts.ApicastImage = "mirror.example.com/3scale/apicast:mytag"
+				opts.BackendImage = RegistryImageURL(imageRegistry, BackendImageURL())
+				opts.SystemImage = RegistryImageURL(imageRegistry, SystemImageURL())
+				opts.ZyncImage = RegistryImageURL(imageRegistry, ZyncImageURL())
+				opts.ZyncDatabasePostgreSQLImage = RegistryImageURL(imageRegistry, component.ZyncPostgreSQLImageURL())
+				opts.SystemMemcachedImage = RegistryImageURL(imageRegistry, SystemMemcachedImageURL())
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
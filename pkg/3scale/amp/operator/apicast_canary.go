@@ -0,0 +1,532 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ApicastCanaryName is the name of the apicast-production canary DeploymentConfig
+	ApicastCanaryName = "apicast-production-canary"
+	// ApicastCanaryRevisionAnnotation is the revision of the apicast-production
+	// pod template deployed by the canary
+	ApicastCanaryRevisionAnnotation = "apps.3scale.net/apicast-canary-revision"
+)
+
+// apicastCanaryCheckPeriod is the time after which the canary health is
+// evaluated again
+const apicastCanaryCheckPeriod = 30 * time.Second
+
+// apicastPodMetrics returns the metric families exposed by an APIcast pod
+var apicastPodMetrics = fetchApicastPodMetrics
+
+// reconcileProductionDeploymentConfig applies the apicast-production changes.
+// With the canary enabled, pod template changes are rolled out to a canary
+// deployment first and applied once the canary has been healthy for the
+// analysis duration
+func (r *ApicastReconciler) reconcileProductionDeploymentConfig(desired *appsv1.DeploymentConfig, mutator reconcilers.MutateFn) (reconcile.Result, error) {
+	if !r.apiManager.IsApicastProductionCanaryEnabled() {
+		err := r.deleteProductionCanary()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.ReconcileDeploymentConfig(desired, mutator)
+	}
+
+	// Images are set by the operator, so image changes go through the canary
+	triggerIdx := imageChangeTriggerIndex(desired)
+	if triggerIdx < 0 {
+		return reconcile.Result{}, fmt.Errorf("DeploymentConfig '%s' has no image change trigger", desired.Name)
+	}
+	imageChangeParams := desired.Spec.Triggers[triggerIdx].ImageChangeParams
+	image, err := r.resolveImageStreamTag(imageChangeParams.From.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if image == "" {
+		r.Logger().Info("Waiting for image to be imported", "ImageStreamTag", imageChangeParams.From.Name)
+		return reconcile.Result{RequeueAfter: apicastCanaryCheckPeriod}, nil
+	}
+	imageChangeParams.Automatic = false
+	for idx := range desired.Spec.Template.Spec.InitContainers {
+		desired.Spec.Template.Spec.InitContainers[idx].Image = image
+	}
+	for idx := range desired.Spec.Template.Spec.Containers {
+		desired.Spec.Template.Spec.Containers[idx].Image = image
+	}
+
+	existing := &appsv1.DeploymentConfig{}
+	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: r.apiManager.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, r.ReconcileDeploymentConfig(desired, mutator)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desired.SetNamespace(r.apiManager.Namespace)
+	candidate := existing.DeepCopy()
+	_, err = r.APIManagerMutator(mutator)(candidate, desired)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if reflect.DeepEqual(candidate.Spec.Template, existing.Spec.Template) {
+		// Changes not affecting the pods are applied directly
+		if !reflect.DeepEqual(candidate, existing) {
+			err = r.UpdateResource(candidate)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, r.stopCanary("apicast-production changes reverted before promotion")
+	}
+
+	revision, err := podTemplateRevision(candidate.Spec.Template)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status := r.apiManager.Status.ApicastCanary
+	if status != nil && status.Revision == revision && status.Phase == appsv1alpha1.ApicastCanaryPhaseRolledBack {
+		r.Logger().Info("apicast-production changes rolled back by the canary. Waiting for new changes", "revision", revision)
+		return reconcile.Result{}, nil
+	}
+
+	if status == nil || status.Revision != revision || status.Phase != appsv1alpha1.ApicastCanaryPhaseProgressing {
+		err = r.startCanary(revision, image)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	err = r.ReconcileDeploymentConfig(apicastCanaryDeploymentConfig(candidate, revision, apicastCanaryReplicas(existing.Spec.Replicas, r.apiManager.ApicastProductionCanaryWeight())), apicastCanaryMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.analyzeCanary(candidate)
+}
+
+// analyzeCanary promotes the canary once it has been rolled out and its
+// error rate has stayed below the maximum for the analysis duration. It is
+// rolled back otherwise
+func (r *ApicastReconciler) analyzeCanary(candidate *appsv1.DeploymentConfig) (reconcile.Result, error) {
+	canary := &appsv1.DeploymentConfig{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: ApicastCanaryName, Namespace: r.apiManager.Namespace}, canary)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rolledOut := helper.IsDeploymentConfigRolledOut(canary)
+	var metricsErr error
+	if rolledOut {
+		var errorRate float64
+		var responses int64
+		var samples []appsv1alpha1.ApicastCanaryPodSample
+		errorRate, responses, samples, metricsErr = r.canaryErrorRate(r.apiManager.Status.ApicastCanary.Samples)
+		if metricsErr == nil {
+			maxErrorRate := float64(r.apiManager.ApicastProductionCanaryMaxErrorRate())
+			if errorRate > maxErrorRate {
+				return reconcile.Result{}, r.rollbackCanary(fmt.Sprintf("Canary error rate %.1f%% over %d responses exceeds %.0f%%", errorRate, responses, maxErrorRate))
+			}
+
+			if !reflect.DeepEqual(r.apiManager.Status.ApicastCanary.Samples, samples) {
+				r.apiManager.Status.ApicastCanary.Samples = samples
+				err = r.UpdateResourceStatus(r.apiManager)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+		}
+	}
+
+	analysisDuration := r.apiManager.ApicastProductionCanaryAnalysisDuration()
+	remaining := analysisDuration - time.Since(r.apiManager.Status.ApicastCanary.StartTime.Time)
+	if remaining > 0 {
+		r.Logger().Info("Analyzing apicast-production canary", "rolledOut", rolledOut, "remaining", remaining.Round(time.Second).String())
+		if remaining > apicastCanaryCheckPeriod {
+			remaining = apicastCanaryCheckPeriod
+		}
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	if !rolledOut {
+		return reconcile.Result{}, r.rollbackCanary(fmt.Sprintf("Canary not available within %s", analysisDuration))
+	}
+	if metricsErr != nil {
+		return reconcile.Result{}, r.rollbackCanary(fmt.Sprintf("Canary error rate could not be read: %s", metricsErr))
+	}
+
+	return reconcile.Result{}, r.promoteCanary(candidate)
+}
+
+func (r *ApicastReconciler) startCanary(revision, image string) error {
+	now := metav1.Now()
+	r.apiManager.Status.ApicastCanary = &appsv1alpha1.ApicastCanaryStatus{
+		Phase:     appsv1alpha1.ApicastCanaryPhaseProgressing,
+		Revision:  revision,
+		Image:     image,
+		StartTime: &now,
+	}
+
+	r.Logger().Info("Starting apicast-production canary", "revision", revision, "image", image)
+	return r.UpdateResourceStatus(r.apiManager)
+}
+
+func (r *ApicastReconciler) promoteCanary(candidate *appsv1.DeploymentConfig) error {
+	err := r.UpdateResource(candidate)
+	if err != nil {
+		return err
+	}
+
+	r.Logger().Info("Promoting apicast-production canary", "revision", r.apiManager.Status.ApicastCanary.Revision)
+	r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "CanaryPromoted",
+		"apicast-production canary revision %s promoted", r.apiManager.Status.ApicastCanary.Revision)
+	return r.completeCanary(appsv1alpha1.ApicastCanaryPhasePromoted, "")
+}
+
+// rollbackCanary keeps apicast-production unchanged. The rolled back changes
+// are not retried
+func (r *ApicastReconciler) rollbackCanary(message string) error {
+	r.Logger().Info("Rolling back apicast-production canary", "revision", r.apiManager.Status.ApicastCanary.Revision, "reason", message)
+	r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "CanaryRolledBack", "%s", message)
+	return r.completeCanary(appsv1alpha1.ApicastCanaryPhaseRolledBack, message)
+}
+
+// stopCanary deletes the canary when there are no changes to roll out
+func (r *ApicastReconciler) stopCanary(message string) error {
+	status := r.apiManager.Status.ApicastCanary
+	if status == nil || status.Phase != appsv1alpha1.ApicastCanaryPhaseProgressing {
+		return r.deleteProductionCanary()
+	}
+
+	return r.completeCanary(appsv1alpha1.ApicastCanaryPhaseRolledBack, message)
+}
+
+func (r *ApicastReconciler) completeCanary(phase, message string) error {
+	err := r.deleteProductionCanary()
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	status := r.apiManager.Status.ApicastCanary
+	status.Phase = phase
+	status.Message = message
+	status.CompletionTime = &now
+	return r.UpdateResourceStatus(r.apiManager)
+}
+
+// deleteProductionCanary deletes the canary DeploymentConfig. Objects with
+// the canary name not created by the canary are left untouched
+func (r *ApicastReconciler) deleteProductionCanary() error {
+	existing := &appsv1.DeploymentConfig{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: ApicastCanaryName, Namespace: r.apiManager.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := existing.Annotations[ApicastCanaryRevisionAnnotation]; !ok || !metav1.IsControlledBy(existing, r.apiManager) {
+		r.Logger().Info("DeploymentConfig is not an apicast-production canary. Not deleted", "name", ApicastCanaryName)
+		return nil
+	}
+
+	canary := &appsv1.DeploymentConfig{ObjectMeta: metav1.ObjectMeta{Name: ApicastCanaryName, Namespace: r.apiManager.Namespace}}
+	common.TagObjectToDelete(canary)
+	return r.ReconcileResource(&appsv1.DeploymentConfig{}, canary, reconcilers.CreateOnlyMutator)
+}
+
+// canaryErrorRate returns the percentage of 5xx responses served by the ready
+// canary pods since the given samples were taken, the number of responses
+// and the new samples. apicast_status counters are cumulative, so comparing
+// lifetime totals would keep old errors in the rate
+func (r *ApicastReconciler) canaryErrorRate(previous []appsv1alpha1.ApicastCanaryPodSample) (float64, int64, []appsv1alpha1.ApicastCanaryPodSample, error) {
+	podList := &v1.PodList{}
+	err := r.Client().List(context.TODO(), podList,
+		client.InNamespace(r.apiManager.Namespace),
+		client.MatchingLabels{"deploymentConfig": component.ApicastProductionName, component.ApicastCanaryLabelKey: "true"},
+	)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	var samples []appsv1alpha1.ApicastCanaryPodSample
+	var errorResponses, responses int64
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+		if pod.Status.PodIP == "" || !isPodReady(pod) {
+			continue
+		}
+
+		metricFamilies, err := apicastPodMetrics(pod)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("pod '%s' metrics: %w", pod.Name, err)
+		}
+		podErrorResponses, podResponses := apicastResponses(metricFamilies)
+		sample := appsv1alpha1.ApicastCanaryPodSample{
+			Pod:            pod.Name,
+			Responses:      int64(podResponses),
+			ErrorResponses: int64(podErrorResponses),
+		}
+		samples = append(samples, sample)
+
+		deltaErrorResponses, deltaResponses := apicastCanarySampleDelta(previous, sample)
+		errorResponses += deltaErrorResponses
+		responses += deltaResponses
+	}
+
+	if responses == 0 {
+		return 0, 0, samples, nil
+	}
+
+	return 100 * float64(errorResponses) / float64(responses), responses, samples, nil
+}
+
+// apicastCanarySampleDelta returns the responses counted since the previous
+// sample of the same pod. Counters are reset when the pod is restarted
+func apicastCanarySampleDelta(previous []appsv1alpha1.ApicastCanaryPodSample, sample appsv1alpha1.ApicastCanaryPodSample) (int64, int64) {
+	for _, previousSample := range previous {
+		if previousSample.Pod != sample.Pod {
+			continue
+		}
+		if sample.Responses < previousSample.Responses || sample.ErrorResponses < previousSample.ErrorResponses {
+			break
+		}
+		return sample.ErrorResponses - previousSample.ErrorResponses, sample.Responses - previousSample.Responses
+	}
+
+	return sample.ErrorResponses, sample.Responses
+}
+
+// resolveImageStreamTag returns the image the given ImageStreamTag points
+// to. It is empty until the image has been imported
+func (r *ApicastReconciler) resolveImageStreamTag(imageStreamTag string) (string, error) {
+	parts := strings.SplitN(imageStreamTag, ":", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid ImageStreamTag '%s'", imageStreamTag)
+	}
+
+	imageStream := &imagev1.ImageStream{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: parts[0], Namespace: r.apiManager.Namespace}, imageStream)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, tag := range imageStream.Status.Tags {
+		if tag.Tag == parts[1] && len(tag.Items) > 0 {
+			return tag.Items[0].DockerImageReference, nil
+		}
+	}
+
+	return "", nil
+}
+
+// apicastCanaryDeploymentConfig deploys the candidate pod template. The
+// canary pods keep the apicast-production labels, so they are added to the
+// apicast-production Service endpoints
+func apicastCanaryDeploymentConfig(candidate *appsv1.DeploymentConfig, revision string, replicas int32) *appsv1.DeploymentConfig {
+	template := candidate.Spec.Template.DeepCopy()
+	template.Labels[component.ApicastCanaryLabelKey] = "true"
+
+	return &appsv1.DeploymentConfig{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps.openshift.io/v1", Kind: "DeploymentConfig"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ApicastCanaryName,
+			Labels: candidate.Labels,
+			Annotations: map[string]string{
+				ApicastCanaryRevisionAnnotation: revision,
+			},
+		},
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: replicas,
+			Selector: map[string]string{
+				"deploymentConfig":              component.ApicastProductionName,
+				component.ApicastCanaryLabelKey: "true",
+			},
+			Strategy: *candidate.Spec.Strategy.DeepCopy(),
+			Triggers: appsv1.DeploymentTriggerPolicies{
+				appsv1.DeploymentTriggerPolicy{
+					Type: appsv1.DeploymentTriggerOnConfigChange,
+				},
+			},
+			Template: template,
+		},
+	}
+}
+
+func apicastCanaryMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*appsv1.DeploymentConfig)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", existingObj)
+	}
+	desired, ok := desiredObj.(*appsv1.DeploymentConfig)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", desiredObj)
+	}
+
+	update := false
+
+	if existing.Spec.Replicas != desired.Spec.Replicas {
+		existing.Spec.Replicas = desired.Spec.Replicas
+		update = true
+	}
+
+	// The pod template is replaced when the candidate changes. Comparing it
+	// would detect the defaults set by the API server
+	revision := desired.Annotations[ApicastCanaryRevisionAnnotation]
+	if existing.Annotations[ApicastCanaryRevisionAnnotation] != revision {
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		existing.Annotations[ApicastCanaryRevisionAnnotation] = revision
+		existing.Spec.Template = desired.Spec.Template
+		update = true
+	}
+
+	return update, nil
+}
+
+// apicastImageTriggerMutator reconciles whether the image change trigger
+// rolls out new images automatically. While the canary is enabled, images
+// are set by the operator
+func apicastImageTriggerMutator(desired, existing *appsv1.DeploymentConfig) bool {
+	desiredIdx := imageChangeTriggerIndex(desired)
+	existingIdx := imageChangeTriggerIndex(existing)
+	if desiredIdx < 0 || existingIdx < 0 {
+		return false
+	}
+
+	update := false
+
+	desiredAutomatic := desired.Spec.Triggers[desiredIdx].ImageChangeParams.Automatic
+	if existing.Spec.Triggers[existingIdx].ImageChangeParams.Automatic != desiredAutomatic {
+		existing.Spec.Triggers[existingIdx].ImageChangeParams.Automatic = desiredAutomatic
+		update = true
+	}
+
+	if desiredAutomatic {
+		return update
+	}
+
+	containerImages := map[string]string{}
+	for _, container := range append(desired.Spec.Template.Spec.InitContainers, desired.Spec.Template.Spec.Containers...) {
+		containerImages[container.Name] = container.Image
+	}
+	for _, containers := range [][]v1.Container{existing.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.Containers} {
+		for idx := range containers {
+			image, ok := containerImages[containers[idx].Name]
+			if ok && containers[idx].Image != image {
+				containers[idx].Image = image
+				update = true
+			}
+		}
+	}
+
+	return update
+}
+
+// apicastCanaryReplicas returns the replicas receiving the given percentage
+// of the traffic balanced across the production and canary pods
+func apicastCanaryReplicas(productionReplicas, weight int32) int32 {
+	replicas := int32(math.Ceil(float64(productionReplicas) * float64(weight) / float64(100-weight)))
+	if replicas < 1 {
+		return 1
+	}
+	return replicas
+}
+
+// apicastResponses returns the 5xx and total responses counted by the
+// apicast_status metric
+func apicastResponses(metricFamilies map[string]*dto.MetricFamily) (float64, float64) {
+	var errorResponses, responses float64
+
+	family, ok := metricFamilies["apicast_status"]
+	if !ok {
+		return 0, 0
+	}
+
+	for _, metric := range family.GetMetric() {
+		value := metric.GetCounter().GetValue() + metric.GetUntyped().GetValue()
+		responses += value
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "status" && strings.HasPrefix(label.GetValue(), "5") {
+				errorResponses += value
+			}
+		}
+	}
+
+	return errorResponses, responses
+}
+
+func fetchApicastPodMetrics(pod *v1.Pod) (map[string]*dto.MetricFamily, error) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, component.ApicastMetricsPort))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+func podTemplateRevision(template *v1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16], nil
+}
+
+func imageChangeTriggerIndex(dc *appsv1.DeploymentConfig) int {
+	for idx := range dc.Spec.Triggers {
+		if dc.Spec.Triggers[idx].Type == appsv1.DeploymentTriggerOnImageChange && dc.Spec.Triggers[idx].ImageChangeParams != nil {
+			return idx
+		}
+	}
+	return -1
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
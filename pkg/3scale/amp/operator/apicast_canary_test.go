@@ -0,0 +1,335 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const apicastCanaryTestImage = "registry.example.com/apicast@sha256:1234"
+
+func TestApicastCanaryReplicas(t *testing.T) {
+	cases := []struct {
+		productionReplicas int32
+		weight             int32
+		expected           int32
+	}{
+		{0, 10, 1},
+		{1, 10, 1},
+		{9, 10, 1},
+		{10, 10, 2},
+		{2, 50, 2},
+		{3, 25, 1},
+	}
+
+	for _, tc := range cases {
+		replicas := apicastCanaryReplicas(tc.productionReplicas, tc.weight)
+		if replicas != tc.expected {
+			t.Errorf("%d production replicas with weight %d: expected %d canary replicas, got %d",
+				tc.productionReplicas, tc.weight, tc.expected, replicas)
+		}
+	}
+}
+
+func apicastCanaryTestMetrics(t *testing.T, responses map[string]int) map[string]*dto.MetricFamily {
+	text := "# TYPE apicast_status counter\n"
+	for status, count := range responses {
+		text += fmt.Sprintf("apicast_status{status=\"%s\"} %d\n", status, count)
+	}
+
+	var parser expfmt.TextParser
+	metricFamilies, err := parser.TextToMetricFamilies(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metricFamilies
+}
+
+func TestApicastResponses(t *testing.T) {
+	errorResponses, responses := apicastResponses(apicastCanaryTestMetrics(t, map[string]int{"200": 90, "404": 4, "502": 5, "503": 1}))
+	if errorResponses != 6 || responses != 100 {
+		t.Errorf("unexpected responses: %v 5xx out of %v", errorResponses, responses)
+	}
+
+	errorResponses, responses = apicastResponses(map[string]*dto.MetricFamily{})
+	if errorResponses != 0 || responses != 0 {
+		t.Errorf("unexpected responses without metrics: %v 5xx out of %v", errorResponses, responses)
+	}
+}
+
+func TestApicastCanaryRollout(t *testing.T) {
+	apimanager := basicApimanagerTestApicastOptions()
+	apimanager.Spec.Apicast.ProductionSpec.Canary = &appsv1alpha1.ApicastCanarySpec{Enabled: true}
+
+	imageStream := &imagev1.ImageStream{
+		ObjectMeta: metav1.ObjectMeta{Name: "amp-apicast", Namespace: namespace},
+		Status: imagev1.ImageStreamStatus{
+			Tags: []imagev1.NamedTagEventList{
+				{Tag: product.ThreescaleRelease, Items: []imagev1.TagEvent{{DockerImageReference: apicastCanaryTestImage}}},
+			},
+		},
+	}
+	canaryPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apicast-production-canary-1-abcde",
+			Namespace: namespace,
+			Labels:    map[string]string{"deploymentConfig": component.ApicastProductionName, component.ApicastCanaryLabelKey: "true"},
+		},
+		Status: v1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	for _, addToScheme := range []func(*runtime.Scheme) error{appsv1.AddToScheme, imagev1.AddToScheme, monitoringv1.AddToScheme, grafanav1alpha1.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	objs := []runtime.Object{apimanager, imageStream, canaryPod}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	log := logf.Log.WithName("operator_test")
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+
+	podResponses := map[string]int{"200": 100}
+	defer func(podMetrics func(*v1.Pod) (map[string]*dto.MetricFamily, error)) { apicastPodMetrics = podMetrics }(apicastPodMetrics)
+	apicastPodMetrics = func(*v1.Pod) (map[string]*dto.MetricFamily, error) {
+		return apicastCanaryTestMetrics(t, podResponses), nil
+	}
+
+	reconcileApicast := func() reconcile.Result {
+		res, err := NewApicastReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)).Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	getDeploymentConfig := func(name string) (*appsv1.DeploymentConfig, bool) {
+		dc := &appsv1.DeploymentConfig{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, dc)
+		if errors.IsNotFound(err) {
+			return nil, false
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dc, true
+	}
+	logLevel := func(dc *appsv1.DeploymentConfig) string {
+		envVars := dc.Spec.Template.Spec.Containers[0].Env
+		idx := helper.FindEnvVar(envVars, "APICAST_LOG_LEVEL")
+		if idx < 0 {
+			return ""
+		}
+		return envVars[idx].Value
+	}
+	rollOutCanary := func() {
+		canary, ok := getDeploymentConfig(ApicastCanaryName)
+		if !ok {
+			t.Fatal("canary not created")
+		}
+		err := cl.Update(context.TODO(), rolledOutDeploymentConfig(canary))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Images are set by the operator
+	reconcileApicast()
+	production, ok := getDeploymentConfig(component.ApicastProductionName)
+	if !ok {
+		t.Fatal("apicast-production not created")
+	}
+	if production.Spec.Template.Spec.Containers[0].Image != apicastCanaryTestImage {
+		t.Errorf("unexpected apicast-production image: %s", production.Spec.Template.Spec.Containers[0].Image)
+	}
+	if production.Spec.Triggers[imageChangeTriggerIndex(production)].ImageChangeParams.Automatic {
+		t.Error("apicast-production images rolled out automatically")
+	}
+	if _, ok := getDeploymentConfig(ApicastCanaryName); ok {
+		t.Error("canary created without changes")
+	}
+
+	// Changes are rolled out to the canary, which is rolled back on errors
+	debugLevel := "debug"
+	apimanager.Spec.Apicast.ProductionSpec.LogLevel = &debugLevel
+	res := reconcileApicast()
+	if res.RequeueAfter != apicastCanaryCheckPeriod {
+		t.Errorf("unexpected result: %v", res)
+	}
+	canary, ok := getDeploymentConfig(ApicastCanaryName)
+	if !ok {
+		t.Fatal("canary not created")
+	}
+	if logLevel(canary) != "debug" || canary.Spec.Template.Labels[component.ApicastCanaryLabelKey] != "true" ||
+		canary.Spec.Template.Labels["deploymentConfig"] != component.ApicastProductionName {
+		t.Errorf("unexpected canary pod template: %v", canary.Spec.Template)
+	}
+	if status := apimanager.Status.ApicastCanary; status == nil || status.Phase != appsv1alpha1.ApicastCanaryPhaseProgressing {
+		t.Fatalf("unexpected canary status: %v", status)
+	}
+	production, _ = getDeploymentConfig(component.ApicastProductionName)
+	if logLevel(production) != "" {
+		t.Error("apicast-production updated before the canary is promoted")
+	}
+
+	// The error rate is computed from the responses since the previous
+	// analysis. Lifetime counters would average out recent errors
+	rollOutCanary()
+	podResponses = map[string]int{"200": 1000}
+	reconcileApicast()
+	if status := apimanager.Status.ApicastCanary; status.Phase != appsv1alpha1.ApicastCanaryPhaseProgressing ||
+		len(status.Samples) != 1 || status.Samples[0].Pod != canaryPod.Name || status.Samples[0].Responses != 1000 {
+		t.Fatalf("unexpected canary status: %v", status)
+	}
+	podResponses = map[string]int{"200": 1010, "500": 40}
+	reconcileApicast()
+	if status := apimanager.Status.ApicastCanary; status.Phase != appsv1alpha1.ApicastCanaryPhaseRolledBack || status.CompletionTime == nil {
+		t.Fatalf("unexpected canary status: %v", status)
+	}
+	if _, ok := getDeploymentConfig(ApicastCanaryName); ok {
+		t.Error("rolled back canary not deleted")
+	}
+
+	// Rolled back changes are not retried
+	reconcileApicast()
+	if _, ok := getDeploymentConfig(ApicastCanaryName); ok {
+		t.Error("rolled back changes retried")
+	}
+	production, _ = getDeploymentConfig(component.ApicastProductionName)
+	if logLevel(production) != "" {
+		t.Error("rolled back changes applied to apicast-production")
+	}
+
+	// Healthy canaries are promoted after the analysis duration
+	infoLevel := "info"
+	apimanager.Spec.Apicast.ProductionSpec.LogLevel = &infoLevel
+	podResponses = map[string]int{"200": 100}
+	reconcileApicast()
+	rollOutCanary()
+	reconcileApicast()
+	if status := apimanager.Status.ApicastCanary; status.Phase != appsv1alpha1.ApicastCanaryPhaseProgressing {
+		t.Fatalf("canary completed before the analysis duration: %v", status)
+	}
+
+	startTime := metav1.NewTime(time.Now().Add(-appsv1alpha1.DefaultApicastCanaryAnalysisDuration))
+	apimanager.Status.ApicastCanary.StartTime = &startTime
+	reconcileApicast()
+	if status := apimanager.Status.ApicastCanary; status.Phase != appsv1alpha1.ApicastCanaryPhasePromoted {
+		t.Fatalf("unexpected canary status: %v", status)
+	}
+	production, _ = getDeploymentConfig(component.ApicastProductionName)
+	if logLevel(production) != "info" {
+		t.Error("promoted changes not applied to apicast-production")
+	}
+	if _, ok := getDeploymentConfig(ApicastCanaryName); ok {
+		t.Error("promoted canary not deleted")
+	}
+
+	// Disabling the canary restores the automatic image rollout
+	apimanager.Spec.Apicast.ProductionSpec.Canary.Enabled = false
+	reconcileApicast()
+	production, _ = getDeploymentConfig(component.ApicastProductionName)
+	if !production.Spec.Triggers[imageChangeTriggerIndex(production)].ImageChangeParams.Automatic {
+		t.Error("automatic image rollout not restored")
+	}
+
+	// Objects with the canary name not created by the canary are not deleted
+	foreign := &appsv1.DeploymentConfig{ObjectMeta: metav1.ObjectMeta{Name: ApicastCanaryName, Namespace: namespace}}
+	err := cl.Create(context.TODO(), foreign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconcileApicast()
+	if _, ok := getDeploymentConfig(ApicastCanaryName); !ok {
+		t.Error("deployment config not created by the canary deleted")
+	}
+}
+
+func TestApicastCanarySampleDelta(t *testing.T) {
+	previous := []appsv1alpha1.ApicastCanaryPodSample{
+		{Pod: "canary-a", Responses: 100, ErrorResponses: 10},
+		{Pod: "canary-b", Responses: 100, ErrorResponses: 10},
+	}
+
+	cases := []struct {
+		testName               string
+		sample                 appsv1alpha1.ApicastCanaryPodSample
+		expectedErrorResponses int64
+		expectedResponses      int64
+	}{
+		{"Delta", appsv1alpha1.ApicastCanaryPodSample{Pod: "canary-a", Responses: 150, ErrorResponses: 15}, 5, 50},
+		{"NewPod", appsv1alpha1.ApicastCanaryPodSample{Pod: "canary-c", Responses: 20, ErrorResponses: 1}, 1, 20},
+		{"CounterReset", appsv1alpha1.ApicastCanaryPodSample{Pod: "canary-b", Responses: 30, ErrorResponses: 3}, 3, 30},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			errorResponses, responses := apicastCanarySampleDelta(previous, tc.sample)
+			if errorResponses != tc.expectedErrorResponses || responses != tc.expectedResponses {
+				subT.Errorf("expected %d 5xx out of %d, got %d out of %d",
+					tc.expectedErrorResponses, tc.expectedResponses, errorResponses, responses)
+			}
+		})
+	}
+}
+
+// The canary pods are served by the apicast-production Service, but they are
+// not part of the apicast-production PodDisruptionBudget
+func TestApicastCanaryPodDisruptionBudget(t *testing.T) {
+	options, err := NewApicastOptionsProvider(basicApimanagerTestApicastOptions(), fake.NewFakeClient()).GetApicastOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apicast := component.NewApicast(options)
+	production := apicast.ProductionDeploymentConfig()
+	canary := apicastCanaryDeploymentConfig(production, "1", 1)
+
+	selector, err := metav1.LabelSelectorAsSelector(apicast.ProductionPodDisruptionBudget().Spec.Selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !selector.Matches(labels.Set(production.Spec.Template.Labels)) {
+		t.Errorf("apicast-production pods %v not selected by %s", production.Spec.Template.Labels, selector)
+	}
+	if selector.Matches(labels.Set(canary.Spec.Template.Labels)) {
+		t.Errorf("canary pods %v selected by %s", canary.Spec.Template.Labels, selector)
+	}
+
+	serviceSelector := labels.SelectorFromSet(apicast.ProductionService().Spec.Selector)
+	if !serviceSelector.Matches(labels.Set(canary.Spec.Template.Labels)) {
+		t.Errorf("canary pods %v not selected by the apicast-production Service %s", canary.Spec.Template.Labels, serviceSelector)
+	}
+}
@@ -53,6 +53,8 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	a.apicastOptions.ProductionWorkers = a.apimanager.Spec.Apicast.ProductionSpec.Workers
 	a.apicastOptions.ProductionLogLevel = a.apimanager.Spec.Apicast.ProductionSpec.LogLevel
 	a.apicastOptions.StagingLogLevel = a.apimanager.Spec.Apicast.StagingSpec.LogLevel
+	a.apicastOptions.ProductionOpenTelemetry = openTelemetryConfig(a.apimanager.Spec.Apicast.ProductionSpec.OpenTelemetry, component.OpenTelemetryGRPCProtocol)
+	a.apicastOptions.StagingOpenTelemetry = openTelemetryConfig(a.apimanager.Spec.Apicast.StagingSpec.OpenTelemetry, component.OpenTelemetryGRPCProtocol)
 
 	a.apicastOptions.ProductionHTTPSPort = a.apimanager.Spec.Apicast.ProductionSpec.HTTPSPort
 	a.apicastOptions.ProductionHTTPSVerifyDepth = a.apimanager.Spec.Apicast.ProductionSpec.HTTPSVerifyDepth
@@ -100,6 +102,11 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 
 	a.setProxyConfigurations()
 
+	err = a.setGateways(imageOpts.ApicastImage)
+	if err != nil {
+		return nil, err
+	}
+
 	err = a.apicastOptions.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("GetApicastOptions validating: %w", err)
@@ -419,3 +426,146 @@ func (a *ApicastOptionsProvider) setProductionProxyConfigurations() {
 	a.apicastOptions.ProductionHTTPSProxy = a.apimanager.Spec.Apicast.ProductionSpec.HTTPSProxy
 	a.apicastOptions.ProductionNoProxy = a.apimanager.Spec.Apicast.ProductionSpec.NoProxy
 }
+
+func (a *ApicastOptionsProvider) setGateways(image string) error {
+	for idx := range a.apimanager.Spec.Apicast.Gateways {
+		gatewayOpts, err := a.gatewayOptions(&a.apimanager.Spec.Apicast.Gateways[idx], idx, image)
+		if err != nil {
+			return err
+		}
+		a.apicastOptions.Gateways = append(a.apicastOptions.Gateways, *gatewayOpts)
+	}
+
+	return nil
+}
+
+func (a *ApicastOptionsProvider) gatewayOptions(spec *appsv1alpha1.ApicastGatewaySpec, idx int, image string) (*component.ApicastGatewayOptions, error) {
+	gatewayFldPath := field.NewPath("spec").Child("apicast").Child("gateways").Index(idx)
+
+	opts := &component.ApicastGatewayOptions{
+		Name:              spec.Name,
+		Environment:       spec.Environment,
+		Replicas:          int32(*spec.Replicas),
+		Affinity:          spec.Affinity,
+		Tolerations:       spec.Tolerations,
+		Workers:           spec.Workers,
+		LogLevel:          spec.LogLevel,
+		CommonLabels:      a.gatewayLabels(spec),
+		PodTemplateLabels: a.gatewayPodTemplateLabels(spec, image),
+		HTTPSPort:         spec.HTTPSPort,
+		HTTPSVerifyDepth:  spec.HTTPSVerifyDepth,
+		AllProxy:          spec.AllProxy,
+		HTTPProxy:         spec.HTTPProxy,
+		HTTPSProxy:        spec.HTTPSProxy,
+		NoProxy:           spec.NoProxy,
+	}
+
+	if spec.PortalEndpointSecretRef != nil {
+		_, err := a.secretSource.RequiredFieldValueFromRequiredSecret(spec.PortalEndpointSecretRef.Name, component.ApicastGatewayPortalEndpointSecretKey)
+		if err != nil {
+			errors := field.ErrorList{}
+			errors = append(errors, field.Invalid(gatewayFldPath.Child("portalEndpointSecretRef"), spec.PortalEndpointSecretRef, err.Error()))
+			return nil, errors.ToAggregate()
+		}
+		opts.PortalEndpointSecretName = &spec.PortalEndpointSecretRef.Name
+	}
+
+	if *a.apimanager.Spec.ResourceRequirementsEnabled {
+		if spec.Environment == appsv1alpha1.ApicastGatewayEnvironmentProduction {
+			opts.ResourceRequirements = component.DefaultProductionResourceRequirements()
+		} else {
+			opts.ResourceRequirements = component.DefaultStagingResourceRequirements()
+		}
+	}
+	if spec.Resources != nil {
+		opts.ResourceRequirements = *spec.Resources
+	}
+
+	// when HTTPS certificate is provided and HTTPS port is not provided, assing default https port
+	if spec.HTTPSCertificateSecretRef != nil {
+		if spec.HTTPSPort == nil {
+			tmpDefaultPort := appsv1alpha1.DefaultHTTPSPort
+			opts.HTTPSPort = &tmpDefaultPort
+		}
+		opts.HTTPSCertificateSecretName = &spec.HTTPSCertificateSecretRef.Name
+	}
+
+	for policyIdx, customPolicySpec := range spec.CustomPolicies {
+		// CR Validation ensures secret name is not nil
+		err := a.validateCustomPolicySecret(customPolicySpec.SecretRef.Name)
+		if err != nil {
+			errors := field.ErrorList{}
+			errors = append(errors, field.Invalid(gatewayFldPath.Child("customPolicies").Index(policyIdx), customPolicySpec, err.Error()))
+			return nil, errors.ToAggregate()
+		}
+
+		opts.CustomPolicies = append(opts.CustomPolicies, component.CustomPolicy{
+			Name:      customPolicySpec.Name,
+			Version:   customPolicySpec.Version,
+			SecretRef: *customPolicySpec.SecretRef,
+		})
+	}
+
+	opts.TracingConfig = &component.APIcastTracingConfig{
+		Enabled:        spec.IsOpenTracingEnabled(),
+		TracingLibrary: component.APIcastDefaultTracingLibrary,
+	}
+	if spec.IsOpenTracingEnabled() {
+		if spec.OpenTracing.TracingLibrary != nil {
+			opts.TracingConfig.TracingLibrary = *spec.OpenTracing.TracingLibrary
+		}
+		if spec.OpenTracing.TracingConfigSecretRef != nil {
+			namespacedName := types.NamespacedName{
+				Name:      spec.OpenTracing.TracingConfigSecretRef.Name, // CR Validation ensures not nil
+				Namespace: a.apimanager.Namespace,
+			}
+			err := a.validateTracingConfigSecret(namespacedName)
+			if err != nil {
+				errors := field.ErrorList{}
+				errors = append(errors, field.Invalid(gatewayFldPath.Child("openTracing").Child("tracingConfigSecretRef"), spec.OpenTracing, err.Error()))
+				return nil, errors.ToAggregate()
+			}
+			opts.TracingConfig.TracingConfigSecretName = &spec.OpenTracing.TracingConfigSecretRef.Name
+		}
+	}
+
+	for envIdx, customEnvSpec := range spec.CustomEnvironments {
+		// CR Validation ensures secret name is not nil
+		namespacedName := types.NamespacedName{
+			Name:      customEnvSpec.SecretRef.Name,
+			Namespace: a.apimanager.Namespace,
+		}
+
+		secret, err := a.customEnvironmentSecret(namespacedName)
+		if err != nil {
+			fieldErrors := field.ErrorList{}
+			fieldErrors = append(fieldErrors, field.Invalid(gatewayFldPath.Child("customEnvironments").Index(envIdx), customEnvSpec, err.Error()))
+			return nil, fieldErrors.ToAggregate()
+		}
+
+		opts.CustomEnvironments = append(opts.CustomEnvironments, secret)
+	}
+
+	return opts, nil
+}
+
+func (a *ApicastOptionsProvider) gatewayLabels(spec *appsv1alpha1.ApicastGatewaySpec) map[string]string {
+	// Environment labels are kept so the gateway pods are monitored
+	// along with the pods of the same environment
+	labels := a.commonLabels()
+	labels["threescale_component_element"] = spec.Environment
+	labels[component.ApicastGatewayLabelKey] = spec.Name
+	return labels
+}
+
+func (a *ApicastOptionsProvider) gatewayPodTemplateLabels(spec *appsv1alpha1.ApicastGatewaySpec, image string) map[string]string {
+	labels := helper.MeteringLabels(spec.DeploymentConfigName(), helper.ParseVersion(image), helper.ApplicationType)
+
+	for k, v := range a.gatewayLabels(spec) {
+		labels[k] = v
+	}
+
+	labels["deploymentConfig"] = spec.DeploymentConfigName()
+
+	return labels
+}
@@ -163,6 +163,23 @@ func defaultApicastOptions() *component.ApicastOptions {
 	}
 }
 
+func testApicastOpenTelemetrySpec() *appsv1alpha1.OpenTelemetrySpec {
+	trueValue := true
+	samplingRatio := "0.25"
+	return &appsv1alpha1.OpenTelemetrySpec{
+		Enabled:            &trueValue,
+		Endpoint:           "https://otel-collector:4317",
+		SamplingRatio:      &samplingRatio,
+		ResourceAttributes: map[string]string{"deployment.environment": "test"},
+		TLS: &appsv1alpha1.OpenTelemetryTLSSpec{
+			CACertificateSecretRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "otel-ca"},
+				Key:                  "ca.crt",
+			},
+		},
+	}
+}
+
 func TestGetApicastOptionsProvider(t *testing.T) {
 	falseValue := false
 
@@ -242,6 +259,26 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithOpenTelemetry",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestApicastOptions()
+				apimanager.Spec.Apicast.ProductionSpec.OpenTelemetry = testApicastOpenTelemetrySpec()
+				return apimanager
+			},
+			func() *component.ApicastOptions {
+				opts := defaultApicastOptions()
+				samplingRatio := "0.25"
+				opts.ProductionOpenTelemetry = &component.OpenTelemetryConfig{
+					Endpoint:                "https://otel-collector:4317",
+					Protocol:                component.OpenTelemetryGRPCProtocol,
+					SamplingRatio:           &samplingRatio,
+					ResourceAttributes:      map[string]string{"deployment.environment": "test"},
+					CACertificateSecretName: &[]string{"otel-ca"}[0],
+					CACertificateSecretKey:  &[]string{"ca.crt"}[0],
+				}
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1,12 +1,15 @@
 package operator
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -73,6 +76,7 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		apicastProxyConfigurationsEnvVarMutator,
 		apicastVolumeMountsMutator,
 		apicastVolumesMutator,
+		openTelemetryDCMutator,                 // Should be always after volume mutator
 		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume mutator
 		apicastTracingConfigAnnotationsMutator, // Should be always after volume mutator
 		apicastCustomEnvAnnotationsMutator,     // Should be always after volume mutator
@@ -97,12 +101,14 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		apicastProxyConfigurationsEnvVarMutator,
 		apicastVolumeMountsMutator,
 		apicastVolumesMutator,
+		openTelemetryDCMutator,                 // Should be always after volume mutator
 		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume mutator
 		apicastTracingConfigAnnotationsMutator, // Should be always after volume mutator
 		apicastCustomEnvAnnotationsMutator,     // Should be always after volume
 		portsMutator,
+		apicastImageTriggerMutator,
 	)
-	err = r.ReconcileDeploymentConfig(apicast.ProductionDeploymentConfig(), productionDCMutator)
+	productionResult, err := r.reconcileProductionDeploymentConfig(apicast.ProductionDeploymentConfig(), productionDCMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -119,6 +125,17 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// OpenTelemetry configuration secrets
+	err = r.reconcileOpenTelemetryConfigSecret(apicast.StagingOpenTelemetryConfigSecret(), r.apiManager.IsAPIcastStagingOpenTelemetryEnabled())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileOpenTelemetryConfigSecret(apicast.ProductionOpenTelemetryConfigSecret(), r.apiManager.IsAPIcastProductionOpenTelemetryEnabled())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Environment ConfigMap
 	err = r.ReconcileConfigMap(apicast.EnvironmentConfigMap(), ApicastEnvCMMutator)
 	if err != nil {
@@ -137,6 +154,11 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	err = r.reconcileGateways(apicast)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	err = r.ReconcileGrafanaDashboard(apicast.ApicastMainAppGrafanaDashboard(), reconcilers.GenericGrafanaDashboardsMutator)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -147,22 +169,117 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(apicast.ApicastPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(apicast.ApicastPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(apicast.ApicastProductionPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(apicast.ApicastProductionPodMonitor(), apicast.ApicastProductionServiceMonitor(), apicast.ApicastProductionMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(apicast.ApicastStagingPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(apicast.ApicastStagingPodMonitor(), apicast.ApicastStagingServiceMonitor(), apicast.ApicastStagingMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	// Requeues while the apicast-production canary is analyzed
+	return productionResult, nil
+}
+
+// reconcileGateways reconciles the additional APIcast gateways and deletes
+// the gateways no longer defined in the APIManager
+func (r *ApicastReconciler) reconcileGateways(apicast *component.Apicast) error {
+	gatewayDCMutator := reconcilers.DeploymentConfigMutator(
+		reconcilers.DeploymentConfigReplicasMutator,
+		reconcilers.DeploymentConfigContainerResourcesMutator,
+		reconcilers.DeploymentConfigAffinityMutator,
+		reconcilers.DeploymentConfigTolerationsMutator,
+		apicastPortalEndpointEnvVarMutator,
+		apicastProductionWorkersEnvVarMutator,
+		apicastLogLevelEnvVarMutator,
+		apicastTracingConfigEnvVarsMutator,
+		apicastEnvironmentEnvVarMutator,
+		apicastHTTPSEnvVarMutator,
+		apicastProxyConfigurationsEnvVarMutator,
+		apicastVolumeMountsMutator,
+		apicastVolumesMutator,
+		apicastCustomPolicyAnnotationsMutator,  // Should be always after volume mutator
+		apicastTracingConfigAnnotationsMutator, // Should be always after volume mutator
+		apicastCustomEnvAnnotationsMutator,     // Should be always after volume mutator
+		portsMutator,
+	)
+
+	desiredNames := []string{}
+	for idx := range apicast.Options.Gateways {
+		gateway := &apicast.Options.Gateways[idx]
+		desiredNames = append(desiredNames, gateway.DeploymentConfigName())
+
+		err := r.ReconcileDeploymentConfig(apicast.GatewayDeploymentConfig(gateway), gatewayDCMutator)
+		if err != nil {
+			return err
+		}
+
+		err = r.ReconcileService(apicast.GatewayService(gateway), reconcilers.ServicePortMutator)
+		if err != nil {
+			return err
+		}
+
+		err = r.ReconcilePodDisruptionBudget(apicast.GatewayPodDisruptionBudget(gateway), reconcilers.GenericPDBMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	existingGateways := &appsv1.DeploymentConfigList{}
+	err := r.Client().List(context.TODO(), existingGateways,
+		client.InNamespace(r.apiManager.Namespace),
+		client.HasLabels{component.ApicastGatewayLabelKey},
+	)
+	if err != nil {
+		return err
+	}
+
+	for idx := range existingGateways.Items {
+		existing := &existingGateways.Items[idx]
+		if helper.ArrayContains(desiredNames, existing.Name) || !metav1.IsControlledBy(existing, r.apiManager) {
+			continue
+		}
+
+		objectMeta := metav1.ObjectMeta{Name: existing.Name, Namespace: r.apiManager.Namespace}
+		deletions := []struct {
+			obj     common.KubernetesObject
+			desired common.KubernetesObject
+		}{
+			{&appsv1.DeploymentConfig{}, &appsv1.DeploymentConfig{ObjectMeta: objectMeta}},
+			{&v1.Service{}, &v1.Service{ObjectMeta: objectMeta}},
+			{&v1beta1.PodDisruptionBudget{}, &v1beta1.PodDisruptionBudget{ObjectMeta: objectMeta}},
+		}
+		for _, deletion := range deletions {
+			common.TagObjectToDelete(deletion.desired)
+			err = r.ReconcileResource(deletion.obj, deletion.desired, reconcilers.CreateOnlyMutator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileOpenTelemetryConfigSecret reconciles the OpenTelemetry configuration
+// secret of an APIcast environment. The secret is deleted when disabled
+func (r *ApicastReconciler) reconcileOpenTelemetryConfigSecret(secret *v1.Secret, enabled bool) error {
+	if !enabled {
+		common.TagObjectToDelete(secret)
+	}
+	return r.ReconcileSecret(secret, reconcilers.SecretStringDataMutator)
+}
+
+func apicastPortalEndpointEnvVarMutator(desired, existing *appsv1.DeploymentConfig) bool {
+	// Reconcile EnvVar only for "THREESCALE_PORTAL_ENDPOINT"
+	return reconcilers.DeploymentConfigEnvVarReconciler(desired, existing, "THREESCALE_PORTAL_ENDPOINT")
 }
 
 func apicastProductionWorkersEnvVarMutator(desired, existing *appsv1.DeploymentConfig) bool {
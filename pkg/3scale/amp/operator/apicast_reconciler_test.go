@@ -2,6 +2,7 @@ package operator
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -11,6 +12,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,6 +24,7 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 )
 
@@ -550,3 +553,307 @@ func TestApicastReconcilerTracingConfigParts(t *testing.T) {
 		t.Fatal("desiredTracingConfig1 tracing config annotation not found. Should have been created")
 	}
 }
+
+func TestApicastReconcilerOpenTelemetryParts(t *testing.T) {
+	var (
+		name                       = "example-apimanager"
+		namespace                  = "operator-unittest"
+		wildcardDomain             = "test.3scale.net"
+		log                        = logf.Log.WithName("operator_test")
+		appLabel                   = "someLabel"
+		tenantName                 = "someTenant"
+		apicastManagementAPI       = "disabled"
+		trueValue                  = true
+		oneValue             int64 = 1
+		caSecretName               = "otel-ca"
+		caSecretKey                = "ca.crt"
+	)
+
+	// apicast-production was deployed with OpenTelemetry enabled
+	apicast := component.NewApicast(&component.ApicastOptions{
+		StagingTracingConfig:    &component.APIcastTracingConfig{},
+		ProductionTracingConfig: &component.APIcastTracingConfig{},
+		ProductionOpenTelemetry: &component.OpenTelemetryConfig{
+			Endpoint:                "https://otel-collector:4317",
+			Protocol:                component.OpenTelemetryGRPCProtocol,
+			CACertificateSecretName: &caSecretName,
+			CACertificateSecretKey:  &caSecretKey,
+		},
+	})
+	existingProdDC := apicast.ProductionDeploymentConfig()
+	existingProdDC.Namespace = namespace
+	existingProdSecret := apicast.ProductionOpenTelemetryConfigSecret()
+	existingProdSecret.Namespace = namespace
+
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				AppLabel:                     &appLabel,
+				ImageStreamTagImportInsecure: &trueValue,
+				WildcardDomain:               wildcardDomain,
+				TenantName:                   &tenantName,
+				ResourceRequirementsEnabled:  &trueValue,
+			},
+			Apicast: &appsv1alpha1.ApicastSpec{
+				ApicastManagementAPI: &apicastManagementAPI,
+				OpenSSLVerify:        &trueValue,
+				IncludeResponseCodes: &trueValue,
+				StagingSpec: &appsv1alpha1.ApicastStagingSpec{
+					Replicas: &oneValue,
+					OpenTelemetry: &appsv1alpha1.OpenTelemetrySpec{
+						Enabled:  &trueValue,
+						Endpoint: "http://otel-collector:4317",
+					},
+				},
+				ProductionSpec: &appsv1alpha1.ApicastProductionSpec{
+					Replicas: &oneValue,
+				},
+			},
+		},
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager, existingProdDC, existingProdSecret}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = imagev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = routev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := monitoringv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := grafanav1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	ctx := context.TODO()
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	apicastReconciler := NewApicastReconciler(baseAPIManagerLogicReconciler)
+	_, err = apicastReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert apicast-production:
+	// - OpenTelemetry env vars, volumes and volume mounts deleted
+	productionDC := &appsv1.DeploymentConfig{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "apicast-production", Namespace: namespace}, productionDC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, envVar := range component.OpenTelemetryEnvVarNames {
+		if helper.FindEnvVar(productionDC.Spec.Template.Spec.Containers[0].Env, envVar) >= 0 {
+			t.Errorf("production %s env var found. Should have been deleted", envVar)
+		}
+	}
+	for _, volumeName := range []string{component.APIcastOpenTelemetryConfigVolumeName, component.OpenTelemetryTLSVolumeName} {
+		if helper.FindVolumeByName(productionDC.Spec.Template.Spec.Volumes, volumeName) >= 0 {
+			t.Errorf("production %s volume found. Should have been deleted", volumeName)
+		}
+		if helper.FindVolumeMountByName(productionDC.Spec.Template.Spec.Containers[0].VolumeMounts, volumeName) >= 0 {
+			t.Errorf("production %s volume mount found. Should have been deleted", volumeName)
+		}
+	}
+	// - OpenTelemetry configuration secret deleted
+	err = cl.Get(ctx, types.NamespacedName{Name: component.APIcastProductionOpenTelemetryConfigSecretName, Namespace: namespace}, &v1.Secret{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("production OpenTelemetry secret should have been deleted: %v", err)
+	}
+
+	// Assert apicast-staging:
+	// - OpenTelemetry configuration secret created
+	stagingSecret := &v1.Secret{}
+	err = cl.Get(ctx, types.NamespacedName{Name: component.APIcastStagingOpenTelemetryConfigSecretName, Namespace: namespace}, stagingSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := stagingSecret.StringData[component.APIcastOpenTelemetryConfigSecretKey]
+	if !strings.Contains(config, `host = "otel-collector"`) || strings.Contains(config, "use_ssl") {
+		t.Errorf("unexpected staging OpenTelemetry configuration: %s", config)
+	}
+	// - OpenTelemetry configuration mounted
+	stagingDC := &appsv1.DeploymentConfig{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "apicast-staging", Namespace: namespace}, stagingDC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if helper.FindEnvVar(stagingDC.Spec.Template.Spec.Containers[0].Env, "OPENTELEMETRY_CONFIG") < 0 {
+		t.Error("staging OPENTELEMETRY_CONFIG env var not found. Should have been created")
+	}
+	if helper.FindVolumeByName(stagingDC.Spec.Template.Spec.Volumes, component.APIcastOpenTelemetryConfigVolumeName) < 0 {
+		t.Error("staging OpenTelemetry configuration volume not found. Should have been created")
+	}
+}
+
+func TestApicastReconcilerGateways(t *testing.T) {
+	var (
+		name                       = "example-apimanager"
+		namespace                  = "operator-unittest"
+		wildcardDomain             = "test.3scale.net"
+		log                        = logf.Log.WithName("operator_test")
+		appLabel                   = "someLabel"
+		tenantName                 = "someTenant"
+		trueValue                  = true
+		apicastManagementAPI       = "disabled"
+		oneValue             int64 = 1
+		twoValue             int64 = 2
+		workers              int32 = 4
+	)
+
+	ctx := context.TODO()
+
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				AppLabel:                     &appLabel,
+				ImageStreamTagImportInsecure: &trueValue,
+				WildcardDomain:               wildcardDomain,
+				TenantName:                   &tenantName,
+				ResourceRequirementsEnabled:  &trueValue,
+			},
+			Apicast: &appsv1alpha1.ApicastSpec{
+				ApicastManagementAPI: &apicastManagementAPI,
+				OpenSSLVerify:        &trueValue,
+				IncludeResponseCodes: &trueValue,
+				StagingSpec: &appsv1alpha1.ApicastStagingSpec{
+					Replicas: &oneValue,
+				},
+				ProductionSpec: &appsv1alpha1.ApicastProductionSpec{
+					Replicas: &oneValue,
+				},
+				Gateways: []appsv1alpha1.ApicastGatewaySpec{
+					{
+						Name:                    "internal",
+						Environment:             appsv1alpha1.ApicastGatewayEnvironmentProduction,
+						Replicas:                &twoValue,
+						Workers:                 &workers,
+						PortalEndpointSecretRef: &v1.LocalObjectReference{Name: "internal-portal-endpoint"},
+					},
+					{
+						Name:        "external",
+						Environment: appsv1alpha1.ApicastGatewayEnvironmentStaging,
+						Replicas:    &oneValue,
+					},
+				},
+			},
+			PodDisruptionBudget: &appsv1alpha1.PodDisruptionBudgetSpec{Enabled: true},
+		},
+	}
+
+	portalEndpointSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-portal-endpoint", Namespace: namespace},
+		Data: map[string][]byte{
+			component.ApicastGatewayPortalEndpointSecretKey: []byte("https://token@internal-admin.test.3scale.net"),
+		},
+	}
+
+	objs := []runtime.Object{apimanager, portalEndpointSecret}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitoringv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := grafanav1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+	apicastReconciler := NewApicastReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+	_, err := apicastReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internalDC := &appsv1.DeploymentConfig{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "apicast-internal", Namespace: namespace}, internalDC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if internalDC.Spec.Replicas != 2 {
+		t.Errorf("unexpected replicas: %d", internalDC.Spec.Replicas)
+	}
+	if internalDC.Spec.Template.Labels["threescale_component_element"] != appsv1alpha1.ApicastGatewayEnvironmentProduction {
+		t.Errorf("unexpected pod template labels: %v", internalDC.Spec.Template.Labels)
+	}
+
+	env := internalDC.Spec.Template.Spec.Containers[0].Env
+	portalEndpointIdx := helper.FindEnvVar(env, "THREESCALE_PORTAL_ENDPOINT")
+	if portalEndpointIdx < 0 || env[portalEndpointIdx].ValueFrom.SecretKeyRef.Name != "internal-portal-endpoint" {
+		t.Errorf("portal endpoint not read from the gateway secret: %v", env)
+	}
+	if workersIdx := helper.FindEnvVar(env, "APICAST_WORKERS"); workersIdx < 0 || env[workersIdx].Value != "4" {
+		t.Errorf("unexpected workers: %v", env)
+	}
+
+	cases := []struct {
+		testName string
+		objName  string
+		obj      runtime.Object
+	}{
+		{"internalService", "apicast-internal", &v1.Service{}},
+		{"internalPDB", "apicast-internal", &v1beta1.PodDisruptionBudget{}},
+		{"externalDeployment", "apicast-external", &appsv1.DeploymentConfig{}},
+		{"externalService", "apicast-external", &v1.Service{}},
+		{"externalPDB", "apicast-external", &v1beta1.PodDisruptionBudget{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			err := cl.Get(ctx, types.NamespacedName{Name: tc.objName, Namespace: namespace}, tc.obj)
+			if err != nil {
+				subT.Errorf("error fetching object %s: %v", tc.objName, err)
+			}
+		})
+	}
+
+	// Gateways removed from the spec are deleted
+	apimanager.Spec.Apicast.Gateways = apimanager.Spec.Apicast.Gateways[:1]
+	_, err = apicastReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, obj := range []runtime.Object{&appsv1.DeploymentConfig{}, &v1.Service{}, &v1beta1.PodDisruptionBudget{}} {
+		err = cl.Get(ctx, types.NamespacedName{Name: "apicast-external", Namespace: namespace}, obj)
+		if !errors.IsNotFound(err) {
+			t.Errorf("expected %T apicast-external to be deleted, got: %v", obj, err)
+		}
+	}
+
+	err = cl.Get(ctx, types.NamespacedName{Name: "apicast-internal", Namespace: namespace}, &appsv1.DeploymentConfig{})
+	if err != nil {
+		t.Errorf("error fetching apicast-internal: %v", err)
+	}
+}
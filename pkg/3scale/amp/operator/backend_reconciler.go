@@ -91,12 +91,12 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(backend.BackendWorkerPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(backend.BackendWorkerPodMonitor(), backend.BackendWorkerServiceMonitor(), backend.BackendWorkerMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(backend.BackendListenerPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(backend.BackendListenerPodMonitor(), backend.BackendListenerServiceMonitor(), backend.BackendListenerMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -106,12 +106,12 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(backend.BackendWorkerPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(backend.BackendWorkerPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(backend.BackendListenerPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(backend.BackendListenerPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
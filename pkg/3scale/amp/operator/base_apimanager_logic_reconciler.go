@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/prometheusrules"
 	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
@@ -133,6 +134,12 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePrometheusRules(desired *monito
 
 	if !r.apiManager.IsPrometheusRulesEnabled() {
 		common.TagObjectToDelete(desired)
+	} else {
+		desired.Namespace = r.apiManager.Namespace
+		err = prometheusrules.ApplyOverrides(desired, r.apiManager.Spec.Monitoring.PrometheusRules)
+		if err != nil {
+			return fmt.Errorf("Failed to apply prometheus rules overrides to %s: %w", desired.Name, err)
+		}
 	}
 	return r.ReconcileResource(&monitoringv1.PrometheusRule{}, desired, mutateFn)
 }
@@ -144,7 +151,7 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileServiceMonitor(desired *monitor
 	}
 
 	if !kindExists {
-		if r.apiManager.IsMonitoringEnabled() {
+		if r.apiManager.IsMonitoringEnabled() && !common.IsObjectTaggedToDelete(desired) {
 			errToLog := fmt.Errorf("Error creating servicemonitor object '%s'. Install prometheus-operator in your cluster to create servicemonitor objects", desired.Name)
 			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "ReconcileError", errToLog.Error())
 			r.logger.Error(errToLog, "ReconcileError")
@@ -165,7 +172,7 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodMonitor(desired *monitoringv
 	}
 
 	if !kindExists {
-		if r.apiManager.IsMonitoringEnabled() {
+		if r.apiManager.IsMonitoringEnabled() && !common.IsObjectTaggedToDelete(desired) {
 			errToLog := fmt.Errorf("Error creating podmonitor object '%s'. Install prometheus-operator in your cluster to create podmonitor objects", desired.Name)
 			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "ReconcileError", errToLog.Error())
 			r.logger.Error(errToLog, "ReconcileError")
@@ -179,6 +186,37 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodMonitor(desired *monitoringv
 	return r.ReconcileResource(&monitoringv1.PodMonitor{}, desired, mutateFn)
 }
 
+// ReconcileMonitor reconciles the monitor scraping a component, of the kind
+// set in the monitoring spec. The monitor of the other kind is deleted, and
+// so is the metrics service when PodMonitors are used
+func (r *BaseAPIManagerLogicReconciler) ReconcileMonitor(podMonitor *monitoringv1.PodMonitor, serviceMonitor *monitoringv1.ServiceMonitor, metricsService *v1.Service) error {
+	var scrape *appsv1alpha1.ScrapeSpec
+	if r.apiManager.Spec.Monitoring != nil {
+		scrape = r.apiManager.Spec.Monitoring.Scrape
+	}
+
+	if r.apiManager.IsServiceMonitorEnabled() {
+		common.TagObjectToDelete(podMonitor)
+		applyServiceMonitorScrapeSpec(serviceMonitor, scrape)
+	} else {
+		common.TagObjectToDelete(serviceMonitor)
+		common.TagObjectToDelete(metricsService)
+		applyPodMonitorScrapeSpec(podMonitor, scrape)
+	}
+
+	err := r.ReconcilePodMonitor(podMonitor, reconcilers.GenericPodMonitorMutator)
+	if err != nil {
+		return err
+	}
+
+	err = r.ReconcileService(metricsService, reconcilers.ServicePortMutator)
+	if err != nil {
+		return err
+	}
+
+	return r.ReconcileServiceMonitor(serviceMonitor, reconcilers.GenericServiceMonitorMutator)
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileResource(obj, desired common.KubernetesObject, mutatefn reconcilers.MutateFn) error {
 	desired.SetNamespace(r.apiManager.GetNamespace())
 
@@ -11,6 +11,7 @@ import (
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
@@ -505,3 +506,83 @@ func TestBaseAPIManagerLogicReconcilerHasServiceMonitors(t *testing.T) {
 		t.Fatalf("Unexpected exists value received. Expected: %t, got: %t", false, exists)
 	}
 }
+
+func TestBaseAPIManagerLogicReconcilerReconcileMonitor(t *testing.T) {
+	var (
+		log            = logf.Log.WithName("operator_test")
+		serviceMonitor = appsv1alpha1.ServiceMonitorType
+		interval       = "30s"
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+		Enabled:     true,
+		MonitorType: &serviceMonitor,
+		Scrape: &appsv1alpha1.ScrapeSpec{
+			Interval:    &interval,
+			DropMetrics: []string{"rack_.*", "ruby_gc_.*"},
+		},
+	}
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := monitoringv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: monitoringv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: monitoringv1.PodMonitorName, Namespaced: true, Kind: monitoringv1.PodMonitorsKind},
+				{Name: monitoringv1.ServiceMonitorName, Namespaced: true, Kind: monitoringv1.ServiceMonitorsKind},
+			},
+		},
+	}
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	backend, err := Backend(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = apimanagerLogicReconciler.ReconcileMonitor(backend.BackendListenerPodMonitor(), backend.BackendListenerServiceMonitor(), backend.BackendListenerMetricsService())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconciledServiceMonitor := &monitoringv1.ServiceMonitor{}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "backend-listener"}, reconciledServiceMonitor)
+	if err != nil {
+		t.Fatalf("error fetching servicemonitor: %v", err)
+	}
+	endpoint := reconciledServiceMonitor.Spec.Endpoints[0]
+	if endpoint.Interval != interval {
+		t.Errorf("servicemonitor interval does not match. got [%s], expected [%s]", endpoint.Interval, interval)
+	}
+	if len(endpoint.MetricRelabelConfigs) != 1 || endpoint.MetricRelabelConfigs[0].Regex != "rack_.*|ruby_gc_.*" {
+		t.Errorf("servicemonitor does not drop the metrics: %v", endpoint.MetricRelabelConfigs)
+	}
+
+	err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "backend-listener-metrics"}, &v1.Service{})
+	if err != nil {
+		t.Fatalf("error fetching metrics service: %v", err)
+	}
+
+	err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "backend-listener"}, &monitoringv1.PodMonitor{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("podmonitor should not exist: %v", err)
+	}
+}
@@ -0,0 +1,385 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// databaseCredentialsRotationTarget describes an internal database whose
+// credentials are stored in a secret and the DeploymentConfigs that have to
+// be rolled out, in order, once the secret has been rewritten
+type databaseCredentialsRotationTarget struct {
+	secretName string
+	// rotateSecret generates new credentials on the secret data
+	rotateSecret func(data map[string][]byte) error
+	databaseDC   string
+	// mutateDatabaseDC applies credentials that the database DeploymentConfig
+	// does not read from the secret. Optional
+	mutateDatabaseDC func(data map[string][]byte, dc *appsv1.DeploymentConfig) error
+	dependentDCs     []string
+}
+
+type DatabaseCredentialsRotationReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewDatabaseCredentialsRotationReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *DatabaseCredentialsRotationReconciler {
+	return &DatabaseCredentialsRotationReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *DatabaseCredentialsRotationReconciler) Reconcile() (reconcile.Result, error) {
+	targets, err := r.rotationTargets()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(targets) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	if !r.apiManager.Status.Conditions.IsTrueFor(appsv1alpha1.APIManagerDatabaseCredentialsRotatingConditionType) {
+		nextRotation := r.nextScheduledRotation()
+		trigger, requested := r.rotationRequested()
+		if !requested && (nextRotation == nil || nextRotation.After(time.Now())) {
+			return r.nextScheduledRotationResult(), nil
+		}
+
+		err = r.startRotation(trigger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	revision := strconv.FormatInt(r.apiManager.Status.DatabaseCredentialsRotation.Revision, 10)
+	for _, target := range targets {
+		res, err := r.rotateTarget(target, revision)
+		if err != nil || res.Requeue {
+			return res, err
+		}
+	}
+
+	err = r.completeRotation()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.nextScheduledRotationResult(), nil
+}
+
+// rotationRequested returns the value of the rotation annotation and whether
+// it has not been processed yet
+func (r *DatabaseCredentialsRotationReconciler) rotationRequested() (string, bool) {
+	trigger, ok := r.apiManager.Annotations[appsv1alpha1.DatabaseCredentialsRotationAnnotation]
+	if !ok || trigger == "" {
+		return "", false
+	}
+
+	status := r.apiManager.Status.DatabaseCredentialsRotation
+	return trigger, status == nil || status.ObservedTrigger != trigger
+}
+
+// nextScheduledRotation returns the time of the next rotation according to
+// the configured interval. It returns nil when no interval is configured
+func (r *DatabaseCredentialsRotationReconciler) nextScheduledRotation() *time.Time {
+	interval := r.apiManager.DatabaseCredentialsRotationInterval()
+	if interval == nil {
+		return nil
+	}
+
+	lastRotation := r.apiManager.CreationTimestamp.Time
+	status := r.apiManager.Status.DatabaseCredentialsRotation
+	if status != nil && status.LastRotationTime != nil {
+		lastRotation = status.LastRotationTime.Time
+	}
+
+	nextRotation := lastRotation.Add(interval.Duration)
+	return &nextRotation
+}
+
+func (r *DatabaseCredentialsRotationReconciler) nextScheduledRotationResult() reconcile.Result {
+	nextRotation := r.nextScheduledRotation()
+	if nextRotation == nil {
+		return reconcile.Result{}
+	}
+
+	return reconcile.Result{RequeueAfter: time.Until(*nextRotation)}
+}
+
+func (r *DatabaseCredentialsRotationReconciler) startRotation(trigger string) error {
+	if r.apiManager.Status.DatabaseCredentialsRotation == nil {
+		r.apiManager.Status.DatabaseCredentialsRotation = &appsv1alpha1.DatabaseCredentialsRotationStatus{}
+	}
+
+	status := r.apiManager.Status.DatabaseCredentialsRotation
+	if trigger != "" {
+		status.ObservedTrigger = trigger
+	}
+	status.Revision++
+
+	r.Logger().Info("Starting database credentials rotation", "revision", status.Revision)
+	return r.updateRotatingCondition(v1.ConditionTrue, appsv1alpha1.DatabaseCredentialsRotationStartedReason,
+		fmt.Sprintf("Rotating database credentials to revision %d", status.Revision))
+}
+
+func (r *DatabaseCredentialsRotationReconciler) completeRotation() error {
+	now := metav1.Now()
+	r.apiManager.Status.DatabaseCredentialsRotation.LastRotationTime = &now
+
+	r.Logger().Info("Database credentials rotation completed", "revision", r.apiManager.Status.DatabaseCredentialsRotation.Revision)
+	return r.updateRotatingCondition(v1.ConditionFalse, appsv1alpha1.DatabaseCredentialsRotationCompletedReason,
+		fmt.Sprintf("Database credentials rotated to revision %d", r.apiManager.Status.DatabaseCredentialsRotation.Revision))
+}
+
+func (r *DatabaseCredentialsRotationReconciler) updateRotatingCondition(status v1.ConditionStatus, reason common.ConditionReason, message string) error {
+	r.apiManager.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerDatabaseCredentialsRotatingConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+
+	return r.UpdateResourceStatus(r.apiManager)
+}
+
+func (r *DatabaseCredentialsRotationReconciler) rotateTarget(target databaseCredentialsRotationTarget, revision string) (reconcile.Result, error) {
+	secret := &v1.Secret{}
+	err := r.Client().Get(context.TODO(), types.NamespacedName{Name: target.secretName, Namespace: r.apiManager.Namespace}, secret)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if secret.Annotations[appsv1alpha1.DatabaseCredentialsRevisionAnnotation] != revision {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		err = target.rotateSecret(secret.Data)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("rotating '%s' secret credentials: %w", target.secretName, err)
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[appsv1alpha1.DatabaseCredentialsRevisionAnnotation] = revision
+
+		err = r.UpdateResource(secret)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		err = r.updateRotatingCondition(v1.ConditionTrue, appsv1alpha1.DatabaseCredentialsSecretsRotatedReason,
+			fmt.Sprintf("Secret '%s' updated with new credentials", target.secretName))
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The database has to be running with the new credentials before
+	// its clients are restarted
+	databaseMutator := func(dc *appsv1.DeploymentConfig) error {
+		if target.mutateDatabaseDC == nil {
+			return nil
+		}
+		return target.mutateDatabaseDC(secret.Data, dc)
+	}
+	res, err := r.rolloutDeploymentConfigs([]string{target.databaseDC}, revision, databaseMutator, appsv1alpha1.DatabaseCredentialsDatabaseRolloutReason)
+	if err != nil || res.Requeue {
+		return res, err
+	}
+
+	return r.rolloutDeploymentConfigs(target.dependentDCs, revision, nil, appsv1alpha1.DatabaseCredentialsDependentsRolloutReason)
+}
+
+// rolloutDeploymentConfigs triggers a new rollout of the given DeploymentConfigs
+// by setting the credentials revision in their pod template. It requeues
+// until all of them are rolled out
+func (r *DatabaseCredentialsRotationReconciler) rolloutDeploymentConfigs(dcNames []string, revision string, mutateFn func(*appsv1.DeploymentConfig) error, reason common.ConditionReason) (reconcile.Result, error) {
+	pending := []string{}
+	for _, dcName := range dcNames {
+		dc := &appsv1.DeploymentConfig{}
+		err := r.Client().Get(context.TODO(), types.NamespacedName{Name: dcName, Namespace: r.apiManager.Namespace}, dc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		if dc.Spec.Template == nil {
+			return reconcile.Result{}, fmt.Errorf("DeploymentConfig %s has no pod template", dcName)
+		}
+
+		if dc.Spec.Template.Annotations[appsv1alpha1.DatabaseCredentialsRevisionAnnotation] != revision {
+			if mutateFn != nil {
+				err = mutateFn(dc)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+
+			if dc.Spec.Template.Annotations == nil {
+				dc.Spec.Template.Annotations = map[string]string{}
+			}
+			dc.Spec.Template.Annotations[appsv1alpha1.DatabaseCredentialsRevisionAnnotation] = revision
+
+			err = r.UpdateResource(dc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			pending = append(pending, dcName)
+		} else if !helper.IsDeploymentConfigRolledOut(dc) {
+			pending = append(pending, dcName)
+		}
+	}
+
+	if len(pending) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	err := r.updateRotatingCondition(v1.ConditionTrue, reason, fmt.Sprintf("Waiting for rollout of %v", pending))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *DatabaseCredentialsRotationReconciler) rotationTargets() ([]databaseCredentialsRotationTarget, error) {
+	targets := []databaseCredentialsRotationTarget{}
+
+	if !r.apiManager.IsExternalDatabaseEnabled() {
+		system, err := System(r.apiManager, r.Client())
+		if err != nil {
+			return nil, err
+		}
+		systemDCs := []string{
+			system.AppDeploymentConfig().Name,
+			system.SidekiqDeploymentConfig().Name,
+			system.SphinxDeploymentConfig().Name,
+		}
+
+		if r.apiManager.IsSystemPostgreSQLEnabled() {
+			systemPostgreSQL, err := SystemPostgreSQL(r.apiManager, r.Client())
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, databaseCredentialsRotationTarget{
+				secretName:   component.SystemSecretSystemDatabaseSecretName,
+				rotateSecret: rotateSystemPostgreSQLCredentials,
+				databaseDC:   systemPostgreSQL.DeploymentConfig().Name,
+				dependentDCs: systemDCs,
+			})
+		} else {
+			systemMySQL, err := SystemMySQL(r.apiManager, r.Client())
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, databaseCredentialsRotationTarget{
+				secretName:       component.SystemSecretSystemDatabaseSecretName,
+				rotateSecret:     rotateSystemMySQLCredentials,
+				databaseDC:       systemMySQL.DeploymentConfig().Name,
+				mutateDatabaseDC: systemMySQLRootPasswordMutator,
+				dependentDCs:     systemDCs,
+			})
+		}
+	}
+
+	if !r.apiManager.IsZyncExternalDatabaseEnabled() {
+		zync, err := Zync(r.apiManager, r.Client())
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, databaseCredentialsRotationTarget{
+			secretName:   component.ZyncSecretName,
+			rotateSecret: rotateZyncDatabaseCredentials,
+			databaseDC:   zync.DatabaseDeploymentConfig().Name,
+			dependentDCs: []string{zync.DeploymentConfig().Name, zync.QueDeploymentConfig().Name},
+		})
+	}
+
+	return targets, nil
+}
+
+// rotateSystemMySQLCredentials rotates both the mysql user password and the
+// root password, which is the one used by system in the database URL
+func rotateSystemMySQLCredentials(data map[string][]byte) error {
+	databaseURL, err := databaseURLWithPassword(string(data[component.SystemSecretSystemDatabaseURLFieldName]), component.DefaultSystemMysqlRootPassword())
+	if err != nil {
+		return err
+	}
+
+	data[component.SystemSecretSystemDatabasePasswordFieldName] = []byte(component.DefaultSystemMysqlPassword())
+	data[component.SystemSecretSystemDatabaseURLFieldName] = []byte(databaseURL)
+	return nil
+}
+
+func rotateSystemPostgreSQLCredentials(data map[string][]byte) error {
+	password := component.DefaultSystemPostgresqlPassword()
+	databaseURL, err := databaseURLWithPassword(string(data[component.SystemSecretSystemDatabaseURLFieldName]), password)
+	if err != nil {
+		return err
+	}
+
+	data[component.SystemSecretSystemDatabasePasswordFieldName] = []byte(password)
+	data[component.SystemSecretSystemDatabaseURLFieldName] = []byte(databaseURL)
+	return nil
+}
+
+func rotateZyncDatabaseCredentials(data map[string][]byte) error {
+	password := component.DefaultZyncDatabasePassword()
+	databaseURL, err := databaseURLWithPassword(string(data[component.ZyncSecretDatabaseURLFieldName]), password)
+	if err != nil {
+		return err
+	}
+
+	data[component.ZyncSecretDatabasePasswordFieldName] = []byte(password)
+	data[component.ZyncSecretDatabaseURLFieldName] = []byte(databaseURL)
+	return nil
+}
+
+// systemMySQLRootPasswordMutator sets the root password of the database URL
+// in the system-mysql container, as it is not read from the secret
+func systemMySQLRootPasswordMutator(data map[string][]byte, dc *appsv1.DeploymentConfig) error {
+	urlObj, err := url.Parse(string(data[component.SystemSecretSystemDatabaseURLFieldName]))
+	if err != nil {
+		return err
+	}
+	rootPassword, _ := urlObj.User.Password()
+
+	for idx := range dc.Spec.Template.Spec.Containers {
+		container := &dc.Spec.Template.Spec.Containers[idx]
+		if envVarIdx := helper.FindEnvVar(container.Env, "MYSQL_ROOT_PASSWORD"); envVarIdx >= 0 {
+			container.Env[envVarIdx] = helper.EnvVarFromValue("MYSQL_ROOT_PASSWORD", rootPassword)
+		}
+	}
+
+	return nil
+}
+
+// databaseURLWithPassword returns the given database URL with the
+// password of its authentication information replaced
+func databaseURLWithPassword(rawURL, password string) (string, error) {
+	urlObj, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid database URL: %w", err)
+	}
+	if urlObj.User == nil || urlObj.User.Username() == "" {
+		return "", fmt.Errorf("database URL does not contain a username")
+	}
+
+	urlObj.User = url.UserPassword(urlObj.User.Username(), password)
+	return urlObj.String(), nil
+}
@@ -1,9 +1,18 @@
 package operator
 
 import (
+	"context"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -31,10 +40,120 @@ func (r *GenericMonitoringReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	prometheusRule := component.KubeStateMetricsPrometheusRules(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel)
-	err = r.ReconcilePrometheusRules(prometheusRule, reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(prometheusRule, reconcilers.GenericPrometheusRulesMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileSLO()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileProductDashboards()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	return reconcile.Result{}, nil
 }
+
+// reconcileSLO reconciles the SLO burn rate alerts and dashboard. They are
+// deleted when the service level objectives are not enabled
+func (r *GenericMonitoringReconciler) reconcileSLO() error {
+	// The objectives are only read when enabled. Otherwise the defaults are
+	// enough to name the objects to delete
+	sloOpts := &component.SLOOptions{
+		ApicastProduction: component.DefaultApicastProductionServiceLevelObjective(),
+		BackendListener:   component.DefaultBackendListenerServiceLevelObjective(),
+	}
+	var err error
+	if r.apiManager.IsSLOEnabled() {
+		sloOpts, err = NewSLOOptionsProvider(r.apiManager).GetSLOOptions()
+		if err != nil {
+			return err
+		}
+	}
+
+	prometheusRules := []*monitoringv1.PrometheusRule{
+		component.ApicastProductionSLOPrometheusRules(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel, sloOpts.ApicastProduction),
+		component.BackendListenerSLOPrometheusRules(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel, sloOpts.BackendListener),
+	}
+	for _, prometheusRule := range prometheusRules {
+		if !r.apiManager.IsSLOEnabled() {
+			common.TagObjectToDelete(prometheusRule)
+		}
+		err = r.ReconcilePrometheusRules(prometheusRule, reconcilers.GenericPrometheusRulesMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	grafanaDashboard := component.SLOGrafanaDashboard(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel, sloOpts)
+	if !r.apiManager.IsSLOEnabled() {
+		common.TagObjectToDelete(grafanaDashboard)
+	}
+	return r.ReconcileGrafanaDashboard(grafanaDashboard, reconcilers.GenericGrafanaDashboardsMutator)
+}
+
+// reconcileProductDashboards reconciles one dashboard for each product of
+// the APIManager namespace. Dashboards of deleted products are deleted
+func (r *GenericMonitoringReconciler) reconcileProductDashboards() error {
+	kindExists, err := r.HasGrafanaDashboards()
+	if err != nil {
+		return err
+	}
+	if !kindExists {
+		// Missing grafana-operator is already reported by the other dashboards
+		return nil
+	}
+
+	productList := &capabilitiesv1beta1.ProductList{}
+	err = r.Client().List(context.TODO(), productList, client.InNamespace(r.apiManager.Namespace))
+	if err != nil {
+		return err
+	}
+
+	desiredNames := []string{}
+	for idx := range productList.Items {
+		product := &productList.Items[idx]
+		// System name is set by the product controller defaults
+		if product.DeletionTimestamp != nil || product.Spec.SystemName == "" {
+			continue
+		}
+
+		grafanaDashboard := component.ProductGrafanaDashboard(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel, product)
+		err = r.ReconcileGrafanaDashboard(grafanaDashboard, reconcilers.GenericGrafanaDashboardsMutator)
+		if err != nil {
+			return err
+		}
+		desiredNames = append(desiredNames, grafanaDashboard.Name)
+	}
+
+	existingDashboards := &grafanav1alpha1.GrafanaDashboardList{}
+	err = r.Client().List(context.TODO(), existingDashboards,
+		client.InNamespace(r.apiManager.Namespace),
+		client.HasLabels{component.ProductGrafanaDashboardLabelKey},
+	)
+	if err != nil {
+		return err
+	}
+
+	for idx := range existingDashboards.Items {
+		existing := &existingDashboards.Items[idx]
+		if helper.ArrayContains(desiredNames, existing.Name) || !metav1.IsControlledBy(existing, r.apiManager) {
+			continue
+		}
+
+		grafanaDashboard := &grafanav1alpha1.GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: existing.Name, Namespace: r.apiManager.Namespace},
+		}
+		common.TagObjectToDelete(grafanaDashboard)
+		err = r.ReconcileGrafanaDashboard(grafanaDashboard, reconcilers.GenericGrafanaDashboardsMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGenericMonitoringReconcilerProductDashboards(t *testing.T) {
+	var (
+		log      = logf.Log.WithName("operator_test")
+		trueTest = true
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.UID = "apimanager-uid"
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{Enabled: true}
+
+	product := &capabilitiesv1beta1.Product{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: namespace},
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name:       "Pet Store",
+			SystemName: "petstore",
+			Methods: map[string]capabilitiesv1beta1.MethodSpec{
+				"list_pets": {Name: "List pets"},
+			},
+			MappingRules: []capabilitiesv1beta1.MappingRuleSpec{
+				{HTTPMethod: "GET", Pattern: "/pets$", MetricMethodRef: "list_pets", Increment: 1, Last: &trueTest},
+			},
+			ApplicationPlans: map[string]capabilitiesv1beta1.ApplicationPlanSpec{
+				"basic": {
+					Limits: []capabilitiesv1beta1.LimitSpec{
+						{Period: "minute", Value: 10, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "list_pets"}},
+					},
+				},
+			},
+		},
+	}
+
+	// Dashboard of a deleted product
+	deletedProductDashboard := &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component.ProductGrafanaDashboardName("deleted"),
+			Namespace: namespace,
+			Labels:    map[string]string{component.ProductGrafanaDashboardLabelKey: "deleted"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: appsv1alpha1.GroupVersion.String(),
+					Kind:       "APIManager",
+					Name:       apimanager.Name,
+					UID:        apimanager.UID,
+					Controller: &trueTest,
+				},
+			},
+		},
+	}
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := grafanav1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager, product, deletedProductDashboard}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: grafanav1alpha1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "grafanadashboards", Namespaced: true, Kind: grafanav1alpha1.GrafanaDashboardKind},
+			},
+		},
+	}
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	monitoringReconciler := NewGenericMonitoringReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	err := monitoringReconciler.reconcileProductDashboards()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dashboard := &grafanav1alpha1.GrafanaDashboard{}
+	err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "threescale-product-petstore"}, dashboard)
+	if err != nil {
+		t.Fatalf("error fetching product dashboard: %v", err)
+	}
+	if dashboard.Labels[component.ProductGrafanaDashboardLabelKey] != product.Name {
+		t.Errorf("product dashboard label does not match. got [%s], expected [%s]", dashboard.Labels[component.ProductGrafanaDashboardLabelKey], product.Name)
+	}
+
+	dashboardJSON := struct {
+		Title  string
+		Panels []struct {
+			Title   string
+			Type    string
+			Content string
+			Targets []struct {
+				Expr string
+			}
+		}
+	}{}
+	err = json.Unmarshal([]byte(dashboard.Spec.Json), &dashboardJSON)
+	if err != nil {
+		t.Fatalf("product dashboard is not valid json: %v", err)
+	}
+	if dashboardJSON.Title != namespace+" / 3scale / Product / Pet Store" {
+		t.Errorf("product dashboard title does not match. got [%s]", dashboardJSON.Title)
+	}
+	for _, panel := range dashboardJSON.Panels {
+		for _, target := range panel.Targets {
+			if !strings.Contains(target.Expr, "service_system_name='petstore'") {
+				t.Errorf("panel %s query is not filtered by product: %s", panel.Title, target.Expr)
+			}
+		}
+		if panel.Type == "text" && panel.Title == "Mapping rules" && !strings.Contains(panel.Content, "| GET | `/pets$` | List pets (list_pets) | 1 | true |") {
+			t.Errorf("mapping rules panel does not match: %s", panel.Content)
+		}
+		if panel.Type == "text" && panel.Title == "Application plan limits" && !strings.Contains(panel.Content, "| basic | List pets (list_pets) | minute | 10 |") {
+			t.Errorf("application plan limits panel does not match: %s", panel.Content)
+		}
+	}
+
+	err = cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: deletedProductDashboard.Name}, &grafanav1alpha1.GrafanaDashboard{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("dashboard of the deleted product should not exist: %v", err)
+	}
+}
+
+func TestGenericMonitoringReconcilerSLODisabledIgnoresInvalidObjectives(t *testing.T) {
+	var (
+		log                = logf.Log.WithName("operator_test")
+		availabilityTarget = "100"
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+		Enabled: true,
+		SLO: &appsv1alpha1.SLOSpec{
+			Enabled: false,
+			ApicastProduction: &appsv1alpha1.ServiceLevelObjectiveSpec{
+				AvailabilityTarget: &availabilityTarget,
+			},
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+
+	objs := []runtime.Object{apimanager}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	monitoringReconciler := NewGenericMonitoringReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	err := monitoringReconciler.reconcileSLO()
+	if err != nil {
+		t.Fatalf("invalid objectives should be ignored when SLO is disabled: %v", err)
+	}
+
+	apimanager.Spec.Monitoring.SLO.Enabled = true
+	err = monitoringReconciler.reconcileSLO()
+	if err == nil {
+		t.Fatal("invalid objectives should be reported when SLO is enabled")
+	}
+}
@@ -1,6 +1,10 @@
 package operator
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -49,6 +53,10 @@ func (h *HighAvailabilityOptionsProvider) GetHighAvailabilityOptions() (*compone
 }
 
 func (h *HighAvailabilityOptionsProvider) setBackendRedisOptions() error {
+	if h.apimanager.IsBackendRedisSpecEnabled() {
+		return h.setBackendRedisOptionsFromSpec()
+	}
+
 	cases := []struct {
 		field       *string
 		secretName  string
@@ -118,6 +126,100 @@ func (h *HighAvailabilityOptionsProvider) setBackendRedisOptions() error {
 	return nil
 }
 
+// setBackendRedisOptionsFromSpec translates backend redis spec into the
+// backend-redis secret fields consumed by backend components
+func (h *HighAvailabilityOptionsProvider) setBackendRedisOptionsFromSpec() error {
+	redisSpec := h.apimanager.Spec.Backend.Redis
+
+	cases := []struct {
+		spec          *appsv1alpha1.BackendRedisEndpointSpec
+		endpoint      *string
+		sentinelHosts *string
+		sentinelRole  *string
+	}{
+		{
+			&redisSpec.Storage,
+			&h.options.BackendRedisStorageEndpoint,
+			&h.options.BackendRedisStorageSentinelHosts,
+			&h.options.BackendRedisStorageSentinelRole,
+		},
+		{
+			&redisSpec.Queues,
+			&h.options.BackendRedisQueuesEndpoint,
+			&h.options.BackendRedisQueuesSentinelHosts,
+			&h.options.BackendRedisQueuesSentinelRole,
+		},
+	}
+
+	for _, option := range cases {
+		endpoint, err := h.backendRedisEndpointURL(option.spec)
+		if err != nil {
+			return err
+		}
+		*option.endpoint = endpoint
+
+		sentinelHosts, err := h.backendRedisSentinelHosts(option.spec)
+		if err != nil {
+			return err
+		}
+		*option.sentinelHosts = sentinelHosts
+
+		*option.sentinelRole = ""
+		if option.spec.Sentinel != nil && option.spec.Sentinel.Role != nil {
+			*option.sentinelRole = *option.spec.Sentinel.Role
+		}
+	}
+
+	return nil
+}
+
+func (h *HighAvailabilityOptionsProvider) backendRedisEndpointURL(spec *appsv1alpha1.BackendRedisEndpointSpec) (string, error) {
+	endpointURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid backend redis url '%s': %w", spec.URL, err)
+	}
+
+	if spec.CredentialsSecretRef != nil {
+		username, err := h.secretSource.FieldValueFromRequiredSecret(spec.CredentialsSecretRef.Name,
+			component.BackendRedisCredentialsSecretUsernameFieldName, "")
+		if err != nil {
+			return "", err
+		}
+		password, err := h.secretSource.RequiredFieldValueFromRequiredSecret(spec.CredentialsSecretRef.Name,
+			component.BackendRedisCredentialsSecretPasswordFieldName)
+		if err != nil {
+			return "", err
+		}
+		endpointURL.User = url.UserPassword(username, password)
+	}
+
+	return endpointURL.String(), nil
+}
+
+func (h *HighAvailabilityOptionsProvider) backendRedisSentinelHosts(spec *appsv1alpha1.BackendRedisEndpointSpec) (string, error) {
+	if !spec.IsTopology(appsv1alpha1.BackendRedisTopologySentinel) || spec.Sentinel == nil {
+		return "", nil
+	}
+
+	var sentinelUser *url.Userinfo
+	if spec.Sentinel.CredentialsSecretRef != nil {
+		password, err := h.secretSource.RequiredFieldValueFromRequiredSecret(spec.Sentinel.CredentialsSecretRef.Name,
+			component.BackendRedisCredentialsSecretPasswordFieldName)
+		if err != nil {
+			return "", err
+		}
+		sentinelUser = url.UserPassword("", password)
+	}
+
+	hosts := make([]string, 0, len(spec.Sentinel.Hosts))
+	for _, host := range spec.Sentinel.Hosts {
+		sentinelURL := url.URL{Scheme: "redis", Host: host, User: sentinelUser}
+		hosts = append(hosts, sentinelURL.String())
+	}
+
+	return strings.Join(hosts, ","), nil
+}
+
 func (h *HighAvailabilityOptionsProvider) setSystemRedisOptions() error {
 	cases := []struct {
 		field       *string
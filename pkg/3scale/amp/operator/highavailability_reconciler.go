@@ -26,7 +26,12 @@ func (r *HighAvailabilityReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Backend Redis Secret
-	err = r.ReconcileSecret(ha.BackendRedisSecret(), reconcilers.DefaultsOnlySecretMutator)
+	backendRedisSecretMutator := reconcilers.DefaultsOnlySecretMutator
+	if r.apiManager.IsBackendRedisSpecEnabled() {
+		// secret content is owned by the backend redis spec
+		backendRedisSecretMutator = reconcilers.SecretStringDataMutator
+	}
+	err = r.ReconcileSecret(ha.BackendRedisSecret(), backendRedisSecretMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
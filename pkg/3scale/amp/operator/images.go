@@ -1,6 +1,9 @@
 package operator
 
 import (
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
@@ -44,3 +47,66 @@ func SystemPostgreSQLImageURL() string {
 func ZyncPostgreSQLImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_ZYNC_POSTGRESQL", component.ZyncPostgreSQLImageURL())
 }
+
+// BackupAgentImageURL returns the image running the backup agent. It is the
+// operator image
+func BackupAgentImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_BACKUP_AGENT", component.BackupAgentImageURL())
+}
+
+// RegistryImageURL returns the given image pulled through the image registry
+// mirrors or override. The image is returned unchanged when no image registry
+// is configured
+func RegistryImageURL(imageRegistry *appsv1alpha1.ImageRegistrySpec, image string) string {
+	if imageRegistry == nil {
+		return image
+	}
+
+	registry, repository := splitImageRegistry(image)
+	name := registry + "/" + repository
+
+	var mirror *appsv1alpha1.ImageRegistryMirrorSpec
+	for idx := range imageRegistry.Mirrors {
+		source := strings.TrimSuffix(imageRegistry.Mirrors[idx].Source, "/")
+		if !imageNameHasPrefix(name, source) {
+			continue
+		}
+		if mirror == nil || len(source) > len(strings.TrimSuffix(mirror.Source, "/")) {
+			mirror = &imageRegistry.Mirrors[idx]
+		}
+	}
+	if mirror != nil {
+		source := strings.TrimSuffix(mirror.Source, "/")
+		return strings.TrimSuffix(mirror.Mirror, "/") + strings.TrimPrefix(name, source)
+	}
+
+	if imageRegistry.Override != nil && *imageRegistry.Override != "" {
+		return strings.TrimSuffix(*imageRegistry.Override, "/") + "/" + repository
+	}
+
+	return image
+}
+
+// splitImageRegistry returns the registry and the repository of the given
+// image, with the tag or digest. Images without registry are pulled from
+// Docker Hub
+func splitImageRegistry(image string) (string, string) {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0], parts[1]
+	}
+	if len(parts) == 1 {
+		return "docker.io", "library/" + image
+	}
+	return "docker.io", image
+}
+
+// imageNameHasPrefix returns whether the given image name belongs to the
+// given registry or repository prefix
+func imageNameHasPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	rest := name[len(prefix):]
+	return rest == "" || strings.HasPrefix(rest, "/") || strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "@")
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 )
 
@@ -66,3 +67,42 @@ func TestImageURLDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryImageURL(t *testing.T) {
+	override := "registry.example.com:5000"
+	imageRegistry := &appsv1alpha1.ImageRegistrySpec{
+		Override: &override,
+		Mirrors: []appsv1alpha1.ImageRegistryMirrorSpec{
+			{Source: "quay.io", Mirror: "mirror.example.com/quay"},
+			{Source: "quay.io/3scale/apicast", Mirror: "mirror.example.com/apicast"},
+			{Source: "docker.io/centos/", Mirror: "mirror.example.com/centos/"},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		imageRegistry *appsv1alpha1.ImageRegistrySpec
+		image         string
+		expected      string
+	}{
+		{"NoImageRegistry", nil, "quay.io/3scale/apicast:latest", "quay.io/3scale/apicast:latest"},
+		{"RegistryMirror", imageRegistry, "quay.io/3scale/porta:latest", "mirror.example.com/quay/3scale/porta:latest"},
+		{"LongestMirror", imageRegistry, "quay.io/3scale/apicast:latest", "mirror.example.com/apicast:latest"},
+		{"RepositoryBoundary", imageRegistry, "quay.io/3scale/apicast-operator:latest", "mirror.example.com/quay/3scale/apicast-operator:latest"},
+		{"Digest", imageRegistry, "quay.io/3scale/apicast@sha256:1234", "mirror.example.com/apicast@sha256:1234"},
+		{"DockerHubMirror", imageRegistry, "centos/redis-5-centos7", "mirror.example.com/centos/redis-5-centos7"},
+		{"DockerHubOfficialOverride", imageRegistry, "memcached:1.5", "registry.example.com:5000/library/memcached:1.5"},
+		{"RegistryOverride", imageRegistry, "registry.redhat.io/openshift4/ose-cli:4.2", "registry.example.com:5000/openshift4/ose-cli:4.2"},
+		{"LocalhostOverride", imageRegistry, "localhost/3scale/zync:latest", "registry.example.com:5000/3scale/zync:latest"},
+		{"NoOverride", &appsv1alpha1.ImageRegistrySpec{}, "memcached:1.5", "memcached:1.5"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			imageURL := RegistryImageURL(tc.imageRegistry, tc.image)
+			if imageURL != tc.expected {
+				subT.Errorf("image url does not match. Expected: %s, got: %s", tc.expected, imageURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MaintenanceScaleDownDeploymentNames are the DeploymentConfigs that do not
+// hold any data and can be scaled to zero during maintenance
+var MaintenanceScaleDownDeploymentNames = []string{
+	component.ApicastStagingName,
+	component.ApicastProductionName,
+	component.BackendListenerName,
+	component.BackendWorkerName,
+	component.BackendCronName,
+	component.SystemMemcachedDeploymentName,
+	component.SystemAppDeploymentName,
+	component.SystemSidekiqName,
+	component.SystemSphinxDeploymentName,
+	component.ZyncName,
+	component.ZyncQueDeploymentName,
+}
+
+type MaintenanceReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewMaintenanceReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *MaintenanceReconciler {
+	return &MaintenanceReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+// Reconcile scales down the non-data DeploymentConfigs when the maintenance
+// scale down is enabled, and restores their replicas once it is disabled.
+// The APIManager is requeued while replicas are being restored, so the
+// DeploymentConfigs are only reconciled once every one of them has its
+// original replicas back
+func (r *MaintenanceReconciler) Reconcile() (reconcile.Result, error) {
+	restoring := false
+	dcNames := []string{}
+	dcNames = append(dcNames, MaintenanceScaleDownDeploymentNames...)
+	dcNames = append(dcNames, r.apiManager.ApicastGatewayDeploymentNames("")...)
+
+	for _, dcName := range dcNames {
+		existing := &appsv1.DeploymentConfig{}
+		err := r.Client().Get(context.TODO(), types.NamespacedName{Name: dcName, Namespace: r.apiManager.Namespace}, existing)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return reconcile.Result{}, err
+		}
+
+		var changed bool
+		if r.apiManager.IsMaintenanceScaleDownEnabled() {
+			changed = maintenanceScaleDown(existing)
+		} else {
+			changed, err = maintenanceRestoreReplicas(existing)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			restoring = restoring || changed
+		}
+
+		if changed {
+			err = r.UpdateResource(existing)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	return reconcile.Result{Requeue: restoring}, nil
+}
+
+func maintenanceScaleDown(dc *appsv1.DeploymentConfig) bool {
+	if _, ok := dc.Annotations[appsv1alpha1.MaintenanceReplicasAnnotation]; ok {
+		// Already scaled down. Keep the original replicas
+		if dc.Spec.Replicas == 0 {
+			return false
+		}
+	} else {
+		if dc.Annotations == nil {
+			dc.Annotations = map[string]string{}
+		}
+		dc.Annotations[appsv1alpha1.MaintenanceReplicasAnnotation] = strconv.FormatInt(int64(dc.Spec.Replicas), 10)
+	}
+
+	dc.Spec.Replicas = 0
+	return true
+}
+
+func maintenanceRestoreReplicas(dc *appsv1.DeploymentConfig) (bool, error) {
+	replicasStr, ok := dc.Annotations[appsv1alpha1.MaintenanceReplicasAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	replicas, err := strconv.ParseInt(replicasStr, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("DeploymentConfig %s has invalid '%s' annotation value: %w", dc.Name, appsv1alpha1.MaintenanceReplicasAnnotation, err)
+	}
+
+	dc.Spec.Replicas = int32(replicas)
+	delete(dc.Annotations, appsv1alpha1.MaintenanceReplicasAnnotation)
+	return true, nil
+}
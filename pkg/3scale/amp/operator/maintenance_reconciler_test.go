@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestMaintenanceReconcilerScaleDownAndRestore(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Spec.Maintenance = &appsv1alpha1.MaintenanceSpec{ScaleDownComponents: true}
+
+	dcReplicas := map[string]int32{
+		component.ApicastProductionName:     3,
+		component.SystemSidekiqName:         2,
+		component.SystemMySQLDeploymentName: 1,
+	}
+
+	objs := []runtime.Object{apimanager}
+	for dcName, replicas := range dcReplicas {
+		objs = append(objs, &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: dcName, Namespace: namespace},
+			Spec:       appsv1.DeploymentConfigSpec{Replicas: replicas},
+		})
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	log := logf.Log.WithName("operator_test")
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), record.NewFakeRecorder(10000))
+	maintenanceReconciler := NewMaintenanceReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	getReplicas := func(dcName string) (int32, bool) {
+		dc := &appsv1.DeploymentConfig{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: dcName, Namespace: namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, annotated := dc.Annotations[appsv1alpha1.MaintenanceReplicasAnnotation]
+		return dc.Spec.Replicas, annotated
+	}
+
+	// reconciling twice must not lose the original replicas
+	for i := 0; i < 2; i++ {
+		res, err := maintenanceReconciler.Reconcile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Requeue {
+			t.Error("scale down requeued")
+		}
+	}
+
+	for _, dcName := range []string{component.ApicastProductionName, component.SystemSidekiqName} {
+		if replicas, annotated := getReplicas(dcName); replicas != 0 || !annotated {
+			t.Errorf("%s not scaled down: replicas %d", dcName, replicas)
+		}
+	}
+	if replicas, annotated := getReplicas(component.SystemMySQLDeploymentName); replicas != 1 || annotated {
+		t.Errorf("data component %s scaled down", component.SystemMySQLDeploymentName)
+	}
+
+	apimanager.Spec.Maintenance.ScaleDownComponents = false
+	res, err := maintenanceReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Requeue {
+		t.Error("replicas restore not requeued")
+	}
+
+	for dcName, expectedReplicas := range dcReplicas {
+		if replicas, annotated := getReplicas(dcName); replicas != expectedReplicas || annotated {
+			t.Errorf("%s replicas not restored: expected %d, got %d", dcName, expectedReplicas, replicas)
+		}
+	}
+
+	// once every replica is restored the reconciliation goes on
+	res, err = maintenanceReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Requeue {
+		t.Error("restored replicas requeued")
+	}
+}
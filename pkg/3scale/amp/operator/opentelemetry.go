@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"reflect"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// openTelemetryConfig returns the component configuration of the given spec.
+// Nil is returned when OpenTelemetry is not enabled
+func openTelemetryConfig(spec *appsv1alpha1.OpenTelemetrySpec, defaultProtocol string) *component.OpenTelemetryConfig {
+	if !spec.IsEnabled() {
+		return nil
+	}
+
+	config := &component.OpenTelemetryConfig{
+		Endpoint:           spec.Endpoint,
+		Protocol:           defaultProtocol,
+		SamplingRatio:      spec.SamplingRatio,
+		ResourceAttributes: spec.ResourceAttributes,
+	}
+	if spec.Protocol != nil {
+		config.Protocol = *spec.Protocol
+	}
+	if spec.TLS != nil && spec.TLS.CACertificateSecretRef != nil {
+		config.CACertificateSecretName = &spec.TLS.CACertificateSecretRef.Name
+		config.CACertificateSecretKey = &spec.TLS.CACertificateSecretRef.Key
+	}
+
+	return config
+}
+
+// openTelemetryDCMutator reconciles the OpenTelemetry env vars, volume mounts
+// and volumes of every container of the DeploymentConfig. Other env vars,
+// volume mounts and volumes are left untouched
+func openTelemetryDCMutator(desired, existing *appsv1.DeploymentConfig) bool {
+	changed := false
+
+	openTelemetryVolumeNames := []string{
+		component.APIcastOpenTelemetryConfigVolumeName,
+		component.OpenTelemetryTLSVolumeName,
+	}
+
+	for idx := range desired.Spec.Template.Spec.Containers {
+		if idx >= len(existing.Spec.Template.Spec.Containers) {
+			break
+		}
+		desiredContainer := &desired.Spec.Template.Spec.Containers[idx]
+		existingContainer := &existing.Spec.Template.Spec.Containers[idx]
+
+		for _, envVar := range component.OpenTelemetryEnvVarNames {
+			tmpChanged := reconcilers.DeploymentConfigContainerEnvVarReconciler(desired, existing, idx, envVar)
+			changed = changed || tmpChanged
+		}
+
+		for _, volumeName := range openTelemetryVolumeNames {
+			desiredIdx := helper.FindVolumeMountByName(desiredContainer.VolumeMounts, volumeName)
+			existingIdx := helper.FindVolumeMountByName(existingContainer.VolumeMounts, volumeName)
+			if desiredIdx < 0 && existingIdx >= 0 {
+				existingContainer.VolumeMounts = append(existingContainer.VolumeMounts[:existingIdx], existingContainer.VolumeMounts[existingIdx+1:]...)
+				changed = true
+			} else if desiredIdx >= 0 && existingIdx < 0 {
+				existingContainer.VolumeMounts = append(existingContainer.VolumeMounts, desiredContainer.VolumeMounts[desiredIdx])
+				changed = true
+			} else if desiredIdx >= 0 && !reflect.DeepEqual(existingContainer.VolumeMounts[existingIdx], desiredContainer.VolumeMounts[desiredIdx]) {
+				existingContainer.VolumeMounts[existingIdx] = desiredContainer.VolumeMounts[desiredIdx]
+				changed = true
+			}
+		}
+	}
+
+	existingSpec := &existing.Spec.Template.Spec
+	desiredSpec := &desired.Spec.Template.Spec
+	for _, volumeName := range openTelemetryVolumeNames {
+		desiredIdx := helper.FindVolumeByName(desiredSpec.Volumes, volumeName)
+		existingIdx := helper.FindVolumeByName(existingSpec.Volumes, volumeName)
+		if desiredIdx < 0 && existingIdx >= 0 {
+			existingSpec.Volumes = append(existingSpec.Volumes[:existingIdx], existingSpec.Volumes[existingIdx+1:]...)
+			changed = true
+		} else if desiredIdx >= 0 && existingIdx < 0 {
+			existingSpec.Volumes = append(existingSpec.Volumes, desiredSpec.Volumes[desiredIdx])
+			changed = true
+		} else if desiredIdx >= 0 && !openTelemetryVolumeEqual(existingSpec.Volumes[existingIdx], desiredSpec.Volumes[desiredIdx]) {
+			existingSpec.Volumes[existingIdx] = desiredSpec.Volumes[desiredIdx]
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// openTelemetryVolumeEqual compares the secret and items of the volumes, the
+// fields defaulted by the API server are ignored
+func openTelemetryVolumeEqual(a, b v1.Volume) bool {
+	return helper.VolumeFromSecretEqual(a, b) && reflect.DeepEqual(a.Secret.Items, b.Secret.Items)
+}
@@ -46,6 +46,9 @@ func (r *RedisOptionsProvider) GetRedisOptions() (*component.RedisOptions, error
 		r.options.SystemImage = *r.apimanager.Spec.System.RedisImage
 	}
 
+	r.options.BackendImage = RegistryImageURL(r.apimanager.Spec.ImageRegistry, r.options.BackendImage)
+	r.options.SystemImage = RegistryImageURL(r.apimanager.Spec.ImageRegistry, r.options.SystemImage)
+
 	r.options.SystemCommonLabels = r.systemCommonLabels()
 	r.options.SystemRedisLabels = r.systemRedisLabels()
 	r.options.SystemRedisPodTemplateLabels = r.systemRedisPodTemplateLabels(r.options.SystemImage)
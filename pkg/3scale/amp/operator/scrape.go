@@ -0,0 +1,112 @@
+package operator
+
+import (
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+// applyPodMonitorScrapeSpec applies the scrape interval, relabelings and
+// dropped metrics of the given spec to the endpoints of the given PodMonitor
+func applyPodMonitorScrapeSpec(podMonitor *monitoringv1.PodMonitor, scrape *appsv1alpha1.ScrapeSpec) {
+	if scrape == nil {
+		return
+	}
+
+	for idx := range podMonitor.Spec.PodMetricsEndpoints {
+		endpoint := &podMonitor.Spec.PodMetricsEndpoints[idx]
+		if scrape.Interval != nil {
+			endpoint.Interval = *scrape.Interval
+		}
+		endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, scrapeRelabelConfigs(scrape)...)
+		endpoint.MetricRelabelConfigs = append(endpoint.MetricRelabelConfigs, scrapeMetricRelabelConfigs(scrape)...)
+	}
+}
+
+// applyServiceMonitorScrapeSpec applies the given spec to the endpoints of the
+// given ServiceMonitor. On top of the PodMonitor settings, ServiceMonitors
+// accept the scheme, TLS and bearer token used to scrape
+func applyServiceMonitorScrapeSpec(serviceMonitor *monitoringv1.ServiceMonitor, scrape *appsv1alpha1.ScrapeSpec) {
+	if scrape == nil {
+		return
+	}
+
+	for idx := range serviceMonitor.Spec.Endpoints {
+		endpoint := &serviceMonitor.Spec.Endpoints[idx]
+		if scrape.Interval != nil {
+			endpoint.Interval = *scrape.Interval
+		}
+		endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, scrapeRelabelConfigs(scrape)...)
+		endpoint.MetricRelabelConfigs = append(endpoint.MetricRelabelConfigs, scrapeMetricRelabelConfigs(scrape)...)
+		if scrape.Scheme != nil {
+			endpoint.Scheme = *scrape.Scheme
+		}
+		endpoint.TLSConfig = scrapeTLSConfig(scrape.TLSConfig)
+		if scrape.BearerTokenSecret != nil {
+			endpoint.BearerTokenSecret = *scrape.BearerTokenSecret
+		}
+	}
+}
+
+func scrapeRelabelConfigs(scrape *appsv1alpha1.ScrapeSpec) []*monitoringv1.RelabelConfig {
+	var relabelConfigs []*monitoringv1.RelabelConfig
+	for _, relabeling := range scrape.Relabelings {
+		relabelConfig := &monitoringv1.RelabelConfig{
+			SourceLabels: relabeling.SourceLabels,
+		}
+		if relabeling.Separator != nil {
+			relabelConfig.Separator = *relabeling.Separator
+		}
+		if relabeling.TargetLabel != nil {
+			relabelConfig.TargetLabel = *relabeling.TargetLabel
+		}
+		if relabeling.Regex != nil {
+			relabelConfig.Regex = *relabeling.Regex
+		}
+		if relabeling.Replacement != nil {
+			relabelConfig.Replacement = *relabeling.Replacement
+		}
+		if relabeling.Action != nil {
+			relabelConfig.Action = *relabeling.Action
+		}
+		relabelConfigs = append(relabelConfigs, relabelConfig)
+	}
+	return relabelConfigs
+}
+
+// scrapeMetricRelabelConfigs returns the metric relabeling dropping the
+// metrics whose name matches any of the dropped metrics expressions
+func scrapeMetricRelabelConfigs(scrape *appsv1alpha1.ScrapeSpec) []*monitoringv1.RelabelConfig {
+	if len(scrape.DropMetrics) == 0 {
+		return nil
+	}
+
+	return []*monitoringv1.RelabelConfig{{
+		SourceLabels: []string{"__name__"},
+		Regex:        strings.Join(scrape.DropMetrics, "|"),
+		Action:       "drop",
+	}}
+}
+
+func scrapeTLSConfig(tlsSpec *appsv1alpha1.ScrapeTLSSpec) *monitoringv1.TLSConfig {
+	if tlsSpec == nil {
+		return nil
+	}
+
+	tlsConfig := &monitoringv1.TLSConfig{
+		InsecureSkipVerify: tlsSpec.InsecureSkipVerify,
+		KeySecret:          tlsSpec.KeySecret,
+	}
+	if tlsSpec.CASecret != nil {
+		tlsConfig.CA = monitoringv1.SecretOrConfigMap{Secret: tlsSpec.CASecret}
+	}
+	if tlsSpec.CertSecret != nil {
+		tlsConfig.Cert = monitoringv1.SecretOrConfigMap{Secret: tlsSpec.CertSecret}
+	}
+	if tlsSpec.ServerName != nil {
+		tlsConfig.ServerName = *tlsSpec.ServerName
+	}
+	return tlsConfig
+}
@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+type SLOOptionsProvider struct {
+	apimanager *appsv1alpha1.APIManager
+}
+
+func NewSLOOptionsProvider(apimanager *appsv1alpha1.APIManager) *SLOOptionsProvider {
+	return &SLOOptionsProvider{apimanager: apimanager}
+}
+
+func (s *SLOOptionsProvider) GetSLOOptions() (*component.SLOOptions, error) {
+	var apicastProductionSpec, backendListenerSpec *appsv1alpha1.ServiceLevelObjectiveSpec
+	if s.apimanager.Spec.Monitoring != nil && s.apimanager.Spec.Monitoring.SLO != nil {
+		apicastProductionSpec = s.apimanager.Spec.Monitoring.SLO.ApicastProduction
+		backendListenerSpec = s.apimanager.Spec.Monitoring.SLO.BackendListener
+	}
+
+	apicastProduction, err := serviceLevelObjective(apicastProductionSpec, component.DefaultApicastProductionSLOLatencyThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("GetSLOOptions reading apicast production objective: %w", err)
+	}
+
+	backendListener, err := serviceLevelObjective(backendListenerSpec, component.DefaultBackendListenerSLOLatencyThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("GetSLOOptions reading backend listener objective: %w", err)
+	}
+
+	return &component.SLOOptions{
+		ApicastProduction: apicastProduction,
+		BackendListener:   backendListener,
+	}, nil
+}
+
+func serviceLevelObjective(spec *appsv1alpha1.ServiceLevelObjectiveSpec, defaultLatencyThreshold string) (*component.ServiceLevelObjective, error) {
+	availabilityTarget := component.DefaultSLOAvailabilityTarget
+	latencyTarget := component.DefaultSLOLatencyTarget
+	latencyThreshold := defaultLatencyThreshold
+
+	if spec != nil {
+		if spec.AvailabilityTarget != nil {
+			availabilityTarget = *spec.AvailabilityTarget
+		}
+		if spec.LatencyTarget != nil {
+			latencyTarget = *spec.LatencyTarget
+		}
+		if spec.LatencyThreshold != nil {
+			latencyThreshold = *spec.LatencyThreshold
+		}
+	}
+
+	return component.NewServiceLevelObjective(availabilityTarget, latencyTarget, latencyThreshold)
+}
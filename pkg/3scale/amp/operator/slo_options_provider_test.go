@@ -0,0 +1,118 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/google/go-cmp/cmp"
+)
+
+func defaultSLOOptions() *component.SLOOptions {
+	return &component.SLOOptions{
+		ApicastProduction: &component.ServiceLevelObjective{
+			AvailabilityTarget: 99.5,
+			LatencyTarget:      99,
+			LatencyThreshold:   "0.5",
+		},
+		BackendListener: &component.ServiceLevelObjective{
+			AvailabilityTarget: 99.5,
+			LatencyTarget:      99,
+			LatencyThreshold:   "0.1",
+		},
+	}
+}
+
+func TestSLOOptionsProvider(t *testing.T) {
+	availabilityTarget := "99.9"
+	latencyTarget := "95"
+	latencyThreshold := "1"
+
+	cases := []struct {
+		testName               string
+		apimanagerFactory      func() *appsv1alpha1.APIManager
+		expectedOptionsFactory func() *component.SLOOptions
+	}{
+		{"Default", basicApimanager, defaultSLOOptions},
+		{"WithApicastProductionObjective",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+					Enabled: true,
+					SLO: &appsv1alpha1.SLOSpec{
+						Enabled: true,
+						ApicastProduction: &appsv1alpha1.ServiceLevelObjectiveSpec{
+							AvailabilityTarget: &availabilityTarget,
+							LatencyTarget:      &latencyTarget,
+							LatencyThreshold:   &latencyThreshold,
+						},
+					},
+				}
+				return apimanager
+			},
+			func() *component.SLOOptions {
+				opts := defaultSLOOptions()
+				opts.ApicastProduction = &component.ServiceLevelObjective{
+					AvailabilityTarget: 99.9,
+					LatencyTarget:      95,
+					LatencyThreshold:   "1",
+				}
+				return opts
+			},
+		},
+		{"WithBackendListenerAvailabilityTarget",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+					Enabled: true,
+					SLO: &appsv1alpha1.SLOSpec{
+						Enabled: true,
+						BackendListener: &appsv1alpha1.ServiceLevelObjectiveSpec{
+							AvailabilityTarget: &availabilityTarget,
+						},
+					},
+				}
+				return apimanager
+			},
+			func() *component.SLOOptions {
+				opts := defaultSLOOptions()
+				opts.BackendListener.AvailabilityTarget = 99.9
+				return opts
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			opts, err := NewSLOOptionsProvider(tc.apimanagerFactory()).GetSLOOptions()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			expectedOptions := tc.expectedOptionsFactory()
+			if !reflect.DeepEqual(expectedOptions, opts) {
+				subT.Errorf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts))
+			}
+		})
+	}
+}
+
+func TestSLOOptionsProviderInvalidTarget(t *testing.T) {
+	invalidTarget := "0"
+
+	apimanager := basicApimanager()
+	apimanager.Spec.Monitoring = &appsv1alpha1.MonitoringSpec{
+		Enabled: true,
+		SLO: &appsv1alpha1.SLOSpec{
+			Enabled: true,
+			ApicastProduction: &appsv1alpha1.ServiceLevelObjectiveSpec{
+				LatencyTarget: &invalidTarget,
+			},
+		},
+	}
+
+	_, err := NewSLOOptionsProvider(apimanager).GetSLOOptions()
+	if err == nil {
+		t.Error("expected error on invalid latency target")
+	}
+}
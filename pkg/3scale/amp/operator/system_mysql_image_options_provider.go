@@ -29,6 +29,7 @@ func (s *SystemMysqlImageOptionsProvider) GetSystemMySQLImageOptions() (*compone
 		s.apimanager.Spec.System.DatabaseSpec.MySQL.Image != nil {
 		s.mysqlImageOptions.Image = *s.apimanager.Spec.System.DatabaseSpec.MySQL.Image
 	}
+	s.mysqlImageOptions.Image = RegistryImageURL(s.apimanager.Spec.ImageRegistry, s.mysqlImageOptions.Image)
 
 	err := s.mysqlImageOptions.Validate()
 	return s.mysqlImageOptions, err
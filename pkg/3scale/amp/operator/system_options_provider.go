@@ -68,6 +68,8 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 	s.options.AppMetrics = true
 	s.options.IncludeOracleOptionalSettings = true
 
+	s.options.AppOpenTelemetry = openTelemetryConfig(s.apimanager.Spec.System.AppSpec.OpenTelemetry, component.OpenTelemetryHTTPProtocol)
+
 	s.options.Namespace = s.namespace
 
 	err = s.options.Validate()
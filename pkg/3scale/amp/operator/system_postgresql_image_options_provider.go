@@ -29,6 +29,7 @@ func (s *SystemPostgreSQLImageOptionsProvider) GetSystemPostgreSQLImageOptions()
 		s.apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image != nil {
 		s.options.Image = *s.apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image
 	}
+	s.options.Image = RegistryImageURL(s.apimanager.Spec.ImageRegistry, s.options.Image)
 
 	err := s.options.Validate()
 	return s.options, err
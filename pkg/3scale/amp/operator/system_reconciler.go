@@ -87,6 +87,7 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentConfigAffinityMutator,
 		reconcilers.DeploymentConfigTolerationsMutator,
 		r.systemAppDCResourceMutator,
+		openTelemetryDCMutator, // Should be always after resource mutator
 	)
 
 	err = r.ReconcileDeploymentConfig(system.AppDeploymentConfig(), systemAppDCMutator)
@@ -177,12 +178,12 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(system.SystemSidekiqPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(system.SystemSidekiqPodMonitor(), system.SystemSidekiqServiceMonitor(), system.SystemSidekiqMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(system.SystemAppPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(system.SystemAppPodMonitor(), system.SystemAppServiceMonitor(), system.SystemAppMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -192,12 +193,12 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(system.SystemAppPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(system.SystemAppPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(system.SystemSidekiqPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(system.SystemSidekiqPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
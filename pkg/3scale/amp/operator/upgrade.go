@@ -6,13 +6,17 @@ import (
 	"reflect"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	appsv1 "github.com/openshift/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -31,29 +35,211 @@ func NewUpgradeApiManager(b *reconcilers.BaseReconciler, apiManager *appsv1alpha
 	}
 }
 
+// plannedUpgradeStep is a step of the upgrade in progress. Its name is
+// qualified with the path it belongs to
+type plannedUpgradeStep struct {
+	name    string
+	upgrade func(u *UpgradeApiManager) (reconcile.Result, error)
+}
+
+// Upgrade migrates the installation from the 3scale release it runs to the
+// release of the operator. The chain of upgrade paths is selected from the
+// registry and applied once the target release is approved, when approval
+// is required, and the pre-flight checks pass
 func (u *UpgradeApiManager) Upgrade() (reconcile.Result, error) {
-	res, err := u.upgradeSystemAMPRelease()
+	fromRelease := u.apiManager.Annotations[appsv1alpha1.ThreescaleVersionAnnotation]
+	toRelease := product.ThreescaleRelease
+
+	chain, err := upgradeChain(upgradePaths, fromRelease, toRelease)
 	if err != nil {
-		return res, fmt.Errorf("Upgrade: remove system AMP_RELEASE error: %w", err)
+		if u.setUpgradingCondition(v1.ConditionFalse, appsv1alpha1.UpgradePathNotFoundReason, err.Error()) {
+			updateErr := u.UpdateResourceStatus(u.apiManager)
+			if updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+		}
+		return reconcile.Result{}, err
 	}
 
-	res, err = u.upgradeImages()
-	if err != nil {
-		return res, fmt.Errorf("Upgrading images: %w", err)
+	if !u.upgradeStarted(fromRelease, toRelease) {
+		approved, err := u.upgradeApproved(fromRelease, toRelease)
+		if err != nil || !approved {
+			return reconcile.Result{}, err
+		}
+
+		err = u.preflightChecks(chain)
+		if err != nil {
+			u.Logger().Info("Upgrade pre-flight checks failed. Retrying later", "reason", err.Error())
+			if u.setUpgradingCondition(v1.ConditionFalse, appsv1alpha1.UpgradePreflightChecksFailedReason, err.Error()) {
+				err = u.UpdateResourceStatus(u.apiManager)
+			}
+			return reconcile.Result{RequeueAfter: upgradePreflightRetryPeriod}, err
+		}
+
+		err = u.startUpgrade(fromRelease, toRelease, chain)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 	}
-	if res.Requeue {
-		return res, nil
+
+	if u.apiManager.IsUpgradeFailed() {
+		u.Logger().Info("Upgrade failed. Waiting for retry", "annotation", appsv1alpha1.UpgradeRetryAnnotation)
+		return reconcile.Result{}, nil
+	}
+
+	for _, step := range u.plannedSteps(fromRelease, toRelease, chain) {
+		res, err := u.applyStep(step)
+		if err != nil || res.Requeue || res.RequeueAfter > 0 || u.apiManager.IsUpgradeFailed() {
+			return res, err
+		}
+	}
+
+	return reconcile.Result{}, u.completeUpgrade()
+}
+
+// plannedSteps returns the steps of the upgrade in order. With the upgrade
+// policy enabled, the installation is backed up before being migrated and
+// the availability of the upgraded components is verified afterwards
+func (u *UpgradeApiManager) plannedSteps(fromRelease, toRelease string, chain []UpgradePath) []plannedUpgradeStep {
+	steps := []plannedUpgradeStep{}
+	addSteps := func(pathName string, pathSteps []UpgradeStep) {
+		for _, step := range pathSteps {
+			steps = append(steps, plannedUpgradeStep{name: fmt.Sprintf("%s/%s", pathName, step.Name), upgrade: step.Upgrade})
+		}
+	}
+
+	if u.apiManager.IsUpgradePolicyEnabled() {
+		addSteps(fromRelease, preUpgradeSteps)
+	}
+	for _, path := range chain {
+		addSteps(path.Name(), path.Steps)
+	}
+	addSteps(toRelease, releaseUpgradeSteps)
+	if u.apiManager.IsUpgradePolicyEnabled() {
+		addSteps(toRelease, postUpgradeSteps)
 	}
 
-	res, err = u.deleteMessageBusConfigurations()
+	return steps
+}
+
+// upgradeStarted returns whether the upgrade between the given releases has
+// already passed the pre-flight checks. A failed upgrade is started again
+// when the retry annotation changes
+func (u *UpgradeApiManager) upgradeStarted(fromRelease, toRelease string) bool {
+	status := u.apiManager.Status.Upgrade
+	if status == nil ||
+		status.FromVersion != fromRelease ||
+		status.ToVersion != toRelease ||
+		status.OperatorVersion != version.Version {
+		return false
+	}
+
+	retryTrigger := u.apiManager.Annotations[appsv1alpha1.UpgradeRetryAnnotation]
+	retryRequested := retryTrigger != "" && retryTrigger != status.ObservedRetryTrigger
+	return !(u.apiManager.IsUpgradeFailed() && retryRequested)
+}
+
+// upgradeApproved returns whether the upgrade can be started. Upgrades of
+// the 3scale release waiting for approval are reported as available
+func (u *UpgradeApiManager) upgradeApproved(fromRelease, toRelease string) (bool, error) {
+	if fromRelease == toRelease || u.apiManager.IsUpgradeApproved(toRelease) {
+		if !u.apiManager.IsUpgradeAwaitingApproval() {
+			return true, nil
+		}
+		u.apiManager.Status.Conditions.RemoveCondition(appsv1alpha1.APIManagerUpgradeAvailableConditionType)
+		u.apiManager.Status.AvailableThreescaleVersion = ""
+		return true, u.UpdateResourceStatus(u.apiManager)
+	}
+
+	changed := u.apiManager.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerUpgradeAvailableConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  appsv1alpha1.UpgradeApprovalRequiredReason,
+		Message: fmt.Sprintf("3scale release '%s' is available. Set spec.upgradeApproval.approvedVersion to '%s' to upgrade", toRelease, toRelease),
+	})
+	if !changed && u.apiManager.Status.AvailableThreescaleVersion == toRelease {
+		return false, nil
+	}
+
+	u.Logger().Info("Upgrade waiting for approval", "release", toRelease)
+	u.apiManager.Status.AvailableThreescaleVersion = toRelease
+	return false, u.UpdateResourceStatus(u.apiManager)
+}
+
+func (u *UpgradeApiManager) startUpgrade(fromRelease, toRelease string, chain []UpgradePath) error {
+	pathNames := []string{}
+	for _, path := range chain {
+		pathNames = append(pathNames, path.Name())
+	}
+
+	now := metav1.Now()
+	u.apiManager.Status.Upgrade = &appsv1alpha1.UpgradeStatus{
+		FromVersion:          fromRelease,
+		ToVersion:            toRelease,
+		OperatorVersion:      version.Version,
+		Paths:                pathNames,
+		StartTime:            &now,
+		ObservedRetryTrigger: u.apiManager.Annotations[appsv1alpha1.UpgradeRetryAnnotation],
+	}
+	u.apiManager.Status.Conditions.RemoveCondition(appsv1alpha1.APIManagerUpgradeFailedConditionType)
+
+	u.Logger().Info("Starting upgrade", "from", fromRelease, "to", toRelease, "paths", pathNames)
+	u.setUpgradingCondition(v1.ConditionTrue, appsv1alpha1.UpgradeInProgressReason,
+		fmt.Sprintf("Upgrading from 3scale release '%s' to '%s'", fromRelease, toRelease))
+	return u.UpdateResourceStatus(u.apiManager)
+}
+
+// applyStep runs the given step unless it has already been completed. The
+// step being applied is reported in the upgrade status
+func (u *UpgradeApiManager) applyStep(step plannedUpgradeStep) (reconcile.Result, error) {
+	status := u.apiManager.Status.Upgrade
+	if helper.ArrayContains(status.CompletedSteps, step.name) {
+		return reconcile.Result{}, nil
+	}
+
+	if status.CurrentStep != step.name {
+		u.Logger().Info("Applying upgrade step", "step", step.name)
+		status.CurrentStep = step.name
+		err := u.UpdateResourceStatus(u.apiManager)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	res, err := step.upgrade(u)
 	if err != nil {
-		return res, fmt.Errorf("Upgrade: delete message bus configurations: %w", err)
+		return res, fmt.Errorf("Upgrade step %s: %w", step.name, err)
 	}
-	if res.Requeue {
+	if res.Requeue || res.RequeueAfter > 0 || u.apiManager.IsUpgradeFailed() {
 		return res, nil
 	}
 
-	return reconcile.Result{}, nil
+	status.CompletedSteps = append(status.CompletedSteps, step.name)
+	status.CurrentStep = ""
+	return reconcile.Result{}, u.UpdateResourceStatus(u.apiManager)
+}
+
+func (u *UpgradeApiManager) completeUpgrade() error {
+	status := u.apiManager.Status.Upgrade
+	if status.CompletionTime == nil {
+		now := metav1.Now()
+		status.CompletionTime = &now
+	}
+
+	u.Logger().Info("Upgrade completed", "from", status.FromVersion, "to", status.ToVersion)
+	u.setUpgradingCondition(v1.ConditionFalse, appsv1alpha1.UpgradeCompletedReason,
+		fmt.Sprintf("Upgraded from 3scale release '%s' to '%s'", status.FromVersion, status.ToVersion))
+	return u.UpdateResourceStatus(u.apiManager)
+}
+
+// setUpgradingCondition returns whether the condition has changed
+func (u *UpgradeApiManager) setUpgradingCondition(status v1.ConditionStatus, reason common.ConditionReason, message string) bool {
+	return u.apiManager.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerUpgradingConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
 }
 
 func (u *UpgradeApiManager) upgradeSystemAMPRelease() (reconcile.Result, error) {
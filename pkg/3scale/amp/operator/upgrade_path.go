@@ -0,0 +1,95 @@
+package operator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// developmentRelease is the 3scale release of the operator development
+// builds. It is considered to be the latest registered release
+const developmentRelease = "master"
+
+// UpgradeStep is a migration applied to the 3scale installation during an
+// upgrade. Steps have to be idempotent: they are run again until they
+// neither fail nor request a requeue
+type UpgradeStep struct {
+	Name    string
+	Upgrade func(u *UpgradeApiManager) (reconcile.Result, error)
+}
+
+// UpgradePath holds the steps migrating a 3scale installation from a 3scale
+// release to the next one
+type UpgradePath struct {
+	From  string
+	To    string
+	Steps []UpgradeStep
+	// Free space, by PersistentVolumeClaim name, the storage of the
+	// installation needs before the path can be applied. The capacity of the
+	// claims has to meet it too
+	StorageRequirements map[string]resource.Quantity
+}
+
+func (p UpgradePath) Name() string {
+	return fmt.Sprintf("%s->%s", p.From, p.To)
+}
+
+// upgradePaths is the registry of upgrade paths, ordered by release. New
+// releases requiring migrations register their path at the end
+var upgradePaths = []UpgradePath{
+	{
+		From: "2.9",
+		To:   "2.10",
+		Steps: []UpgradeStep{
+			{Name: "RemoveSystemAMPRelease", Upgrade: (*UpgradeApiManager).upgradeSystemAMPRelease},
+			{Name: "DeleteMessageBusConfigurations", Upgrade: (*UpgradeApiManager).deleteMessageBusConfigurations},
+		},
+	},
+}
+
+// releaseUpgradeSteps are applied at the end of every upgrade, once the
+// installation has been migrated to the target release. Operator upgrades
+// not changing the 3scale release only apply these steps
+var releaseUpgradeSteps = []UpgradeStep{
+	{Name: "UpgradeImages", Upgrade: (*UpgradeApiManager).upgradeImages},
+}
+
+// upgradeChain returns the upgrade paths, in order, migrating an
+// installation from the given release to the target one. The chain is empty
+// when both releases are the same
+func upgradeChain(paths []UpgradePath, from, to string) ([]UpgradePath, error) {
+	if len(paths) > 0 {
+		latestRelease := paths[len(paths)-1].To
+		if from == developmentRelease {
+			from = latestRelease
+		}
+		if to == developmentRelease {
+			to = latestRelease
+		}
+	}
+
+	chain := []UpgradePath{}
+	for current := from; current != to; {
+		// Each release is upgraded from at most once
+		if len(chain) == len(paths) {
+			return nil, fmt.Errorf("no upgrade path from 3scale release '%s' to '%s'", from, to)
+		}
+
+		next := -1
+		for idx := range paths {
+			if paths[idx].From == current {
+				next = idx
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("no upgrade path from 3scale release '%s' to '%s'", from, to)
+		}
+
+		chain = append(chain, paths[next])
+		current = paths[next].To
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,67 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeChain(t *testing.T) {
+	paths := []UpgradePath{
+		{From: "2.8", To: "2.9"},
+		{From: "2.9", To: "2.10"},
+		{From: "2.10", To: "2.11"},
+	}
+
+	cases := []struct {
+		testName      string
+		from          string
+		to            string
+		expectedPaths []string
+		expectedError bool
+	}{
+		{"same release", "2.10", "2.10", []string{}, false},
+		{"single hop", "2.9", "2.10", []string{"2.9->2.10"}, false},
+		{"multi hop", "2.8", "2.11", []string{"2.8->2.9", "2.9->2.10", "2.10->2.11"}, false},
+		{"to development release", "2.9", developmentRelease, []string{"2.9->2.10", "2.10->2.11"}, false},
+		{"from development release", developmentRelease, developmentRelease, []string{}, false},
+		{"unknown release", "2.7", "2.10", nil, true},
+		{"downgrade", "2.11", "2.10", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			chain, err := upgradeChain(paths, tc.from, tc.to)
+			if tc.expectedError {
+				if err == nil {
+					subT.Errorf("expected error, got chain %v", chain)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			pathNames := []string{}
+			for _, path := range chain {
+				pathNames = append(pathNames, path.Name())
+			}
+			if !reflect.DeepEqual(pathNames, tc.expectedPaths) {
+				subT.Errorf("expected paths %v, got %v", tc.expectedPaths, pathNames)
+			}
+		})
+	}
+}
+
+func TestUpgradePathsRegistry(t *testing.T) {
+	// Every registered path continues the previous one
+	for idx := 1; idx < len(upgradePaths); idx++ {
+		if upgradePaths[idx].From != upgradePaths[idx-1].To {
+			t.Errorf("upgrade path %s does not continue %s", upgradePaths[idx].Name(), upgradePaths[idx-1].Name())
+		}
+	}
+
+	_, err := upgradeChain(upgradePaths, upgradePaths[0].From, developmentRelease)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,296 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// upgradeWaitPeriod is the time after which the pre-upgrade backup and the
+// availability of the upgraded components are checked again
+const upgradeWaitPeriod = 30 * time.Second
+
+// preUpgradeSteps are applied before migrating the installation when the
+// upgrade policy is enabled
+var preUpgradeSteps = []UpgradeStep{
+	{Name: "PreUpgradeBackup", Upgrade: (*UpgradeApiManager).preUpgradeBackup},
+	{Name: "RecordPreviousImages", Upgrade: (*UpgradeApiManager).recordPreviousImages},
+}
+
+// postUpgradeSteps are applied once the installation has been migrated when
+// the upgrade policy is enabled
+var postUpgradeSteps = []UpgradeStep{
+	{Name: "VerifyAvailability", Upgrade: (*UpgradeApiManager).verifyAvailability},
+}
+
+// preUpgradeBackup creates an APIManagerBackup from the template of the
+// upgrade policy and waits for it to complete. The upgrade fails when the
+// backup fails
+func (u *UpgradeApiManager) preUpgradeBackup() (reconcile.Result, error) {
+	status := u.apiManager.Status.Upgrade
+	if status.BackupName == "" {
+		status.BackupName = fmt.Sprintf("%s-pre-upgrade-%s", u.apiManager.Name, status.StartTime.UTC().Format("20060102-1504"))
+		err := u.UpdateResourceStatus(u.apiManager)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	backup := &appsv1alpha1.APIManagerBackup{}
+	err := u.Client().Get(context.TODO(), types.NamespacedName{Name: status.BackupName, Namespace: u.apiManager.Namespace}, backup)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	if errors.IsNotFound(err) {
+		err = u.CreateResource(u.preUpgradeBackupObject(status.BackupName))
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		u.Logger().Info("Pre-upgrade backup created", "backup", status.BackupName)
+		return reconcile.Result{RequeueAfter: upgradeWaitPeriod}, nil
+	}
+
+	if backup.BackupFailed() {
+		return reconcile.Result{}, u.failUpgrade(appsv1alpha1.PreUpgradeBackupFailedReason,
+			fmt.Sprintf("APIManagerBackup '%s' failed. Nothing has been upgraded", backup.Name))
+	}
+
+	if !backup.BackupCompleted() {
+		u.Logger().Info("Waiting for pre-upgrade backup to complete", "backup", backup.Name)
+		return reconcile.Result{RequeueAfter: upgradeWaitPeriod}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// preUpgradeBackupObject is not owned by the APIManager, so the backup is
+// kept when the APIManager is deleted
+func (u *UpgradeApiManager) preUpgradeBackupObject(name string) *appsv1alpha1.APIManagerBackup {
+	return &appsv1alpha1.APIManagerBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "APIManagerBackup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: u.apiManager.Namespace,
+			Labels: map[string]string{
+				appsv1alpha1.PreUpgradeBackupLabel: u.apiManager.Name,
+			},
+		},
+		Spec: *u.apiManager.Spec.UpgradePolicy.BackupTemplate.DeepCopy(),
+	}
+}
+
+// recordPreviousImages stores the ImageStreamTags the DeploymentConfigs are
+// deployed from, and the images they resolve to, before their image change
+// triggers are upgraded. Image change triggers paused by a previous failed
+// upgrade are resumed
+func (u *UpgradeApiManager) recordPreviousImages() (reconcile.Result, error) {
+	dcs, err := u.ownedDeploymentConfigs()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	previousImages := []appsv1alpha1.DeploymentConfigImageStatus{}
+	for idx := range dcs {
+		dc := &dcs[idx]
+		triggerIdx, err := u.findDeploymentTriggerOnImageChange(dc.Spec.Triggers)
+		if err != nil {
+			// Not deployed from an ImageStream
+			continue
+		}
+		imageChangeParams := dc.Spec.Triggers[triggerIdx].ImageChangeParams
+		previousImages = append(previousImages, appsv1alpha1.DeploymentConfigImageStatus{
+			Name:           dc.Name,
+			ImageStreamTag: imageChangeParams.From.Name,
+			Image:          deploymentConfigImage(dc, imageChangeParams),
+		})
+
+		if _, ok := dc.Annotations[appsv1alpha1.PreviousImageRestoredAnnotation]; ok {
+			u.Logger().Info("Resuming image change trigger", "DeploymentConfig", dc.Name)
+			delete(dc.Annotations, appsv1alpha1.PreviousImageRestoredAnnotation)
+			imageChangeParams.Automatic = true
+			err = u.UpdateResource(dc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	u.apiManager.Status.Upgrade.PreviousImages = previousImages
+	return reconcile.Result{}, u.UpdateResourceStatus(u.apiManager)
+}
+
+// deploymentConfigImage returns the image the DeploymentConfig is running.
+// The last image resolved by the trigger is not rolled out when the trigger
+// is paused
+func deploymentConfigImage(dc *appsv1.DeploymentConfig, imageChangeParams *appsv1.DeploymentTriggerImageChangeParams) string {
+	if imageChangeParams.Automatic && imageChangeParams.LastTriggeredImage != "" {
+		return imageChangeParams.LastTriggeredImage
+	}
+
+	for _, container := range append(dc.Spec.Template.Spec.InitContainers, dc.Spec.Template.Spec.Containers...) {
+		if helper.ArrayContains(imageChangeParams.ContainerNames, container.Name) {
+			return container.Image
+		}
+	}
+
+	return ""
+}
+
+// verifyAvailability waits for the DeploymentConfigs to be rolled out. When
+// the availability timeout is exceeded the previous images are restored and
+// the upgrade fails
+func (u *UpgradeApiManager) verifyAvailability() (reconcile.Result, error) {
+	status := u.apiManager.Status.Upgrade
+	if status.AvailabilityDeadline == nil {
+		deadline := metav1.NewTime(time.Now().Add(u.apiManager.UpgradeAvailabilityTimeout()))
+		status.AvailabilityDeadline = &deadline
+		err := u.UpdateResourceStatus(u.apiManager)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	dcs, err := u.ownedDeploymentConfigs()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	pending := []string{}
+	for idx := range dcs {
+		if !helper.IsDeploymentConfigRolledOut(&dcs[idx]) {
+			pending = append(pending, dcs[idx].Name)
+		}
+	}
+
+	if len(pending) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	if time.Now().Before(status.AvailabilityDeadline.Time) {
+		u.Logger().Info("Waiting for upgraded components to be available", "pending", pending)
+		return reconcile.Result{RequeueAfter: upgradeWaitPeriod}, nil
+	}
+
+	err = u.restorePreviousImages()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, u.failUpgrade(appsv1alpha1.ComponentsUnavailableReason,
+		fmt.Sprintf("DeploymentConfigs %v not available within %s. Previous images restored", pending, u.apiManager.UpgradeAvailabilityTimeout()))
+}
+
+// restorePreviousImages sets the recorded images in the containers of the
+// DeploymentConfigs and pauses their image change triggers, so the upgraded
+// images are not rolled out again from the same ImageStreamTag
+func (u *UpgradeApiManager) restorePreviousImages() error {
+	for _, previousImage := range u.apiManager.Status.Upgrade.PreviousImages {
+		dc := &appsv1.DeploymentConfig{}
+		err := u.Client().Get(context.TODO(), types.NamespacedName{Name: previousImage.Name, Namespace: u.apiManager.Namespace}, dc)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		triggerIdx, err := u.findDeploymentTriggerOnImageChange(dc.Spec.Triggers)
+		if err != nil {
+			return fmt.Errorf("unexpected: '%s' in DeploymentConfig '%s'", err, dc.Name)
+		}
+
+		imageChangeParams := dc.Spec.Triggers[triggerIdx].ImageChangeParams
+		update := false
+		if imageChangeParams.From.Name != previousImage.ImageStreamTag {
+			imageChangeParams.From.Name = previousImage.ImageStreamTag
+			update = true
+		}
+
+		if previousImage.Image != "" {
+			if imageChangeParams.Automatic {
+				imageChangeParams.Automatic = false
+				update = true
+			}
+			for _, containers := range [][]v1.Container{dc.Spec.Template.Spec.InitContainers, dc.Spec.Template.Spec.Containers} {
+				for idx := range containers {
+					if helper.ArrayContains(imageChangeParams.ContainerNames, containers[idx].Name) && containers[idx].Image != previousImage.Image {
+						containers[idx].Image = previousImage.Image
+						update = true
+					}
+				}
+			}
+			if _, ok := dc.Annotations[appsv1alpha1.PreviousImageRestoredAnnotation]; !ok {
+				if dc.Annotations == nil {
+					dc.Annotations = map[string]string{}
+				}
+				dc.Annotations[appsv1alpha1.PreviousImageRestoredAnnotation] = "true"
+				update = true
+			}
+		}
+
+		if update {
+			u.Logger().Info("Restoring previous image", "DeploymentConfig", dc.Name, "ImageStreamTag", previousImage.ImageStreamTag, "Image", previousImage.Image)
+			err = u.UpdateResource(dc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// failUpgrade stops the upgrade until a retry is requested
+func (u *UpgradeApiManager) failUpgrade(reason common.ConditionReason, message string) error {
+	u.Logger().Info("Upgrade failed", "reason", reason, "message", message)
+	u.EventRecorder().Eventf(u.apiManager, v1.EventTypeWarning, "UpgradeFailed", "%s", message)
+
+	u.apiManager.Status.Conditions.SetCondition(common.Condition{
+		Type:    appsv1alpha1.APIManagerUpgradeFailedConditionType,
+		Status:  v1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	u.setUpgradingCondition(v1.ConditionFalse, appsv1alpha1.UpgradeFailedReason, message)
+	return u.UpdateResourceStatus(u.apiManager)
+}
+
+// ownedDeploymentConfigs returns the DeploymentConfigs deployed by the
+// APIManager sorted by name
+func (u *UpgradeApiManager) ownedDeploymentConfigs() ([]appsv1.DeploymentConfig, error) {
+	dcList := &appsv1.DeploymentConfigList{}
+	err := u.Client().List(context.TODO(), dcList, client.InNamespace(u.apiManager.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	dcs := []appsv1.DeploymentConfig{}
+	for idx := range dcList.Items {
+		for _, ownerRef := range dcList.Items[idx].GetOwnerReferences() {
+			if ownerRef.UID == u.apiManager.UID {
+				dcs = append(dcs, dcList.Items[idx])
+				break
+			}
+		}
+	}
+	sort.Slice(dcs, func(i, j int) bool { return dcs[i].Name < dcs[j].Name })
+
+	return dcs, nil
+}
@@ -0,0 +1,294 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func upgradePolicyTestApimanager() *appsv1alpha1.APIManager {
+	apimanager := basicApimanager()
+	apimanager.UID = "apimanager-uid"
+	apimanager.Spec.UpgradePolicy = &appsv1alpha1.UpgradePolicySpec{
+		BackupTemplate: appsv1alpha1.APIManagerBackupSpec{
+			BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+				S3: &appsv1alpha1.S3BackupDestination{
+					S3Location: appsv1alpha1.S3Location{
+						Bucket:               "backups",
+						CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+					},
+				},
+			},
+		},
+	}
+	return apimanager
+}
+
+func upgradePolicyTestDeploymentConfig(apimanager *appsv1alpha1.APIManager, name, imageStreamTag, image string) *appsv1.DeploymentConfig {
+	return &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: appsv1alpha1.GroupVersion.String(), Kind: "APIManager", Name: apimanager.Name, UID: apimanager.UID},
+			},
+		},
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: 1,
+			Template: &v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: name, Image: image}},
+				},
+			},
+			Triggers: []appsv1.DeploymentTriggerPolicy{
+				{Type: appsv1.DeploymentTriggerOnConfigChange},
+				{
+					Type: appsv1.DeploymentTriggerOnImageChange,
+					ImageChangeParams: &appsv1.DeploymentTriggerImageChangeParams{
+						Automatic:          true,
+						ContainerNames:     []string{name},
+						From:               v1.ObjectReference{Kind: "ImageStreamTag", Name: imageStreamTag},
+						LastTriggeredImage: image,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUpgradePolicyPreUpgradeBackup(t *testing.T) {
+	apimanager := upgradePolicyTestApimanager()
+	upgrade, cl := newUpgradeTestApiManager(t, apimanager, nil)
+	err := upgrade.startUpgrade("2.9", "2.10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := upgrade.preUpgradeBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter != upgradeWaitPeriod {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	backupName := apimanager.Status.Upgrade.BackupName
+	backup := &appsv1alpha1.APIManagerBackup{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: backupName, Namespace: namespace}, backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.Labels[appsv1alpha1.PreUpgradeBackupLabel] != apimanager.Name || backup.Spec.BackupDestination.S3 == nil {
+		t.Errorf("unexpected pre-upgrade backup: %v", backup)
+	}
+
+	// Waits for the backup to complete
+	res, err = upgrade.preUpgradeBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter != upgradeWaitPeriod {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	completed := true
+	backup.Status.Completed = &completed
+	err = cl.Status().Update(context.TODO(), backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = upgrade.preUpgradeBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Requeue || res.RequeueAfter > 0 || apimanager.IsUpgradeFailed() {
+		t.Errorf("unexpected result %v", res)
+	}
+}
+
+func TestUpgradePolicyPreUpgradeBackupFailed(t *testing.T) {
+	apimanager := upgradePolicyTestApimanager()
+	apimanager.Status.Upgrade = &appsv1alpha1.UpgradeStatus{BackupName: "backup1"}
+	failed := true
+	backup := &appsv1alpha1.APIManagerBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup1", Namespace: namespace},
+		Status:     appsv1alpha1.APIManagerBackupStatus{Failed: &failed},
+	}
+
+	upgrade, _ := newUpgradeTestApiManager(t, apimanager, []runtime.Object{backup})
+	_, err := upgrade.preUpgradeBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := apimanager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerUpgradeFailedConditionType)
+	if condition == nil || !condition.IsTrue() || condition.Reason != appsv1alpha1.PreUpgradeBackupFailedReason {
+		t.Errorf("unexpected upgrade failed condition: %v", condition)
+	}
+}
+
+func TestUpgradePolicyRollback(t *testing.T) {
+	apimanager := upgradePolicyTestApimanager()
+	objs := []runtime.Object{
+		upgradePolicyTestDeploymentConfig(apimanager, "system-app", "amp-system:2.9", "registry.example.com/system@sha256:29"),
+		upgradePolicyTestDeploymentConfig(apimanager, "backend-listener", "amp-backend:2.9", "registry.example.com/backend@sha256:29"),
+	}
+	upgrade, cl := newUpgradeTestApiManager(t, apimanager, objs)
+	err := upgrade.startUpgrade("2.9", "2.10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = upgrade.recordPreviousImages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedImages := []appsv1alpha1.DeploymentConfigImageStatus{
+		{Name: "backend-listener", ImageStreamTag: "amp-backend:2.9", Image: "registry.example.com/backend@sha256:29"},
+		{Name: "system-app", ImageStreamTag: "amp-system:2.9", Image: "registry.example.com/system@sha256:29"},
+	}
+	previousImages := apimanager.Status.Upgrade.PreviousImages
+	if len(previousImages) != 2 || previousImages[0] != expectedImages[0] || previousImages[1] != expectedImages[1] {
+		t.Fatalf("unexpected previous images: %v", previousImages)
+	}
+
+	// Upgraded images
+	for _, dcName := range []string{"system-app", "backend-listener"} {
+		dc := &appsv1.DeploymentConfig{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: dcName, Namespace: namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dc.Spec.Triggers[1].ImageChangeParams.From.Name = dcName + ":2.10"
+		dc.Spec.Triggers[1].ImageChangeParams.LastTriggeredImage = "registry.example.com/" + dcName + "@sha256:210"
+		dc.Spec.Template.Spec.Containers[0].Image = dc.Spec.Triggers[1].ImageChangeParams.LastTriggeredImage
+		err = cl.Update(context.TODO(), dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Components not available before the deadline
+	res, err := upgrade.verifyAvailability()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter != upgradeWaitPeriod || apimanager.IsUpgradeFailed() {
+		t.Fatalf("unexpected result %v", res)
+	}
+
+	// Components not available after the deadline
+	deadline := metav1.NewTime(time.Now().Add(-time.Minute))
+	apimanager.Status.Upgrade.AvailabilityDeadline = &deadline
+	_, err = upgrade.verifyAvailability()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := apimanager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerUpgradeFailedConditionType)
+	if condition == nil || !condition.IsTrue() || condition.Reason != appsv1alpha1.ComponentsUnavailableReason {
+		t.Errorf("unexpected upgrade failed condition: %v", condition)
+	}
+
+	for _, expectedImage := range expectedImages {
+		dc := &appsv1.DeploymentConfig{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: expectedImage.Name, Namespace: namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dc.Spec.Triggers[1].ImageChangeParams.From.Name != expectedImage.ImageStreamTag {
+			t.Errorf("DeploymentConfig %s image not restored: %s", dc.Name, dc.Spec.Triggers[1].ImageChangeParams.From.Name)
+		}
+		if dc.Spec.Template.Spec.Containers[0].Image != expectedImage.Image || dc.Spec.Triggers[1].ImageChangeParams.Automatic {
+			t.Errorf("DeploymentConfig %s image not restored: %s", dc.Name, dc.Spec.Template.Spec.Containers[0].Image)
+		}
+	}
+
+	// Failed upgrade is only started again when the retry annotation changes
+	if !upgrade.upgradeStarted("2.9", "2.10") {
+		t.Error("failed upgrade started again")
+	}
+	apimanager.Annotations[appsv1alpha1.UpgradeRetryAnnotation] = "1"
+	if upgrade.upgradeStarted("2.9", "2.10") {
+		t.Error("failed upgrade not retried")
+	}
+}
+
+func TestUpgradePolicyRollbackSameImageStreamTag(t *testing.T) {
+	apimanager := upgradePolicyTestApimanager()
+	previousImage := "registry.example.com/system@sha256:old"
+	upgradedImage := "registry.example.com/system@sha256:new"
+	objs := []runtime.Object{
+		upgradePolicyTestDeploymentConfig(apimanager, "system-app", "amp-system:2.10", previousImage),
+	}
+	upgrade, cl := newUpgradeTestApiManager(t, apimanager, objs)
+	err := upgrade.startUpgrade("2.10", "2.10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = upgrade.recordPreviousImages()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getSystemApp := func() *appsv1.DeploymentConfig {
+		dc := &appsv1.DeploymentConfig{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: "system-app", Namespace: namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dc
+	}
+
+	// The tag is not renamed, but a new image is imported into it
+	dc := getSystemApp()
+	dc.Spec.Triggers[1].ImageChangeParams.LastTriggeredImage = upgradedImage
+	dc.Spec.Template.Spec.Containers[0].Image = upgradedImage
+	err = cl.Update(context.TODO(), dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := metav1.NewTime(time.Now().Add(-time.Minute))
+	apimanager.Status.Upgrade.AvailabilityDeadline = &deadline
+	_, err = upgrade.verifyAvailability()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dc = getSystemApp()
+	if dc.Spec.Template.Spec.Containers[0].Image != previousImage {
+		t.Errorf("previous image not restored: %s", dc.Spec.Template.Spec.Containers[0].Image)
+	}
+	if dc.Spec.Triggers[1].ImageChangeParams.Automatic {
+		t.Error("image change trigger would roll out the upgraded image again")
+	}
+	if _, ok := dc.Annotations[appsv1alpha1.PreviousImageRestoredAnnotation]; !ok {
+		t.Error("restored DeploymentConfig not annotated")
+	}
+
+	// Retried upgrades record the restored image and resume the trigger
+	_, err = upgrade.recordPreviousImages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previousImages := apimanager.Status.Upgrade.PreviousImages; len(previousImages) != 1 || previousImages[0].Image != previousImage {
+		t.Errorf("unexpected previous images: %v", previousImages)
+	}
+	dc = getSystemApp()
+	if !dc.Spec.Triggers[1].ImageChangeParams.Automatic {
+		t.Error("image change trigger not resumed")
+	}
+	if _, ok := dc.Annotations[appsv1alpha1.PreviousImageRestoredAnnotation]; ok {
+		t.Error("restored annotation not removed")
+	}
+}
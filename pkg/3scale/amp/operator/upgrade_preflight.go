@@ -0,0 +1,376 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// upgradePreflightRetryPeriod is the time after which failed upgrade
+// pre-flight checks are run again
+const upgradePreflightRetryPeriod = time.Minute
+
+const (
+	upgradeFreeSpaceContainerName = "measure-free-space"
+	upgradeFreeSpaceMountPath     = "/storage"
+)
+
+// preflightChecks verifies the installation can be upgraded through the
+// given chain. It returns the reason of the first failed check
+func (u *UpgradeApiManager) preflightChecks(chain []UpgradePath) error {
+	err := u.checkDatabasesAvailable()
+	if err != nil {
+		return err
+	}
+
+	err = u.checkNoBackupInProgress()
+	if err != nil {
+		return err
+	}
+
+	return u.checkStorage(chain)
+}
+
+// checkDatabasesAvailable verifies the internal databases are running.
+// External databases are not checked
+func (u *UpgradeApiManager) checkDatabasesAvailable() error {
+	if u.apiManager.IsExternalDatabaseEnabled() {
+		return nil
+	}
+
+	redis, err := Redis(u.apiManager, u.Client())
+	if err != nil {
+		return err
+	}
+
+	databaseDCs := []*appsv1.DeploymentConfig{
+		redis.BackendDeploymentConfig(),
+		redis.SystemDeploymentConfig(),
+	}
+
+	if u.apiManager.IsSystemPostgreSQLEnabled() {
+		systemPostgreSQL, err := SystemPostgreSQL(u.apiManager, u.Client())
+		if err != nil {
+			return err
+		}
+		databaseDCs = append(databaseDCs, systemPostgreSQL.DeploymentConfig())
+	} else {
+		systemMySQL, err := SystemMySQL(u.apiManager, u.Client())
+		if err != nil {
+			return err
+		}
+		databaseDCs = append(databaseDCs, systemMySQL.DeploymentConfig())
+	}
+
+	for _, desired := range databaseDCs {
+		existing := &appsv1.DeploymentConfig{}
+		err := u.Client().Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: u.apiManager.Namespace}, existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if errors.IsNotFound(err) || !helper.IsDeploymentConfigAvailable(existing) {
+			return fmt.Errorf("database DeploymentConfig '%s' is not available", desired.Name)
+		}
+	}
+
+	return nil
+}
+
+// checkNoBackupInProgress verifies no APIManagerBackup of the namespace is
+// being performed. Backups taken while the installation is migrated would
+// not be consistent
+func (u *UpgradeApiManager) checkNoBackupInProgress() error {
+	backupList := &appsv1alpha1.APIManagerBackupList{}
+	err := u.Client().List(context.TODO(), backupList, client.InNamespace(u.apiManager.Namespace))
+	if err != nil {
+		return err
+	}
+
+	for idx := range backupList.Items {
+		backup := &backupList.Items[idx]
+		if !backup.BackupCompleted() && !backup.BackupFailed() {
+			return fmt.Errorf("APIManagerBackup '%s' is in progress", backup.Name)
+		}
+	}
+
+	return nil
+}
+
+// checkStorage verifies the PersistentVolumeClaims of the installation are
+// bound and meet the storage requirements of the upgrade paths. The capacity
+// of the claims is compared to the requirement of each path, and their free
+// space, measured by a Job mounting them, to the requirements of the whole
+// chain
+func (u *UpgradeApiManager) checkStorage(chain []UpgradePath) error {
+	pvcNames, err := u.persistentVolumeClaimNames()
+	if err != nil {
+		return err
+	}
+
+	for _, pvcName := range pvcNames {
+		pvc := &v1.PersistentVolumeClaim{}
+		err := u.Client().Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: u.apiManager.Namespace}, pvc)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if errors.IsNotFound(err) || pvc.Status.Phase != v1.ClaimBound {
+			return fmt.Errorf("PersistentVolumeClaim '%s' is not bound", pvcName)
+		}
+
+		capacity := pvc.Status.Capacity[v1.ResourceStorage]
+		for _, path := range chain {
+			required, ok := path.StorageRequirements[pvcName]
+			if ok && capacity.Cmp(required) < 0 {
+				return fmt.Errorf("PersistentVolumeClaim '%s' capacity %s is lower than the %s required by the upgrade path %s",
+					pvcName, capacity.String(), required.String(), path.Name())
+			}
+		}
+
+		required, ok := chainStorageRequirement(chain, pvcName)
+		if !ok {
+			continue
+		}
+		err = u.checkFreeSpace(pvc, required)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chainStorageRequirement returns the free space the upgrade paths of the
+// chain need, all together, in the given PersistentVolumeClaim
+func chainStorageRequirement(chain []UpgradePath, pvcName string) (resource.Quantity, bool) {
+	total := resource.Quantity{}
+	found := false
+	for _, path := range chain {
+		required, ok := path.StorageRequirements[pvcName]
+		if ok {
+			total.Add(required)
+			found = true
+		}
+	}
+	return total, found
+}
+
+// checkFreeSpace verifies the free space of the given PersistentVolumeClaim
+// is not lower than the required one. The free space is measured by a Job
+// running the backup agent with the claim mounted. The check fails while
+// the Job has not finished. The Job is deleted once finished, so the free
+// space is measured again each time the pre-flight checks are run
+func (u *UpgradeApiManager) checkFreeSpace(pvc *v1.PersistentVolumeClaim, required resource.Quantity) error {
+	desired, err := u.freeSpaceJob(pvc)
+	if err != nil {
+		return err
+	}
+
+	job := &batchv1.Job{}
+	err = u.Client().Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if errors.IsNotFound(err) {
+		err = u.CreateResource(desired)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("PersistentVolumeClaim '%s' free space is being measured", pvc.Name)
+	}
+
+	// The Job of the previous measure is deleted along with its pods
+	if job.DeletionTimestamp != nil || (job.Status.Succeeded == 0 && !freeSpaceJobFailed(job)) {
+		return fmt.Errorf("PersistentVolumeClaim '%s' free space is being measured", pvc.Name)
+	}
+
+	message := ""
+	if job.Status.Succeeded > 0 {
+		message, err = helper.JobContainerTerminationMessage(u.Client(), job, upgradeFreeSpaceContainerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = u.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	availableBytes, err := strconv.ParseInt(message, 10, 64)
+	if err != nil {
+		return fmt.Errorf("PersistentVolumeClaim '%s' free space could not be measured by Job '%s'", pvc.Name, job.Name)
+	}
+
+	available := resource.NewQuantity(availableBytes, resource.BinarySI)
+	if available.Cmp(required) < 0 {
+		return fmt.Errorf("PersistentVolumeClaim '%s' free space %s is lower than the %s required by the upgrade",
+			pvc.Name, available.String(), required.String())
+	}
+
+	return nil
+}
+
+// freeSpaceJob returns the Job measuring the free space of the given
+// PersistentVolumeClaim. The claim is mounted read only. The Job runs in the
+// node of the running pods mounting the claim, as ReadWriteOnce volumes can
+// only be mounted by the pods of a single node
+func (u *UpgradeApiManager) freeSpaceJob(pvc *v1.PersistentVolumeClaim) (*batchv1.Job, error) {
+	nodeName, err := u.persistentVolumeClaimNodeName(pvc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var affinity *v1.Affinity
+	if nodeName != "" {
+		affinity = &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchFields: []v1.NodeSelectorRequirement{
+								{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{nodeName}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	var backoffLimit int32 = 1
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("upgrade-free-space-%s", pvc.Name),
+			Namespace: u.apiManager.Namespace,
+			Labels:    map[string]string{"app": *u.apiManager.Spec.AppLabel},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Affinity:      affinity,
+					Containers: []v1.Container{
+						{
+							Name:    upgradeFreeSpaceContainerName,
+							Image:   RegistryImageURL(u.apiManager.Spec.ImageRegistry, BackupAgentImageURL()),
+							Command: []string{"/manager", "backup-agent"},
+							Args:    []string{"measure-free-space", "--path", upgradeFreeSpaceMountPath},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "storage", MountPath: upgradeFreeSpaceMountPath, ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "storage",
+							VolumeSource: v1.VolumeSource{
+								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = controllerutil.SetControllerReference(u.apiManager, job, u.Scheme())
+	return job, err
+}
+
+// freeSpaceJobFailed returns whether the Job measuring the free space has
+// exceeded its backoff limit
+func freeSpaceJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// persistentVolumeClaimNodeName returns the node of the running pods
+// mounting the given PersistentVolumeClaim. It is empty when no running pod
+// mounts it
+func (u *UpgradeApiManager) persistentVolumeClaimNodeName(pvcName string) (string, error) {
+	podList := &v1.PodList{}
+	err := u.Client().List(context.TODO(), podList, client.InNamespace(u.apiManager.Namespace))
+	if err != nil {
+		return "", err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != v1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				return pod.Spec.NodeName, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// persistentVolumeClaimNames returns the PersistentVolumeClaims deployed by
+// the APIManager
+func (u *UpgradeApiManager) persistentVolumeClaimNames() ([]string, error) {
+	pvcNames := []string{}
+
+	fileStorageSpec := u.apiManager.Spec.System.FileStorageSpec
+	if fileStorageSpec == nil || fileStorageSpec.S3 == nil {
+		system, err := System(u.apiManager, u.Client())
+		if err != nil {
+			return nil, err
+		}
+		pvcNames = append(pvcNames, system.SharedStorage().Name)
+	}
+
+	if u.apiManager.IsExternalDatabaseEnabled() {
+		return pvcNames, nil
+	}
+
+	redis, err := Redis(u.apiManager, u.Client())
+	if err != nil {
+		return nil, err
+	}
+	pvcNames = append(pvcNames, redis.BackendPVC().Name, redis.SystemPVC().Name)
+
+	if u.apiManager.IsSystemPostgreSQLEnabled() {
+		systemPostgreSQL, err := SystemPostgreSQL(u.apiManager, u.Client())
+		if err != nil {
+			return nil, err
+		}
+		pvcNames = append(pvcNames, systemPostgreSQL.DataPersistentVolumeClaim().Name)
+	} else {
+		systemMySQL, err := SystemMySQL(u.apiManager, u.Client())
+		if err != nil {
+			return nil, err
+		}
+		pvcNames = append(pvcNames, systemMySQL.PersistentVolumeClaim().Name)
+	}
+
+	return pvcNames, nil
+}
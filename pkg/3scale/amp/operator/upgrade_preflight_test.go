@@ -0,0 +1,263 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newUpgradeTestApiManager(t *testing.T, apimanager *appsv1alpha1.APIManager, objs []runtime.Object) (*UpgradeApiManager, client.Client) {
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager, &appsv1alpha1.APIManagerBackup{}, &appsv1alpha1.APIManagerBackupList{})
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs = append(objs, apimanager)
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	log := logf.Log.WithName("operator_test")
+
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	return NewUpgradeApiManager(baseReconciler, apimanager), cl
+}
+
+func boundTestPVC(name, capacity string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:    v1.ClaimBound,
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+}
+
+func upgradePreflightTestObjects(t *testing.T, apimanager *appsv1alpha1.APIManager) []runtime.Object {
+	cl := fake.NewFakeClient()
+	redis, err := Redis(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	systemMySQL, err := SystemMySQL(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []runtime.Object{
+		rolledOutDeploymentConfig(redis.BackendDeploymentConfig()),
+		rolledOutDeploymentConfig(redis.SystemDeploymentConfig()),
+		rolledOutDeploymentConfig(systemMySQL.DeploymentConfig()),
+		boundTestPVC("system-storage", "100Mi"),
+		boundTestPVC("backend-redis-storage", "1Gi"),
+		boundTestPVC("system-redis-storage", "1Gi"),
+		boundTestPVC("mysql-storage", "1Gi"),
+	}
+}
+
+func TestUpgradePreflightChecks(t *testing.T) {
+	apimanager := basicApimanager()
+	chain := []UpgradePath{{From: "2.9", To: "2.10"}}
+
+	upgrade, _ := newUpgradeTestApiManager(t, apimanager, upgradePreflightTestObjects(t, apimanager))
+	err := upgrade.preflightChecks(chain)
+	if err != nil {
+		t.Fatalf("unexpected pre-flight checks failure: %s", err)
+	}
+
+	cases := []struct {
+		testName        string
+		mutateObjects   func([]runtime.Object) []runtime.Object
+		chain           []UpgradePath
+		expectedFailure string
+	}{
+		{"database not available", func(objs []runtime.Object) []runtime.Object {
+			for _, obj := range objs {
+				if dc, ok := obj.(*appsv1.DeploymentConfig); ok && dc.Name == "system-mysql" {
+					dc.Status.Conditions = nil
+				}
+			}
+			return objs
+		}, chain, "database DeploymentConfig 'system-mysql' is not available"},
+		{"backup in progress", func(objs []runtime.Object) []runtime.Object {
+			return append(objs, &appsv1alpha1.APIManagerBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup1", Namespace: namespace},
+			})
+		}, chain, "APIManagerBackup 'backup1' is in progress"},
+		{"PVC not bound", func(objs []runtime.Object) []runtime.Object {
+			for _, obj := range objs {
+				if pvc, ok := obj.(*v1.PersistentVolumeClaim); ok && pvc.Name == "mysql-storage" {
+					pvc.Status.Phase = v1.ClaimPending
+				}
+			}
+			return objs
+		}, chain, "PersistentVolumeClaim 'mysql-storage' is not bound"},
+		{"not enough storage", func(objs []runtime.Object) []runtime.Object {
+			return objs
+		}, []UpgradePath{{From: "2.9", To: "2.10", StorageRequirements: map[string]resource.Quantity{
+			"mysql-storage": resource.MustParse("2Gi"),
+		}}}, "PersistentVolumeClaim 'mysql-storage' capacity 1Gi is lower than the 2Gi required by the upgrade path 2.9->2.10"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := basicApimanager()
+			objs := tc.mutateObjects(upgradePreflightTestObjects(subT, apimanager))
+			upgrade, _ := newUpgradeTestApiManager(subT, apimanager, objs)
+			err := upgrade.preflightChecks(tc.chain)
+			if err == nil || !strings.Contains(err.Error(), tc.expectedFailure) {
+				subT.Errorf("expected failure '%s', got %v", tc.expectedFailure, err)
+			}
+		})
+	}
+}
+
+func TestUpgradePreflightFreeSpace(t *testing.T) {
+	chain := []UpgradePath{
+		{From: "2.9", To: "2.10", StorageRequirements: map[string]resource.Quantity{"mysql-storage": resource.MustParse("300Mi")}},
+		{From: "2.10", To: "2.11", StorageRequirements: map[string]resource.Quantity{"mysql-storage": resource.MustParse("200Mi")}},
+	}
+
+	apimanager := basicApimanager()
+	objs := append(upgradePreflightTestObjects(t, apimanager), &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-mysql-1-x2f8k", Namespace: namespace},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+			Volumes: []v1.Volume{{Name: "mysql-storage", VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "mysql-storage"},
+			}}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	})
+	upgrade, cl := newUpgradeTestApiManager(t, apimanager, objs)
+	jobKey := types.NamespacedName{Name: "upgrade-free-space-mysql-storage", Namespace: namespace}
+
+	// measureFreeSpace runs the pre-flight checks until the Job measuring the
+	// free space reports the given available bytes
+	measureFreeSpace := func(availableBytes string) error {
+		err := upgrade.preflightChecks(chain)
+		if err == nil || err.Error() != "PersistentVolumeClaim 'mysql-storage' free space is being measured" {
+			t.Fatalf("expected free space being measured, got %v", err)
+		}
+
+		job := &batchv1.Job{}
+		err = cl.Get(context.TODO(), jobKey, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		job.Status.Succeeded = 1
+		err = cl.Status().Update(context.TODO(), job)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", job.Name, availableBytes), Namespace: namespace, Labels: map[string]string{"job-name": job.Name}},
+			Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+				Name:  "measure-free-space",
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: availableBytes}},
+			}}},
+		}
+		err = cl.Create(context.TODO(), pod)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The pods are garbage collected along with the Job
+		defer cl.Delete(context.TODO(), pod)
+
+		return upgrade.preflightChecks(chain)
+	}
+
+	err := upgrade.preflightChecks(chain)
+	if err == nil {
+		t.Fatal("expected free space being measured")
+	}
+
+	job := &batchv1.Job{}
+	err = cl.Get(context.TODO(), jobKey, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.Containers) != 1 || !reflect.DeepEqual(podSpec.Containers[0].Args, []string{"measure-free-space", "--path", "/storage"}) {
+		t.Errorf("unexpected containers: %v", podSpec.Containers)
+	}
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].PersistentVolumeClaim == nil ||
+		podSpec.Volumes[0].PersistentVolumeClaim.ClaimName != "mysql-storage" || !podSpec.Volumes[0].PersistentVolumeClaim.ReadOnly {
+		t.Errorf("unexpected volumes: %v", podSpec.Volumes)
+	}
+	// The ReadWriteOnce volume is already mounted by the system-mysql pod
+	expectedNodeSelector := &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{
+		MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-1"}}},
+	}}}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil ||
+		!reflect.DeepEqual(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, expectedNodeSelector) {
+		t.Errorf("unexpected affinity: %v", podSpec.Affinity)
+	}
+	err = cl.Delete(context.TODO(), job)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 100Mi available, 500Mi required by the whole chain
+	err = measureFreeSpace("104857600")
+	expectedFailure := "PersistentVolumeClaim 'mysql-storage' free space 100Mi is lower than the 500Mi required by the upgrade"
+	if err == nil || err.Error() != expectedFailure {
+		t.Errorf("expected failure '%s', got %v", expectedFailure, err)
+	}
+	err = cl.Get(context.TODO(), jobKey, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected the Job to be deleted once finished, got %v", err)
+	}
+
+	// Measured again, 1Gi available
+	err = measureFreeSpace("1073741824")
+	if err != nil {
+		t.Errorf("unexpected pre-flight checks failure: %s", err)
+	}
+}
+
+func TestUpgradePreflightChecksFailed(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations[appsv1alpha1.ThreescaleVersionAnnotation] = developmentRelease
+
+	// Databases are not deployed
+	upgrade, _ := newUpgradeTestApiManager(t, apimanager, nil)
+	res, err := upgrade.Upgrade()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter != upgradePreflightRetryPeriod {
+		t.Errorf("unexpected result: %v", res)
+	}
+
+	condition := apimanager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerUpgradingConditionType)
+	if condition == nil || !condition.IsFalse() || condition.Reason != appsv1alpha1.UpgradePreflightChecksFailedReason {
+		t.Fatalf("unexpected upgrading condition: %v", condition)
+	}
+	if apimanager.Status.Upgrade != nil {
+		t.Errorf("upgrade started: %v", apimanager.Status.Upgrade)
+	}
+}
@@ -0,0 +1,104 @@
+package operator
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUpgradeApplyStep(t *testing.T) {
+	apimanager := basicApimanager()
+	upgrade, _ := newUpgradeTestApiManager(t, apimanager, nil)
+
+	err := upgrade.startUpgrade("2.9", "2.10", []UpgradePath{{From: "2.9", To: "2.10"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !upgrade.upgradeStarted("2.9", "2.10") {
+		t.Fatal("upgrade not started")
+	}
+
+	runs := 0
+	step := plannedUpgradeStep{name: "2.9->2.10/TestStep", upgrade: func(u *UpgradeApiManager) (reconcile.Result, error) {
+		runs++
+		return reconcile.Result{Requeue: runs == 1}, nil
+	}}
+
+	// Requeued step is reported as the current one
+	res, err := upgrade.applyStep(step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Requeue || apimanager.Status.Upgrade.CurrentStep != "2.9->2.10/TestStep" {
+		t.Fatalf("unexpected result %v, status %v", res, apimanager.Status.Upgrade)
+	}
+
+	// Completed step is not applied again
+	for i := 0; i < 2; i++ {
+		res, err = upgrade.applyStep(step)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if runs != 2 || res.Requeue {
+		t.Errorf("unexpected step runs %d, result %v", runs, res)
+	}
+	status := apimanager.Status.Upgrade
+	if status.CurrentStep != "" || len(status.CompletedSteps) != 1 || status.CompletedSteps[0] != "2.9->2.10/TestStep" {
+		t.Errorf("unexpected upgrade status: %v", status)
+	}
+
+	err = upgrade.completeUpgrade()
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := apimanager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerUpgradingConditionType)
+	if condition == nil || !condition.IsFalse() || condition.Reason != appsv1alpha1.UpgradeCompletedReason {
+		t.Errorf("unexpected upgrading condition: %v", condition)
+	}
+	if status.CompletionTime == nil {
+		t.Error("upgrade completion time not set")
+	}
+}
+
+func TestUpgradeApproval(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations[appsv1alpha1.ThreescaleVersionAnnotation] = "2.9"
+	apimanager.Spec.UpgradeApproval = &appsv1alpha1.UpgradeApprovalSpec{ApprovedVersion: "2.9"}
+	upgrade, _ := newUpgradeTestApiManager(t, apimanager, nil)
+
+	res, err := upgrade.Upgrade()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != (reconcile.Result{}) {
+		t.Errorf("unexpected result: %v", res)
+	}
+
+	condition := apimanager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerUpgradeAvailableConditionType)
+	if condition == nil || !condition.IsTrue() || condition.Reason != appsv1alpha1.UpgradeApprovalRequiredReason {
+		t.Fatalf("unexpected upgrade available condition: %v", condition)
+	}
+	if apimanager.Status.AvailableThreescaleVersion != product.ThreescaleRelease {
+		t.Errorf("unexpected available version: %s", apimanager.Status.AvailableThreescaleVersion)
+	}
+	if apimanager.Status.Upgrade != nil {
+		t.Fatalf("upgrade started: %v", apimanager.Status.Upgrade)
+	}
+
+	// Approved. Pre-flight checks fail as databases are not deployed
+	apimanager.Spec.UpgradeApproval.ApprovedVersion = product.ThreescaleRelease
+	res, err = upgrade.Upgrade()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter != upgradePreflightRetryPeriod {
+		t.Errorf("unexpected result: %v", res)
+	}
+	if apimanager.IsUpgradeAwaitingApproval() || apimanager.Status.AvailableThreescaleVersion != "" {
+		t.Errorf("upgrade still waiting for approval: %v", apimanager.Status)
+	}
+}
@@ -59,6 +59,8 @@ func (z *ZyncOptionsProvider) GetZyncOptions() (*component.ZyncOptions, error) {
 
 	z.zyncOptions.ZyncMetrics = true
 
+	z.zyncOptions.ZyncOpenTelemetry = openTelemetryConfig(z.apimanager.Spec.Zync.AppSpec.OpenTelemetry, component.OpenTelemetryHTTPProtocol)
+
 	z.zyncOptions.ZyncQueServiceAccountImagePullSecrets = z.zyncQueServiceAccountImagePullSecrets()
 
 	z.zyncOptions.Namespace = z.apimanager.Namespace
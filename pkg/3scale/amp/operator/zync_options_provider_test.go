@@ -242,6 +242,7 @@ func defaultZyncOptions(opts *component.ZyncOptions) *component.ZyncOptions {
 
 func TestGetZyncOptionsProvider(t *testing.T) {
 	falseValue := false
+	trueValue := true
 
 	cases := []struct {
 		testName               string
@@ -365,6 +366,24 @@ func TestGetZyncOptionsProvider(t *testing.T) {
 				return expectedOpts
 			},
 		},
+		{"WithOpenTelemetry", nil,
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerSpecTestZyncOptions()
+				apimanager.Spec.Zync.AppSpec.OpenTelemetry = &appsv1alpha1.OpenTelemetrySpec{
+					Enabled:  &trueValue,
+					Endpoint: "http://otel-collector:4318",
+				}
+				return apimanager
+			},
+			func(opts *component.ZyncOptions) *component.ZyncOptions {
+				expectedOpts := defaultZyncOptions(opts)
+				expectedOpts.ZyncOpenTelemetry = &component.OpenTelemetryConfig{
+					Endpoint: "http://otel-collector:4318",
+					Protocol: component.OpenTelemetryHTTPProtocol,
+				}
+				return expectedOpts
+			},
+		},
 	}
 
 	for _, tc := range cases {
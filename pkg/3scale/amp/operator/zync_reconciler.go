@@ -44,7 +44,14 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Zync DC
-	err = r.ReconcileDeploymentConfig(zync.DeploymentConfig(), reconcilers.GenericDeploymentConfigMutator())
+	zyncDCMutator := reconcilers.DeploymentConfigMutator(
+		reconcilers.DeploymentConfigReplicasMutator,
+		reconcilers.DeploymentConfigContainerResourcesMutator,
+		reconcilers.DeploymentConfigAffinityMutator,
+		reconcilers.DeploymentConfigTolerationsMutator,
+		openTelemetryDCMutator,
+	)
+	err = r.ReconcileDeploymentConfig(zync.DeploymentConfig(), zyncDCMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -98,12 +105,12 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(zync.ZyncPodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(zync.ZyncPodMonitor(), zync.ZyncServiceMonitor(), zync.ZyncMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePodMonitor(zync.ZyncQuePodMonitor(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileMonitor(zync.ZyncQuePodMonitor(), zync.ZyncQueServiceMonitor(), zync.ZyncQueMetricsService())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -113,12 +120,12 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(zync.ZyncPrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(zync.ZyncPrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(zync.ZyncQuePrometheusRules(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(zync.ZyncQuePrometheusRules(), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
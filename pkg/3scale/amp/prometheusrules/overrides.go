@@ -0,0 +1,133 @@
+package prometheusrules
+
+import (
+	"fmt"
+	"regexp"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// alertThresholdRegexp matches the comparison ending an alert expression
+var alertThresholdRegexp = regexp.MustCompile(`(==|!=|>=|<=|>|<)(\s*)-?[0-9]+(\.[0-9]+)?(\s*)$`)
+
+// ApplyOverrides customizes the alerts of the given PrometheusRule with the
+// given overrides. Disabled alerts are removed, the thresholds, durations and
+// labels of the overridden alerts are replaced and the additional rules
+// targeting the PrometheusRule are added in their own group
+func ApplyOverrides(prometheusRule *monitoringv1.PrometheusRule, overrides *appsv1alpha1.PrometheusRulesSpec) error {
+	if overrides == nil {
+		return nil
+	}
+
+	alertOverrides := map[string]*appsv1alpha1.PrometheusAlertSpec{}
+	for idx := range overrides.Alerts {
+		alertOverrides[overrides.Alerts[idx].Name] = &overrides.Alerts[idx]
+	}
+
+	for groupIdx := range prometheusRule.Spec.Groups {
+		group := &prometheusRule.Spec.Groups[groupIdx]
+		rules := []monitoringv1.Rule{}
+		for _, rule := range group.Rules {
+			alertOverride := alertOverrides[rule.Alert]
+			if rule.Alert != "" && alertOverride != nil && alertOverride.Disabled {
+				continue
+			}
+
+			err := overrideAlert(&rule, overrides.ExtraLabels, alertOverride)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+		}
+		group.Rules = rules
+	}
+
+	var additionalRules []monitoringv1.Rule
+	for _, additional := range overrides.AdditionalRules {
+		if additional.PrometheusRule != prometheusRule.Name {
+			continue
+		}
+		for _, ruleSpec := range additional.Rules {
+			rule := monitoringv1.Rule{
+				Alert:       ruleSpec.Alert,
+				Record:      ruleSpec.Record,
+				Expr:        intstr.FromString(ruleSpec.Expr),
+				For:         ruleSpec.For,
+				Labels:      copyLabels(ruleSpec.Labels),
+				Annotations: copyLabels(ruleSpec.Annotations),
+			}
+			err := overrideAlert(&rule, overrides.ExtraLabels, alertOverrides[rule.Alert])
+			if err != nil {
+				return err
+			}
+			additionalRules = append(additionalRules, rule)
+		}
+	}
+	if len(additionalRules) > 0 {
+		prometheusRule.Spec.Groups = append(prometheusRule.Spec.Groups, monitoringv1.RuleGroup{
+			Name:  fmt.Sprintf("%s/%s-additional.rules", prometheusRule.Namespace, prometheusRule.Name),
+			Rules: additionalRules,
+		})
+	}
+
+	return nil
+}
+
+// overrideAlert adds the extra labels to the given alerting rule and applies
+// the given alert override. Recording rules are left unchanged
+func overrideAlert(rule *monitoringv1.Rule, extraLabels map[string]string, alertOverride *appsv1alpha1.PrometheusAlertSpec) error {
+	if rule.Alert == "" {
+		return nil
+	}
+
+	rule.Labels = mergeLabels(rule.Labels, extraLabels)
+	if alertOverride == nil {
+		return nil
+	}
+
+	if alertOverride.Threshold != nil {
+		expr := rule.Expr.String()
+		if !alertThresholdRegexp.MatchString(expr) {
+			return fmt.Errorf("alert '%s' expression does not end with a threshold: %s", rule.Alert, expr)
+		}
+		rule.Expr = intstr.FromString(alertThresholdRegexp.ReplaceAllString(expr, "${1}${2}"+*alertOverride.Threshold+"${4}"))
+	}
+	if alertOverride.For != nil {
+		rule.For = *alertOverride.For
+	}
+	rule.Labels = mergeLabels(rule.Labels, alertOverride.Labels)
+
+	return nil
+}
+
+// mergeLabels returns a copy of the given labels with the given extra labels
+// added
+func mergeLabels(labels, extraLabels map[string]string) map[string]string {
+	if len(extraLabels) == 0 {
+		return labels
+	}
+
+	result := copyLabels(labels)
+	if result == nil {
+		result = map[string]string{}
+	}
+	for key, value := range extraLabels {
+		result[key] = value
+	}
+	return result
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
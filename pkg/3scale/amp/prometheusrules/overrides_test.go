@@ -0,0 +1,100 @@
+package prometheusrules
+
+import (
+	"reflect"
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func overridesTestPrometheusRule() *monitoringv1.PrometheusRule {
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "apicast", Namespace: "ns"},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "ns/apicast.rules",
+					Rules: []monitoringv1.Rule{
+						{Alert: "JobDown", Expr: intstr.FromString(`up{namespace="ns"} == 0`), For: "1m", Labels: map[string]string{"severity": "critical"}},
+						{Alert: "ErrorRate", Expr: intstr.FromString(`sum(rate(apicast_status[1m])) * 100 > 5`), For: "5m", Labels: map[string]string{"severity": "warning"}},
+						{Alert: "Throttling", Expr: intstr.FromString(`sum(throttled) > ( 25 / 100 )`), For: "15m"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestApplyOverrides(t *testing.T) {
+	prometheusRule := overridesTestPrometheusRule()
+	overrides := &appsv1alpha1.PrometheusRulesSpec{
+		ExtraLabels: map[string]string{"team": "api", "severity": "page"},
+		Alerts: []appsv1alpha1.PrometheusAlertSpec{
+			{Name: "JobDown", For: stringPtr("5m")},
+			{Name: "ErrorRate", Threshold: stringPtr("2.5"), Labels: map[string]string{"severity": "critical"}},
+			{Name: "Throttling", Disabled: true},
+		},
+		AdditionalRules: []appsv1alpha1.PrometheusAdditionalRulesSpec{
+			{
+				PrometheusRule: "apicast",
+				Rules: []appsv1alpha1.PrometheusRuleSpec{
+					{Record: "apicast:requests:rate1m", Expr: "sum(rate(apicast_status[1m]))"},
+					{Alert: "NoRequests", Expr: "apicast:requests:rate1m == 0", For: "10m"},
+				},
+			},
+			{
+				PrometheusRule: "backend-worker",
+				Rules:          []appsv1alpha1.PrometheusRuleSpec{{Alert: "Other", Expr: "vector(1)"}},
+			},
+		},
+	}
+
+	err := ApplyOverrides(prometheusRule, overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []monitoringv1.RuleGroup{
+		{
+			Name: "ns/apicast.rules",
+			Rules: []monitoringv1.Rule{
+				{Alert: "JobDown", Expr: intstr.FromString(`up{namespace="ns"} == 0`), For: "5m", Labels: map[string]string{"severity": "page", "team": "api"}},
+				{Alert: "ErrorRate", Expr: intstr.FromString(`sum(rate(apicast_status[1m])) * 100 > 2.5`), For: "5m", Labels: map[string]string{"severity": "critical", "team": "api"}},
+			},
+		},
+		{
+			Name: "ns/apicast-additional.rules",
+			Rules: []monitoringv1.Rule{
+				{Record: "apicast:requests:rate1m", Expr: intstr.FromString("sum(rate(apicast_status[1m]))")},
+				{Alert: "NoRequests", Expr: intstr.FromString("apicast:requests:rate1m == 0"), For: "10m", Labels: map[string]string{"severity": "page", "team": "api"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(prometheusRule.Spec.Groups, expected) {
+		t.Errorf("unexpected rule groups: %v", prometheusRule.Spec.Groups)
+	}
+
+	// The overrides are not modified
+	if len(overrides.AdditionalRules[0].Rules[1].Labels) != 0 {
+		t.Errorf("additional rule labels modified: %v", overrides.AdditionalRules[0].Rules[1].Labels)
+	}
+}
+
+func TestApplyOverridesThresholdNotFound(t *testing.T) {
+	overrides := &appsv1alpha1.PrometheusRulesSpec{
+		Alerts: []appsv1alpha1.PrometheusAlertSpec{{Name: "Throttling", Threshold: stringPtr("50")}},
+	}
+
+	err := ApplyOverrides(overridesTestPrometheusRule(), overrides)
+	if err == nil {
+		t.Error("threshold of expression without threshold overridden")
+	}
+}
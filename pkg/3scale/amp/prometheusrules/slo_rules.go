@@ -0,0 +1,45 @@
+package prometheusrules
+
+import (
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func init() {
+	PrometheusRuleFactories = append(PrometheusRuleFactories,
+		NewApicastProductionSLOPrometheusRuleFactory,
+		NewBackendListenerSLOPrometheusRuleFactory,
+	)
+}
+
+type ApicastProductionSLOPrometheusRuleFactory struct {
+}
+
+func NewApicastProductionSLOPrometheusRuleFactory() PrometheusRuleFactory {
+	return &ApicastProductionSLOPrometheusRuleFactory{}
+}
+
+func (a *ApicastProductionSLOPrometheusRuleFactory) Type() string {
+	return "apicast-production-slo"
+}
+
+func (a *ApicastProductionSLOPrometheusRuleFactory) PrometheusRule(ns string) *monitoringv1.PrometheusRule {
+	return component.ApicastProductionSLOPrometheusRules(ns, appsv1alpha1.Default3scaleAppLabel, component.DefaultApicastProductionServiceLevelObjective())
+}
+
+type BackendListenerSLOPrometheusRuleFactory struct {
+}
+
+func NewBackendListenerSLOPrometheusRuleFactory() PrometheusRuleFactory {
+	return &BackendListenerSLOPrometheusRuleFactory{}
+}
+
+func (b *BackendListenerSLOPrometheusRuleFactory) Type() string {
+	return "backend-listener-slo"
+}
+
+func (b *BackendListenerSLOPrometheusRuleFactory) PrometheusRule(ns string) *monitoringv1.PrometheusRule {
+	return component.BackendListenerSLOPrometheusRules(ns, appsv1alpha1.Default3scaleAppLabel, component.DefaultBackendListenerServiceLevelObjective())
+}
@@ -6,6 +6,8 @@
 // assets/monitoring/backend-grafana-dashboard-1.json.tpl
 // assets/monitoring/kubernetes-resources-by-namespace-grafana-dashboard-1.json.tpl
 // assets/monitoring/kubernetes-resources-by-pod-grafana-dashboard-1.json.tpl
+// assets/monitoring/product-grafana-dashboard-1.json.tpl
+// assets/monitoring/slo-grafana-dashboard-1.json.tpl
 // assets/monitoring/system-grafana-dashboard-1.json.tpl
 // assets/monitoring/zync-grafana-dashboard-1.json.tpl
 package assets
@@ -184,6 +186,46 @@ func monitoringKubernetesResourcesByPodGrafanaDashboard1JsonTpl() (*asset, error
 	return a, nil
 }
 
+var _monitoringProductGrafanaDashboard1JsonTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5c\x6d\x6f\xdb\x38\x12\xfe\x9e\x5f\x41\x10\x8b\x4b\xf6\xe0\x14\x91\x13\xb7\x49\x80\x7e\xc8\x16\xe8\xa2\x40\xf6\xae\x48\xbb\xfb\xa5\x28\xbc\xb4\x38\x96\x79\xa1\x48\x95\xa4\x9c\xf8\x02\xdf\x6f\x3f\x90\x92\x65\x52\xa2\x13\xb7\xd9\x75\xfa\x22\xc4\x40\xcd\xe1\xdb\x70\x9e\x99\xe1\x43\x4a\xee\xdd\x1e\x42\x08\x61\x22\x84\x34\xc4\x30\x29\x34\x3e\x47\x95\x10\x21\xcc\x99\x36\xf8\x1c\x7d\xa8\xcb\xa8\xa9\xb1\x1f\x3c\x29\x19\x37\x6f\x04\x3e\x47\xc9\xc0\x97\x53\x62\x88\x96\xa5\x4a\x01\x9f\x23\x7c\x78\x88\x7e\x55\x64\x4a\x04\x41\x87\x87\x38\x68\x08\x82\x4c\xb8\x6d\x64\x54\x09\x41\xcd\x8c\xd1\xa8\x9c\xa5\x52\xbc\x92\x5c\x2a\x3b\xb2\xca\x26\xe4\xe0\x68\x80\x86\x49\x32\x40\xc3\xd1\x68\x80\x92\x9f\xc3\x09\x04\xc9\xed\x30\xf8\x62\xbd\x3c\xf4\x0f\x74\xc1\x41\x19\x1d\xb6\x34\x8b\xc2\xb5\xa4\x44\xcf\x26\x92\x28\x8a\x9b\xda\x65\xfd\xed\xa3\xfb\x77\x59\x75\xc3\x40\x99\xe9\x68\x8f\x33\x01\xe6\x0d\xc5\xe7\x48\x94\x9c\xaf\x64\x8a\x14\xb3\xf7\x52\x72\xc3\x0a\x7c\x8e\x8e\x6a\x31\xa3\x6b\xc3\x61\xce\xc4\xb5\xb5\xfc\x87\x8f\xb5\xa0\x20\x02\xb8\xf6\x6c\xbf\xb6\x3c\x4e\x25\xe7\xa4\xd0\x60\x07\x98\x12\xae\x3d\x13\xe1\x4c\x31\xfa\x56\xfa\x20\xda\x3f\x3c\xeb\x80\x74\x83\xcf\xd1\xf0\x24\x10\xdd\xae\xb5\xab\x25\x0b\x2b\x69\x04\xf5\xd2\x3b\xda\xb7\x14\xae\x97\x60\x3f\x58\x41\x01\xc4\x04\xf6\xb0\x7f\xd8\x30\xe3\x4c\x87\xdf\x2b\x32\x9d\xb2\xd4\x03\xa3\x81\x42\xc9\x1b\xbc\xd7\x9a\xd9\xb3\x02\xe1\x8c\x68\xe7\x0b\x76\xd6\x3b\x5f\xb9\x09\x51\x3a\x62\x1b\x8b\xed\x25\x88\xcc\x38\x6b\x78\x2b\x75\x35\x10\xef\xe2\xbb\xf2\x4f\x5e\xd1\x6f\x04\x3a\x55\xac\xb0\xd1\x63\x5b\x5d\xc1\xa7\x12\xb4\xd1\xa8\x00\x85\x34\xa4\x52\x50\xa4\x41\xcd\x81\xa2\xc9\x02\x5d\xbc\x7d\x93\x12\x6d\xd0\x54\x2a\x64\x66\x80\x0a\x25\x69\x99\x1a\xdf\x00\x53\xc6\x79\xcb\xba\x56\xf4\xab\x22\x94\x81\x30\x21\x4a\xf7\x21\xfe\xa2\x83\xf8\xe9\x16\x80\x27\x8d\xc0\xb7\xa9\x73\xd7\xa1\x27\xe0\x90\x81\xa0\xed\x69\xc9\x3c\xeb\x9a\xd1\xfa\x6c\xa9\x54\xa5\x7b\x27\xa6\x73\x72\x1b\xed\x92\x33\x11\x95\xeb\x99\xbc\x89\x8d\x63\xa4\x21\x3c\xda\x63\x4e\x78\xe9\xe0\xb5\x7d\xa2\xab\xe3\x4c\x34\x0d\x5a\xe2\x1b\x46\x4d\x2b\x7c\x3a\xe1\x6a\x3f\xd8\xba\xf8\x5b\xc9\x84\xf9\x4d\xba\xe4\xe5\x04\x3e\xae\xb2\x68\x27\xd8\xc6\xc7\x2e\x9b\x01\xa3\xea\x15\xa0\x52\x10\x86\x64\xd0\x5d\x1f\x2e\xec\x9c\xd6\x37\x4a\xbb\x82\x51\xbb\x26\xe6\xd6\x0a\x04\x05\x05\x2e\x8b\x4e\xb9\x0c\xbc\x4f\x83\x62\xa0\xff\x3d\x07\xa5\x18\x85\xce\x2a\x75\x41\x52\x88\x47\x91\x36\x24\xbd\x8e\xcc\xa6\x0d\x14\x05\xd0\x4b\x26\x62\xea\x1b\xa2\x32\x30\x7e\xaa\x0b\x03\xdd\xfe\x61\xb8\x2d\x9c\xb2\xba\xcc\x0f\x14\x31\x70\x40\x0a\x66\xc3\x68\xac\x0d\x31\xa5\xbe\xb3\x89\xde\x69\xf6\x72\xff\xee\x0e\x3d\xfb\xd7\xaa\x88\x96\xcb\xfd\x01\x2a\x24\x7d\xf9\xbf\xfd\xba\xcb\xe1\x4f\x20\xe6\xcf\xfe\xb9\x3f\x70\x41\xc9\x52\x18\xeb\x85\x36\x90\x8f\xed\x18\x55\xf7\x77\x4e\x60\x07\xb1\xfd\x97\x1f\x92\xfc\xe3\xcf\xe1\xce\x62\x43\x52\xaa\xdc\xa5\x36\x6c\x58\x0e\xe3\xca\x6c\xed\x46\x4c\x18\x50\x73\xc2\x5f\x93\xd4\x48\x15\x46\x90\x17\x45\xaf\x9b\xb1\x54\x9d\x3c\xda\x03\x29\x98\xba\x7d\x05\x5f\xb4\x6b\xac\x75\x1d\x12\x9e\x78\xb5\x65\x21\xe4\x43\x67\x66\x0a\xf4\x4c\x72\xda\x01\xd5\xae\xe0\xb5\x92\x79\x2c\x51\xe7\x70\x05\x59\xed\xb7\x9d\x4e\xef\x66\x6c\x7a\x5f\x7a\xbf\xea\x2e\x07\x9b\x66\x2b\x0c\xc2\x40\xcf\x88\x02\xda\x89\x41\x5b\x23\x55\x2b\xe9\xad\x62\x7a\xbc\xda\x2b\x98\xa0\x6c\xce\x68\x49\xb8\xb7\x6f\x47\xf6\x14\xb7\x17\xfb\xca\xdc\x92\x5b\xd6\x89\xc8\x49\x99\x5e\x57\x2e\x19\xae\xcb\x26\xab\x3a\xb6\xad\x5d\xa2\x5c\xa3\xd3\x63\x53\xba\x6a\x92\xd2\x86\x98\x5f\x90\x5b\x78\x20\x2a\xd6\x2e\xa8\xe0\x53\xd1\x71\x3e\x4e\x26\xc0\x23\x2a\xd9\x2a\x99\xfd\x42\x34\x84\x69\xcd\xcb\xc6\x91\x2e\x55\x3a\x0e\x40\x08\x57\xe7\x55\x2c\x07\xdb\x28\xad\x67\x16\xd8\x1d\x28\x1d\xa9\xa8\xf5\x76\xc9\xe8\xc1\xc0\x59\xc4\x9c\x84\x70\x96\xc5\x37\x28\x57\x73\x09\xf3\x66\x1d\x7b\xed\xe1\x1b\xfb\xac\x47\xfc\xb6\xf8\xcc\x64\xe1\xc8\x4b\x95\x7d\x51\x2a\x29\x20\x05\xa6\x54\x22\x20\x39\x4f\x46\x6a\x4e\x3f\x87\xd4\x1c\xf7\xa4\xa6\x27\x35\x3f\x28\xa9\xb1\xc1\x7a\x50\x45\xf1\x8e\x08\xce\xdd\xdd\x9f\x77\x77\xd5\x8c\xcb\xe5\x9f\xcb\xe5\x77\x42\x74\xde\xad\x33\x61\x4f\x76\x7a\xb2\xd3\x93\x9d\xef\x88\xec\x28\xf8\x0f\xa4\x06\xa8\xbb\xb3\x81\xdb\x14\x80\x32\x91\x39\x02\x44\x8a\x82\xb3\xd4\xdd\x2c\xa2\x82\x13\x81\x38\xcb\x99\xd1\xcf\x9a\x6b\x9e\xaa\xaf\xb6\x8d\x73\x74\xc3\xcc\xcc\x7e\x43\x27\xc3\xb3\x15\x77\x9a\x2c\x10\x85\x29\x29\xf9\xd3\xb1\xa5\xe4\xf9\xe7\xd0\xa5\x93\x9e\x2e\xf5\x74\xe9\x47\xa3\x4b\x83\x3a\x5c\x5f\xee\x9f\x0c\xcf\x76\x7b\x23\x74\x32\x3c\xfb\x4e\x38\xd2\xa5\xcb\x8d\x75\x06\x05\xda\xd3\xa4\x9e\x26\xf5\x34\xe9\x5e\x9a\xb4\xdb\x27\x7d\xa7\x7b\x11\xac\xaa\x4d\x7f\xf4\xf8\x27\x7d\x97\xc4\x80\x48\x17\x38\x12\x81\xdf\xea\x93\xbe\xf7\x2c\x07\x24\x5c\x32\x43\x46\x22\x0d\x82\x3a\x7a\xa7\x40\x17\x52\x68\xb0\x42\x5b\x4e\xb9\x23\x6d\xbb\xe1\x77\xc9\x70\x0b\xa8\xcf\x1a\x81\x6f\x4d\x07\xf5\xf3\x9e\xdf\xf5\xfc\xee\x29\xf9\xdd\x8c\x69\x23\x33\x45\xf2\xf1\xa7\x92\x08\xc3\x38\x1c\x1c\x3d\x1b\x0d\x50\xc3\xfb\xdc\x9d\xe8\x78\x15\x64\xe3\x9a\x6c\xd9\xa3\x9a\x1e\x57\xdb\xf4\xce\xae\xce\x38\xec\x8a\x06\x16\xa3\xa3\x47\xd2\xc0\xc1\x17\x9a\xfe\xac\xb7\xfd\xd9\x68\xa3\xed\x7f\xf9\x7b\x6d\x7f\xd6\xdb\x7e\xf3\xf1\xe7\xd5\x56\xb6\x6f\xbe\x3f\xf1\xf1\xe7\xaa\x06\x0e\xb5\xce\x09\xfd\xe1\xe7\x6f\x38\xfc\xe8\x5d\x9c\x21\x02\x00\xc2\x95\x79\x15\xcb\xc1\x56\x0a\xf7\xf7\xc3\xdf\xc6\xfd\xb0\x17\xc4\x48\x4e\x1d\xdf\x2f\x0b\x6d\x14\x90\xdc\xde\xfa\x3e\x1d\xcb\x4f\x86\x9f\x43\xf3\xbd\x21\xeb\x9c\xdb\xd3\xfc\x9e\xe6\x7f\x4d\x34\x7f\x15\x55\x3d\xd3\x7f\x12\xa6\xff\xc3\x9a\xff\xeb\x20\xfb\x3f\xae\xf9\xbf\x17\xbe\xff\x7b\x8d\x20\x52\x3d\xf1\xef\x89\x7f\x4f\xfc\xb7\x22\xfe\xbb\x7d\xe2\x91\x3c\xdf\x8b\x80\x55\x11\xe4\xd3\xc7\x3f\xf2\xf8\x8d\x14\x85\x7d\x53\x44\x95\x3c\xc8\x8c\xdb\x3e\xf8\x48\xa5\x30\x15\xd3\xb6\xd9\xb9\x1e\xed\xca\x0e\xf6\xaa\xaa\x41\xcb\xe5\xa6\xf3\x4c\x4b\xa7\xd6\x31\x26\xd0\x6c\x75\x8c\xa9\x7f\x8a\x84\x48\xfd\x18\x23\x07\xa3\x58\xaa\x91\x54\x28\x07\x33\x93\xd4\xbd\xc6\xb2\x40\x4c\xa4\x0a\xf2\xd6\x13\x8d\x7b\xc0\x39\xfd\x42\x70\x5e\x34\x12\x7f\x95\x0e\x9c\xb3\x87\x18\xfc\x2a\x3b\xe5\x44\x5d\x53\x79\x23\x36\x90\xf7\xe5\xd6\x1b\xc1\x43\xd9\x3e\xb0\x68\x0c\x6b\x03\xb7\x06\x7f\x35\x9e\x3f\x1c\x35\x12\xdf\x06\x8c\xb6\x4f\x02\x5f\xe8\xfa\x17\xad\x17\xa3\x1e\xef\xfe\xde\x88\x6f\xed\x80\x8f\x0d\x81\xfa\x6d\x04\x39\x8d\xbe\xc8\xd5\x0e\x0a\xbc\x1d\x1a\x5f\xe8\xea\xc3\x7b\xf2\x50\x92\x7c\x75\xbe\x7e\x11\x7f\xeb\x2d\x06\x71\xe0\xf5\x7b\xde\x96\x62\x59\x9d\xbd\x82\xb5\x8d\x92\xbc\xee\x8a\x75\x3a\x83\x9c\xfc\x01\x4a\x57\x89\x2a\xa9\x0d\x8a\xb5\x59\xf0\xfa\x07\xba\xea\x7a\xd5\xda\x90\xcc\xe7\x05\xf8\x58\xa7\x84\xaf\xc9\x08\x5e\x61\x17\xcc\x6b\x20\x2f\x38\x31\x4c\x64\xdb\xff\xd2\xb9\xfe\x39\x72\x08\x1c\x13\x29\x2f\x29\x5c\xf0\xf8\x4d\xc5\xa6\x2d\x1c\xe7\x25\x37\x2c\xda\xa5\x26\x4e\xbe\x17\x07\xf5\x6b\x30\x7d\xfc\x11\xc2\x9f\x4a\x50\x36\xaa\xed\x8a\x6d\xaa\x86\xd2\x8f\xb7\xc0\xd8\x61\xbe\x50\x90\x81\x4d\x10\x38\x6c\xae\xaf\x59\xf1\xbb\xe2\xef\x16\x22\x8d\x2a\xba\x42\xd7\x53\x34\xe6\xc0\x81\x0d\x09\xe7\x7f\x58\xa6\x1a\x33\xc9\xfa\x4a\xc9\xef\x82\x6a\xf7\x39\x6f\x90\xb4\x4e\xe1\x77\x5c\x91\xc7\x56\x13\xaf\xc5\x72\xf0\x57\xe1\x88\x41\xcc\x99\x92\xa2\xb5\xf1\x6d\x05\x28\x88\xf9\x46\x24\x3d\x71\x68\xb1\xfa\x0a\x87\xbb\x77\x4f\x23\xbc\x79\x3b\x03\x3d\x6c\xa2\x96\x91\xee\x57\xa2\xb3\xc6\x40\x0b\x6d\x48\x66\x03\x6b\xb3\x0a\xab\x16\x41\x83\x35\xdf\x6c\xc2\x34\xe2\xd8\xb5\xea\x83\xd8\x24\x5b\x7b\x6c\x5a\x6a\x23\x73\xbc\xd7\x9e\x3a\xfc\x71\xbf\x4d\x82\x7e\x76\x98\x56\xe7\x43\x2c\xe4\xcd\xe1\xf3\xf5\xa9\x09\x1b\x59\x4b\x71\xa7\x7b\xc1\xd2\x6b\x50\xfe\x20\x75\x0c\x8e\x57\x47\xe2\x10\x7d\x3c\x0a\xb6\xc8\xe4\x28\x28\x1e\x87\xc5\x26\x61\xda\x0f\x1e\x05\xa5\x24\x2c\x1e\x1f\x85\xb5\xc1\x99\x6f\x18\x94\x92\xe6\x3f\x3d\x68\x40\x70\x67\xe0\x71\xcc\x5b\xef\x9f\x35\x9c\xc6\x33\x99\x6d\x1a\xce\x3a\x3c\x09\x8b\xc1\x7b\x81\x2f\x82\xd2\xf1\x11\xc5\x1b\xf1\xfa\xaf\x14\xe0\x65\xb2\x66\xb7\xb2\xdc\xe1\xbd\xfd\xde\x30\x05\x3c\x5f\xef\x2f\x7b\xcb\xff\x0f\x00\xe3\xa2\x9c\x3b\x02\x43\x00\x00")
+
+func monitoringProductGrafanaDashboard1JsonTplBytes() ([]byte, error) {
+	return bindataRead(
+		_monitoringProductGrafanaDashboard1JsonTpl,
+		"monitoring/product-grafana-dashboard-1.json.tpl",
+	)
+}
+
+func monitoringProductGrafanaDashboard1JsonTpl() (*asset, error) {
+	bytes, err := monitoringProductGrafanaDashboard1JsonTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "monitoring/product-grafana-dashboard-1.json.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _monitoringSloGrafanaDashboard1JsonTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5d\x6d\x6f\xdb\x38\x12\xfe\x9e\x5f\x41\x10\x87\xc3\x1e\x90\x6c\xa3\xb8\x49\xbb\xfe\x96\x1e\xae\x8b\x02\xb9\xdb\x62\x7b\x77\x5f\x16\x0b\x83\x16\xc7\x16\x2f\x14\xa9\x25\x29\x27\xbe\xc2\xff\x7d\x41\x49\x96\x49\x4b\x76\x1d\x2c\xfc\x12\xef\xc0\x42\x6b\x0d\xdf\x86\x33\xe4\xcc\x13\x3e\x16\xf4\xf5\x82\x10\x42\x28\x53\x4a\x3b\xe6\x84\x56\x96\x0e\x49\x2d\x24\x84\x4a\x61\x1d\x1d\x92\x5f\x9a\x7b\xd2\x96\xf8\x8b\x8e\x4b\x21\xdd\x27\x45\x87\x24\xb9\x0c\xe5\x9c\x39\x66\x75\x69\x52\xa0\x43\x42\xaf\xae\xc8\x8f\x86\x4d\x98\x62\xe4\xea\x8a\x46\x15\x41\xb1\xb1\xf4\x95\x9c\x29\x21\x2a\xc9\x04\xef\x95\x8b\x54\xab\xbf\x6b\xa9\x8d\xef\xd9\x4c\xc7\xec\xbb\xeb\x4b\x72\x93\x24\x97\xe4\xe6\xf6\xf6\x92\x24\x7f\x8b\x07\x50\x2c\xf7\xdd\xd0\xfb\xd5\xf4\xc8\x5f\xc9\xbd\x04\xe3\x6c\x5c\xd3\xcd\x8b\xaa\x26\x67\x36\x1b\x6b\x66\x38\x6d\x4b\x17\xcd\xb7\x5f\xab\xff\x17\x75\x33\x0a\x5c\xb8\x8e\xf6\x74\xaa\xc0\x7d\xe2\x74\x48\x54\x29\xe5\x52\x66\x58\x91\xfd\x5b\x6b\xe9\x44\x41\x87\xe4\xba\x11\x0b\xbe\x32\x1c\x95\x42\x3d\x7a\xcb\xff\xf2\x6b\x23\x28\x98\x02\x69\x03\xdb\xaf\x2c\x4f\x53\x2d\x25\x2b\x2c\xf8\x0e\x26\x4c\xda\xc0\x44\x74\x6a\x04\xff\xac\x43\x27\xfa\x0f\xcd\x3a\x4e\x7a\xa2\x43\x72\xf3\x36\x12\x3d\xaf\xb4\x6b\x24\x73\x2f\x69\x05\xcd\xd4\x3b\xda\xaf\x29\xdc\x4c\xc1\x5f\xd4\x40\x01\xcc\x45\xf6\xf0\x1f\xea\x84\xab\x4c\x47\xef\x3f\x7f\x4a\x99\x75\xa4\x30\x9a\x97\xa9\x5f\x80\x84\xcd\x98\x90\x6c\x2c\xa4\x70\xf3\xc0\x49\xad\x8b\x8c\x7e\xa2\x17\x6b\x1a\x05\xd6\x61\x52\x30\x5b\xad\x11\xaf\xcd\xd7\x50\xe9\x31\x33\xb6\xc7\x66\xde\xe7\x0f\xa0\xa6\xae\xb2\x52\x60\x81\xaa\x04\xfa\x9b\x84\x4b\xfc\x2f\xc1\x6d\x58\x09\x6c\x6a\x44\xe1\x27\xe5\x6b\xfd\xec\x17\x20\xd1\x13\x62\xe0\xb7\x12\xac\xb3\x44\x69\x47\x72\x00\x27\xd4\x94\xb8\x0c\x88\x1e\xff\x0f\x52\x27\x66\x51\x2f\x13\x21\xe5\x9a\xad\xbd\xe8\x47\xc3\xb8\x00\xe5\x62\x9f\x6d\xf3\xff\xbb\x8e\xff\xdf\xef\xe0\xfe\xa4\x15\x84\x96\xac\x16\xef\x4d\x20\x90\x30\x05\xc5\xd7\x87\x65\xb3\x69\xd7\x78\x7e\x05\x97\xc6\xd4\xba\x77\x76\x78\xce\x9e\x7b\x9b\xe4\x42\xf5\xca\x6d\xa6\x9f\xfa\xfa\x71\xda\x31\xd9\xdb\x62\xc6\x64\x59\x39\xd5\xb7\xe9\x9d\x9d\x14\xaa\xad\xb0\x26\x7e\x12\xdc\xad\x6d\xa6\xce\xe6\xf5\x17\xf5\x0b\xfe\xb3\x16\xca\xfd\x53\x57\xa1\xac\x12\x84\x7e\xd5\xc5\x7a\xb8\x6d\x57\xd6\x43\xdb\x61\xaf\x7a\x05\x98\x14\x94\x63\x53\xe8\xce\x8f\x16\x7e\x4c\xbf\x36\x4a\x3f\x83\xdb\xf5\x92\xbe\xc5\x6c\x40\x71\x30\x50\xc5\xd4\x89\xd4\x2e\xd4\xd2\x82\x11\x60\x7f\x9a\x81\x31\x82\x43\x67\x96\xb6\x60\x29\xf4\xef\x1d\xeb\x58\xfa\xd8\x33\x9a\x75\x50\x14\xc0\x1f\x84\xea\x53\xdf\x31\x33\x05\x17\x06\xbe\x78\x7b\xfb\x0f\x85\xe7\xa2\x52\xd6\x65\x06\xc0\xa6\x4c\xc2\x90\x15\xc2\xc7\x91\xd1\x2a\x8e\x8c\xc2\x38\x32\x04\x63\xb4\x19\x19\xbf\x05\xfd\xbf\x70\x9b\x07\xb3\xf4\x17\x9d\x68\x93\x57\xa1\x8a\x3a\x91\xc3\xa8\x9e\xf8\x7a\x25\xa1\x1c\x98\x19\x93\x1f\x59\xea\xb4\x89\xf7\x40\xb0\x0f\x3e\xb6\x7d\x75\xc7\x31\x30\xa9\x32\x04\xbd\x5f\x2f\xf1\x96\xa9\xac\x18\x88\x17\x97\x7b\x35\x43\x92\x1d\xc6\x0c\x49\xb6\xd1\x0c\x1f\x76\x32\x43\xfb\x3d\x5c\x7d\x7e\xe2\x36\xd3\x92\x77\xd6\xa5\xd7\xfd\xa3\xd1\x79\x5f\xe6\xc9\xe1\x67\x98\x36\x5b\xaf\xd3\xe8\x4b\x26\x26\xdb\xf2\xd5\x3f\xbc\x01\x49\xb5\x8e\x02\xbd\xa9\x6b\xd3\x7b\xe8\x23\x6a\x33\x66\x80\x77\x22\x89\x2f\xd1\x66\x2d\x74\x2f\x23\xd3\x68\x99\xe7\x84\xe2\x62\x26\x78\xc9\x64\x80\x45\x7a\xf2\x61\x85\x2f\x42\x65\x9e\xd9\xb3\xe8\xc4\x95\x71\x99\x3e\xd6\x1b\x2b\x9e\x9a\x0f\xb9\x4d\x84\xf2\xa6\xe9\xc5\x4f\x9d\x16\x9b\x82\x6e\x1b\x5a\x37\x44\xae\x39\x7b\x86\x6f\xec\xed\xd5\xfa\x6b\xc2\x5c\xa9\x44\x18\x91\xfc\x87\x4a\x36\x06\xd9\xa3\x98\x2f\xd2\xd3\x0f\xcc\x42\x1c\xa2\x83\xcc\xd2\xd3\xa4\x4e\x2d\x91\x2b\xe2\x39\x06\x05\x8b\xcb\x5d\x54\xb7\x99\x77\xef\x01\x94\xee\x29\x68\xf4\xae\x02\x6b\x50\xd2\xbf\x83\xe6\x7d\x4b\x85\x49\x31\xed\x4f\xb6\x55\xc9\x03\xcc\xda\x79\x5c\xac\x77\xdf\xda\x67\xd5\xe3\xe9\x22\xb2\x2f\x05\x00\xaf\x90\x57\x95\x1e\xc8\xb8\xe4\x53\x70\x44\x58\x32\x06\x8f\xc9\x52\xad\x6c\x99\x03\x27\xcc\x7d\x4f\xee\xc9\xb8\x34\xca\x6f\x7d\xf0\x20\x2e\x21\xf0\x9c\xb1\xd2\xc3\xb8\x4e\x07\xcc\xd5\x9d\x2a\xee\x6b\xfa\xaf\x03\xc2\xd9\xdc\x92\x27\xa1\xb8\x7e\x3a\x1a\xba\x7b\xff\x12\x74\x37\x40\x74\x87\xe8\xee\x95\xa2\xbb\x24\x23\x6f\xc8\xd7\xaf\xe4\xfb\xfb\xba\xe1\xe7\xb6\xdd\x7d\xd0\xac\xca\xe5\x1f\xea\x3d\xbf\x58\x04\x76\x39\x0e\x10\x3a\x05\x3c\x78\x77\xb2\x86\xbb\x3b\x2f\x04\xd9\x64\x8a\x36\xa5\x20\x98\xdc\x2f\x98\x3c\x18\x22\x8b\x9c\x10\xcf\x2e\x28\x58\x5c\x9e\x94\xd2\x3d\x05\x08\x23\x77\x86\x91\xed\xc1\x5e\x07\x08\x1a\xc8\x99\x50\x1e\x4a\xea\x19\x98\x0a\x14\x4a\x7f\xda\x59\xc3\xc1\xa3\xe1\xc0\xe4\xee\x25\x40\x30\x38\x24\x6e\xa2\x32\x02\x41\x04\x82\x07\x04\x82\x09\xb9\x22\xdf\xfd\x01\x50\x33\xe0\x2f\x07\x35\x31\x91\xb4\x37\x54\x33\xe0\x1b\x51\xcd\x6e\x70\xb0\xfd\x7e\x4a\xa8\xa6\x0d\x7b\x88\x6a\xf6\x8b\x6a\xf0\x88\xec\x4c\x8e\xc8\x0e\x4b\xe9\xbe\xbf\xe8\xf1\x55\x9d\xec\x6f\xf7\x42\xe9\x4a\xe6\x40\xa5\xc8\xe6\x1e\x9d\xcd\xfd\xa1\x15\x84\x96\xac\x3c\x7f\x87\x30\x0f\x61\xde\x31\x61\xde\x76\x84\xd7\x84\x90\x3f\x31\x91\xbb\xc9\x02\x49\x76\x18\x0b\x24\xe7\x75\x02\x87\x1c\x2e\x72\xb8\xc8\xe1\x22\x87\xfb\xea\x39\xdc\x2d\x98\x2e\xe8\xb0\x09\xe7\x88\xe9\x10\xd3\x9d\x3c\xa6\xdb\x4c\xdf\x3e\xd4\x2d\x8e\x41\x40\x26\xd9\xa9\x02\xc0\xbb\x53\x34\x17\xf2\xb5\xc8\xd7\x22\x5f\x8b\x7c\x2d\xf2\xb5\x7b\xe1\x6b\xb7\x80\xbe\xa0\x7d\x13\x95\x11\xf4\x21\xe8\x3b\x20\xe8\xdb\x81\xaf\xdd\x04\x65\x06\xfc\x45\x50\x06\x59\x5a\x64\x69\x91\xa5\x45\x96\xf6\x2c\x59\xda\xe4\xee\xa2\xc7\x59\x35\x4d\xfb\xc3\x1f\xa7\x69\x3f\xb0\xf4\xd1\x9f\x6e\xf9\x67\xbc\x41\x81\xc1\xe7\x6e\x4f\xeb\xb9\xdb\x77\xad\x24\x34\xa5\xe0\xeb\xb6\x42\x88\x87\x10\xef\xe0\x10\x2f\x40\x77\xe3\x3a\x8e\x8c\x96\x71\x64\xfb\x6f\xf1\xce\x94\xae\x7d\x99\x11\x92\xec\x30\x46\x48\xce\xeb\x0c\x0e\x19\x5b\x64\x6c\x91\xb1\x45\xc6\xf6\xd5\x33\xb6\x5b\xb1\x5d\x82\xd8\x0e\xb1\xdd\xab\xc4\x76\x2d\x6d\xdb\xfc\x69\xf9\xd0\xb4\x3a\xf6\xa3\xa3\x49\x76\xc2\x58\xf0\xee\x44\x8d\x86\xfc\x2d\xf2\xb7\xc8\xdf\x22\x7f\x8b\xfc\xed\x5e\xf8\xdb\xad\x10\x30\x88\xcd\x4d\x5c\x46\x08\x88\x10\xf0\x80\x10\x70\x9d\xc1\x7d\x19\xa4\x19\xf0\x97\x42\x1a\xe4\x71\x91\xc7\x45\x1e\x17\x79\xdc\xb3\xe4\x71\x6f\xde\x5e\xf4\x38\xab\x49\xf5\x83\x3d\x10\xb9\xcd\x8f\x6b\x68\xcf\xd6\x44\x0e\xf7\x90\x1c\xee\xcd\x6d\x2b\x09\x4d\x59\x7b\xfe\x2d\x82\x3c\x04\x79\xc7\x04\x79\xdb\xf0\xdd\xa6\xdf\xe7\xfd\x59\xe8\xdb\x4d\xf3\x4f\xb2\xc3\xcc\x3f\x39\xaf\xd3\x37\x64\x6e\x91\xb9\x45\xe6\x16\x99\xdb\x57\xcf\xdc\x6e\x45\x74\xb7\x88\xe8\x10\xd1\xbd\x36\x44\xb7\x89\xb4\x3d\xe2\xa3\xa3\x49\x76\x9a\xe0\xef\xee\xf4\x4c\x85\x2c\x2d\xb2\xb4\xc8\xd2\x22\x4b\x8b\x2c\xed\x5e\x58\xda\xad\x70\x2f\xa8\xde\xc4\x65\x84\x7b\x08\xf7\x0e\x08\xf7\xbe\xc9\xd2\x7e\xf3\x29\xdb\x5d\x80\x0c\x72\xb3\xc8\xcd\x22\x37\x8b\xdc\xec\x3e\xb8\xd9\x8b\x60\x08\xbf\xdf\xfd\x9f\x1d\xde\x9b\xc9\xf2\xf8\x9f\xda\x34\x83\x9c\xfd\x17\x8c\xad\x61\x45\xd2\xe4\x6d\x6a\xdd\x5c\x36\x6f\xec\x35\x8f\xcb\xda\x8e\x4d\xc3\x15\x43\x07\x55\x60\x6c\x4a\x7d\x6f\x52\xd3\x68\x4c\x07\x79\x21\x99\x7f\x10\x74\xf7\xd7\x1e\x37\xef\x26\x8e\x77\xa3\x50\xa9\x2c\x39\xdc\xcb\xfe\xa4\xbb\xc9\x9d\x34\x2f\xa5\x13\xbd\x4d\x9a\xed\x14\x62\xaf\xa8\x7c\x95\x40\xc3\x10\x45\x08\xfd\xad\x04\xe3\xd9\x47\x5a\x18\x9d\x83\xcb\xa0\x8c\xa3\x73\x60\xe8\x68\x01\x51\x03\x53\xf0\xab\x9e\xc6\xd5\xed\xa3\x28\xfe\x63\xe4\x97\xb9\x4a\x7b\x15\x5d\x86\x96\x40\xd1\x8e\xa7\xe3\x77\x27\xfb\x30\x1a\xda\x7b\x52\xc7\x61\xaa\xf4\xd3\x55\xf0\x17\x2d\x75\xba\x91\xd2\x4e\xf3\x42\xa4\x8f\x60\xc2\x4e\x9a\x59\x8d\x96\x99\x27\x0e\x1d\xf4\x36\x34\x02\x4d\xae\xa3\xdb\x41\x7c\xdb\x2e\x3f\x7f\xc5\x5c\x14\x4d\xe2\xdb\xc1\x75\x5c\x1a\xc5\xd6\x9b\xe8\x2e\x69\xdf\x29\xdd\xfa\xab\x8a\xa2\xa3\xc0\x93\x3b\x8e\x1a\x0f\x13\x1d\x02\xd0\x24\x1e\xf5\xe6\x6d\x7c\x1b\xa6\x59\xfa\x2e\xba\x1b\x5c\x73\xba\xd1\x5f\xff\xd7\x0a\x82\xb5\xb1\xca\x77\xfe\x6d\x51\xff\x62\x39\x54\x2f\x0b\x23\x8b\x05\x79\x43\xea\x6d\x47\xde\x90\x2f\x60\x66\x22\x05\x52\x45\x01\xf2\xd3\x92\xa2\x5f\x1a\x9b\xce\x56\xfb\xfa\x62\xf1\xfb\x00\xd3\x3f\xc3\x9d\x8b\x7c\x00\x00")
+
+func monitoringSloGrafanaDashboard1JsonTplBytes() ([]byte, error) {
+	return bindataRead(
+		_monitoringSloGrafanaDashboard1JsonTpl,
+		"monitoring/slo-grafana-dashboard-1.json.tpl",
+	)
+}
+
+func monitoringSloGrafanaDashboard1JsonTpl() (*asset, error) {
+	bytes, err := monitoringSloGrafanaDashboard1JsonTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "monitoring/slo-grafana-dashboard-1.json.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _monitoringSystemGrafanaDashboard1JsonTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\x6b\x73\xdb\x38\xb2\xf6\xf7\xfc\x0a\xbc\x9c\x99\xb5\x33\x2b\xd9\xa2\x2e\xb1\xe5\xaa\xd4\x5b\x71\x32\x39\xb3\x5b\x49\xd6\x93\x78\x66\x6b\x4e\x2a\xa5\x85\x48\x58\xc2\x9a\x04\x18\x00\xb4\xa5\xa4\x3c\xbf\xfd\x14\x41\x52\x04\x6f\xba\x52\x77\x7c\x49\x2c\x80\x04\xc1\xee\x46\x3f\x0f\x80\x46\xf3\xfb\x33\x00\x0c\x48\x08\x15\x50\x60\x4a\xb8\x71\x05\x82\x22\x00\x0c\x07\x73\x61\x5c\x81\xcf\xf2\x17\x88\x4a\x65\x4d\xdf\xc7\x8e\xf8\x07\x31\xae\x80\x59\x4b\x4a\x6d\x28\x20\xa7\x3e\xb3\x90\x71\x05\x8c\x7a\x1d\xfc\x0f\x83\x77\x90\x40\x50\xaf\x1b\xca\x65\x88\xc0\xbe\x13\x5c\x22\x98\x8f\x94\xf2\x21\xb6\x0b\x4a\xb1\x45\xc9\x6b\xea\x50\x16\xb4\xc9\x06\x7d\x78\xda\xa8\x81\xa6\x69\xd6\x40\xb3\xd3\xa9\x01\xf3\xb9\xda\x34\x81\xae\x7c\xf6\xab\xe4\x75\xc0\xdf\xc0\x2b\x07\x31\xc1\xd5\xeb\xc4\xd8\x93\xd7\xd9\x90\x0f\xfb\x14\x32\xdb\x88\xea\x9e\xe4\xff\x5f\x9e\x01\xf0\x14\x5c\x6e\xd8\x88\x5b\x0c\x7b\x41\x4b\xc1\xf5\xb2\x0d\x03\xd9\x58\x64\x5e\xc1\x18\x10\x24\xfe\x61\x1b\x57\x80\xf8\x8e\x13\x96\x30\xe8\x0d\x6f\x29\x75\x04\xf6\x8c\x2b\xd0\x90\x85\x38\xb8\xe4\x22\xfc\x53\x20\x06\xa3\x86\xcd\x17\x0d\xb3\x7b\xd1\x31\x3b\xa6\xd9\xea\xca\x5a\x07\x93\xfb\x40\x15\x9f\xbf\xc8\x9f\x1e\x24\xc8\xe1\x13\x65\xc4\xaa\x30\x2c\xea\x38\xd0\xe3\x28\x68\xf6\x0e\x3a\x7c\x22\x39\x63\xc0\xb0\x7d\x43\x13\x6d\x86\x22\xce\x68\xec\xd1\xb8\x02\xcd\xb6\x52\x30\x8a\x7b\x1a\xfd\x1e\x07\xbf\x63\xd9\x4c\xda\x96\x6f\x71\x39\xf9\x99\x74\xee\xcb\xa4\x4c\x60\x21\x05\x64\xfc\x1b\xf5\xc1\x2b\xcf\x33\x92\x9a\x48\xf6\x8c\x3e\x86\x52\x8f\xda\x9d\xbc\x13\x74\x30\xe4\x52\xe5\xb2\xf7\xc9\x63\xfb\x50\x96\xa4\xdf\x33\x50\xe1\x3b\x44\x06\x42\xbe\x5b\x23\x55\x8e\x8a\x2e\x57\x6d\xf4\x47\xe5\xe7\xe4\x92\x3b\xec\x38\xaa\x9c\xca\x45\x79\x99\x11\xa5\xd9\x9c\x21\x4a\xb3\x58\x94\xdd\xc9\x4f\x07\x0d\x10\xb1\xd3\x4f\x82\x0f\x83\xec\x6b\x04\x9a\xf7\x19\x43\x44\x14\xd4\xb8\x70\x54\x54\x8a\x49\x41\x29\x1f\xd2\xc7\xfc\x98\x13\x54\x40\xa7\xe0\xea\x07\xe8\xf8\x89\x4c\x73\xef\xe2\x60\x22\x6b\xd5\xd6\x64\xe1\x23\xb6\x45\xca\xf4\x32\xe6\x2d\x8b\x82\x81\x73\x43\x31\x11\xef\xa9\xf4\x03\xb2\x20\xd1\x0a\xf5\x26\xde\x29\x79\xa2\x87\x98\x85\x88\x80\x03\x94\x53\xb4\x17\x34\xc5\xa0\x8d\xfd\xe0\x9e\x66\xba\x3c\x6f\x17\x0c\x11\x1b\x31\x24\xbd\xcc\x9d\x43\x45\xf2\x60\x8e\x18\x46\xfc\x5f\x0f\x88\x31\x6c\xa3\x4c\xa7\xb9\x07\x2d\x54\x64\x7e\x5c\x40\xeb\x3e\x2b\x0b\x2e\x90\xe7\x21\xfb\x1d\x26\xf9\xfe\x0a\xc8\x06\x48\x70\xc5\xdf\xaa\x1e\x37\xf0\x3a\x23\x4f\xf6\x8e\xfb\xee\x29\x83\x02\x9d\x32\x88\x1d\xde\x63\xe8\xab\x8f\xb8\xe0\x3d\xa9\xb4\xef\x81\x07\x94\x9d\x7a\x79\xf2\xe3\xe4\xef\x93\x9a\x47\xed\x97\x7f\x9d\xf0\x31\x17\xc8\xad\x43\xcf\xab\x7f\x86\xf5\x6f\x8d\x7a\xf7\xcb\xdf\x93\xbf\x4e\x9e\x3e\x9b\xee\x97\xe7\xcf\x41\x7f\x0c\x4e\xb9\x80\xc2\xe7\xaa\x73\x0d\x46\x06\x65\x2e\x0c\x4c\xce\x10\xd8\x45\xbd\x50\x32\xe9\x4b\x30\x11\x88\x3d\x48\xeb\x31\x4c\xb7\xb8\xee\x2d\xb4\x84\xf4\xe7\x66\xaa\x3a\xb4\xfd\xb7\x93\x67\x7c\xff\xfe\x9f\xef\xdf\xc3\x7e\x3c\x3d\xfd\xe7\xe9\x29\xdd\x18\x43\x77\xd2\xdf\x1a\xaf\x8c\x49\xf1\x53\xf4\x97\xe2\x86\x86\x0c\xf1\x21\x75\xec\x9c\x7b\x72\xd1\x5b\x46\x5d\xc5\x61\x4f\xca\x3f\xa2\x41\x64\x69\x99\x1b\x3e\x0d\xf1\x9d\xc8\xdf\x11\x39\xba\x4f\x52\xb8\x20\xd6\x07\xf0\x10\x03\x1c\x59\x94\xd8\xe0\xb4\x3f\x06\x59\x81\x1a\x62\x02\x0d\xdf\xd5\xf1\x08\x99\x74\xe6\x99\x11\xc9\x29\x13\x19\x7f\x22\x07\x63\x2f\xf6\xa6\x98\xd8\xf8\x01\xdb\x3e\x74\x8c\xdc\xb8\x8c\xaf\x91\x88\x94\x74\x60\x04\x47\x38\xe3\xd4\xfa\xbe\x75\x1f\x1a\xa1\xfa\x8e\x81\xf7\x88\xc6\x64\x20\x86\x02\xc0\xcd\x5c\x5d\xec\x55\x26\xde\xe3\xf3\x97\x5c\x17\xc7\x70\x84\xa6\xd8\xbe\x8d\x2c\xec\x42\x89\x31\x66\x89\x45\x32\xf4\xd5\xcb\xd8\xa2\x03\xfb\xc8\x99\xc0\x76\x52\x4c\x07\xd7\x90\xa3\x5c\x5b\xa1\xdf\x4c\xbf\xca\xc4\x71\xe6\x8a\x95\x77\x4c\xec\xaf\x56\xdc\xfd\xa4\x97\x7c\x18\x28\xb2\xb0\x97\xb9\x27\xac\xb1\x9f\xb9\x71\x32\xce\xdb\x02\x74\xf0\xa0\x08\x32\x64\xf9\x3b\xf4\x30\xe9\x74\x8a\x3a\x1d\x30\x98\xa7\x0a\xa6\xa0\xb9\xd2\x6f\x0d\xe7\x1a\xce\x77\x02\xce\x2d\x4a\x04\xa3\x8e\x83\xd8\xf6\x21\x3d\xe9\xcb\x01\xc0\x7a\x91\x60\x35\xb4\x6b\x68\x07\x1a\xda\xf7\x08\xda\xb3\xf3\xf4\x6e\x09\xb2\x9b\x1a\xd9\x35\xb2\x6f\x1f\xd9\x6b\xe1\x64\xf2\xe5\x5f\x27\xcd\xcf\x41\xc9\xcf\x1a\xeb\xab\xc4\xfa\xe6\x68\xa4\xf1\x7e\xf2\x16\x1a\xef\x35\xde\x83\xfd\xc6\xfb\x17\x19\xb8\xcf\xcd\xe4\xcb\xf0\xbe\xa9\xf1\xbe\x02\xbc\x4f\x3d\x76\x47\xe0\x3e\xfd\x94\xbd\xc1\xfb\xb6\xc6\xfb\xea\xf1\xbe\xad\xf1\x5e\x79\x8b\x83\xc3\x7b\xa3\x61\x68\xb8\x3f\x6a\xb8\xbf\x9c\x13\xee\x5b\x1a\xee\x0f\x75\x7a\xbf\xa7\x78\xdf\xd1\x78\x5f\x3d\xde\x77\x34\xde\x2b\x6f\xa1\xf1\xbe\xb0\x93\x1a\xef\xf7\x06\xef\x67\x87\xdd\x5d\x94\x00\x7e\x5b\x03\xbe\x06\xfc\x15\x01\xbf\x67\xfb\x61\x1c\x6f\x2f\x84\x11\xde\xe3\xbe\x5b\xc9\xc6\xfd\x39\x98\xf7\x89\x16\xf5\x89\xa8\xe2\x99\x4b\x13\x8a\x39\x49\xc3\xad\x34\xf0\xd9\x2c\xa1\xc4\x3d\x97\x68\xc2\xee\xf7\x98\x4f\xa2\x3e\xae\x59\x07\x05\xcf\xda\x1b\xe9\xbf\xb2\x04\x7e\x40\x1f\x91\x45\x99\x5d\xa2\x84\xeb\xa5\x95\xf0\x80\xd1\xe3\xe6\xd4\x50\xf8\xb4\xbd\x51\xc4\x1f\x18\x3d\x96\x28\xe0\xf5\x8e\x70\xe5\x8f\x81\x98\x01\x7c\x40\x0c\x0e\x10\x60\x88\x7b\x94\x70\x04\x52\xac\x52\x13\xe3\xe5\x88\x71\x8e\xfa\x29\xb4\x53\xef\x7b\xed\x1a\x31\xb6\x20\xb3\x33\x0d\x07\x45\x37\xd0\xb6\x31\x19\xe4\x2d\x27\xa8\xfc\x48\x7d\x62\x67\x1a\x9f\xf4\xd4\x8a\x8e\x33\x65\x1a\x9c\x9c\x72\xfa\xa1\x73\xd1\x6d\xbf\x6d\xaa\x36\x2a\x6f\xf9\x64\xc1\x70\x6c\xf2\xaf\x29\x0d\xc4\xb5\x43\xe4\x46\xe3\x48\x20\xe6\x51\x07\x0a\x74\x2d\xcd\x55\xb9\x14\x8d\x3c\x4a\x42\xfa\xda\x38\xeb\x14\x8c\x0e\xea\x41\x0b\x8b\x71\x7e\x04\x06\x9c\x3d\xf1\x60\x82\xc7\xe3\x6c\x11\x8e\x5f\x74\x9c\x0a\xac\xc6\xf5\xf3\x51\xb9\x79\xb2\x3f\x44\x50\xb8\xd0\x4b\x53\xd8\x21\xb6\xd1\xff\x22\x46\xaf\x27\xfe\x22\x4d\x0b\x87\x78\x30\x74\xf0\x60\x28\x5e\x47\xfa\x4f\xb1\xeb\x70\xfe\xd0\x99\x3a\x7f\x88\xec\xb6\x94\xb3\x67\x89\x78\x21\xd3\x66\xe8\x01\x31\x8e\xfe\x2c\xeb\xe6\x82\xec\x75\x2a\x8d\x0c\x55\x3a\x37\x80\x9e\xfd\x7c\xf2\x14\x2e\x49\x39\xa8\x14\x32\x63\xd9\x17\xc2\xe5\x7c\xcb\x50\x8d\xa9\x58\x2a\xd7\xa1\x1c\xb4\xfc\xfa\xd3\x14\xec\x9c\x01\x91\x05\x6b\x48\xd1\xeb\xca\xb5\xa4\x14\x68\x82\x50\xb8\x00\x93\xe8\xd2\x39\xce\x82\x14\x21\x50\x50\xfa\x2b\xe6\x82\x0e\x18\x74\x4b\xad\x2b\x86\xcb\xac\xf4\x8d\xd1\xab\x9c\x97\xcc\xbb\xd7\xa4\x9d\x51\x68\x76\x1f\x7c\xb7\x2f\xa7\x68\x29\x41\x44\x95\x9f\xf0\xb7\x2c\x7e\x1a\xe3\xfc\x63\x14\xfc\x53\x29\x40\x31\xf4\x15\x23\x47\x21\x6e\x14\xa2\x46\x99\xf0\x3c\x07\x8b\x89\x61\x15\x3a\xe7\x71\xf8\x52\xd7\x91\x03\x37\xa0\x2f\xa8\x91\xad\x2d\x96\xc7\x38\x27\x0f\x8d\x27\x2b\xe0\x49\x29\x26\x5c\x2c\xba\xfe\xd3\xec\x6c\x0e\x12\x5e\xec\x1d\x24\xcc\x5e\x5e\x58\x10\x17\x0a\xb7\x34\x92\x85\xf5\x97\x27\x84\x0a\x7c\x87\xad\xf0\x80\x76\x6a\x6f\xe3\x38\x81\xe4\x83\x2a\x8f\xc2\xad\x09\x0d\x2b\x40\xc3\x8a\x86\x95\x0d\x4d\x53\x96\x47\x9e\xdc\x6c\x64\x93\xd0\x73\xa1\xa1\x67\x26\xf4\x08\x44\x20\x11\x1a\x74\x80\x71\x1b\x4a\x42\xc3\x8d\x86\x1b\x0d\x37\x7b\x0a\x37\xd9\x89\x4e\xab\xb9\x39\xb4\xb9\xd4\x68\x33\x13\x6d\xc2\x38\xae\x73\x07\x3f\x20\x8d\x38\xc6\x3b\xfc\x80\x08\xe2\x1a\x72\x34\xe4\x68\xc8\xd9\x57\xc8\xc9\xcd\x70\x36\x89\x39\xd3\x13\xa5\x69\xcc\x51\x30\x87\x21\x68\x8f\x35\xe8\x18\x1f\x11\xb4\xb1\x46\x1d\x8d\x3a\x4b\xa3\xce\x06\x52\x6c\xb6\x4a\x4e\xa4\x34\x93\xb8\xc6\xa9\x49\x36\x3f\x61\x1b\xdd\xe3\xaf\x46\xce\xa0\x0e\x30\xc9\xe6\x4c\x61\xb6\x4b\x12\x96\x36\x67\x64\xef\x70\xf0\x80\xbc\xe2\xb7\xc5\xc9\x69\xc3\x58\xe0\x4c\x61\x12\x0a\x9c\xa9\x58\x24\x12\x98\x05\x98\xf7\xa9\x30\xf7\xed\x92\x41\xc2\x85\xae\x41\xc7\x08\x6f\x36\x46\x98\x87\x43\xb2\xf7\x5f\xda\xe7\x3d\xee\x5b\x16\xe2\xd3\x8f\x06\x2d\x1b\x7a\x18\xa5\x4d\xce\x58\x43\x11\x46\x37\xa7\x42\xf4\xa7\xb0\x8f\x77\xbe\x03\x82\x3e\x57\x18\xa4\x9b\x12\xc5\x1d\xc4\x0e\xb2\xd7\x22\x89\x05\x5f\xf8\xad\xec\xc9\xb4\x97\xbd\xde\x91\x58\xcc\x7f\xd2\x3e\x07\x1e\xa3\x81\x7e\x90\x0d\x02\x86\x3a\x8e\x68\xc9\x6a\xb1\x98\xcd\xac\xfb\x38\x92\x58\xcc\xc4\x98\x68\xd9\x49\xa4\x6a\x62\x19\xf5\x59\x24\xb5\x5c\x9f\x45\xba\x02\xed\xcb\x12\x76\x32\x23\xd7\x48\x85\xec\x24\x40\x8c\x5f\x5c\x4f\x8c\x8b\xab\x82\x49\xfa\xb1\x52\x1a\x00\x39\xf8\x16\xbc\xff\xbe\x53\x9b\x35\xa6\x33\x5b\x96\xd9\xc8\x45\x88\xaf\x3e\xf2\xcb\xd7\x21\xd6\x4e\x72\x6e\x26\x20\x8a\x09\x90\x8b\x12\xb2\x43\xf3\xae\x4b\xac\x99\xf1\xac\x22\xa1\xe5\xc8\xcf\xdc\x52\xd8\x51\x2a\xd4\x1f\x03\xd9\x75\x4d\x83\x34\x0d\xd2\x34\x68\x87\x69\x50\x6e\xdb\xa0\x94\x07\xcd\x48\xc2\xa2\x79\xd0\x26\x78\x90\xe6\x3f\x13\xd9\x57\xcb\x7f\x1e\x29\xbb\x5f\x35\x71\xcb\x4a\xc0\x2f\xe1\x3e\xec\xc5\x3e\xa4\x6c\xc9\xe3\xfd\x7f\x69\x1f\x48\xe8\xd5\x90\xaf\x21\x5f\x43\xfe\xee\x42\x7e\x76\xe5\xa3\xf3\xa2\x04\xf1\x67\x64\x61\x99\x03\xf1\xcb\x73\xb4\xcc\x0f\xf9\x1a\xd8\x8f\x14\xd8\x5d\x38\x4a\x63\xfa\x23\xc4\x02\x93\xc1\xd4\x74\x12\x4f\x9b\x9c\xac\x2f\xbe\x50\xb1\x4d\xc8\xfe\x2d\xe8\x28\xe0\xf8\x9b\xf2\xd6\x1a\xa3\x97\xc2\xe8\xb5\xc3\x9f\x46\x69\xb5\x7c\x6f\x50\x3a\x93\x47\x21\x1c\x71\x0e\x14\x88\x58\x63\x80\x39\x10\x43\x04\x6c\x7c\x77\x87\x18\x22\x16\x52\x82\x98\x00\xc7\x41\x41\x50\x4f\x1d\x1b\x71\x21\xe9\x34\x26\xb2\x44\x7a\x18\xf0\x08\x39\x40\x44\xfe\x6d\x9f\x81\x7f\x31\x30\xa4\x8f\xc0\xa1\x64\x10\x5e\xca\x41\xe4\x1e\x01\x16\x1c\x08\x9f\x11\x20\x28\xe8\x23\x80\x46\xc8\xf2\x05\xb2\xcf\x36\xb3\x7e\x50\xca\x26\xa6\xe7\x64\xd0\x6c\x42\xb3\x89\xf5\x46\x80\xa8\x74\x42\x8e\xa2\x5e\x34\x30\x35\x93\x58\x8e\x49\x84\xe2\xc3\x9a\x4d\xac\xcc\x26\x34\x93\xd0\x4c\x62\x73\xf3\xfd\x17\xed\x12\x84\x9e\x9e\x22\x43\x67\x5d\xd5\x78\xaa\xe2\xe9\x30\x8e\x9e\xef\x7d\xf5\x21\x11\xd8\x41\xa7\x8d\xb3\x6e\xa7\x06\x8a\x16\xe4\x73\x29\x21\xa7\x9e\xae\xc8\x9c\x8e\x78\x0e\xd6\x0d\xbf\xdd\xce\x4f\x20\x7e\x0b\x40\xef\x40\xd4\x73\xb0\xe3\x38\xfc\x4f\xda\x8f\xd7\xe0\x03\xe2\x1f\x27\x85\xd4\xb9\x20\x35\x26\x6b\x4c\xde\x69\x4c\xce\x4d\x9b\x4b\x41\x79\x7a\xf2\x10\x0d\xca\x1a\x94\xb7\x00\xca\xe1\x3e\x75\x0d\x6c\x04\x9c\xf7\x6f\x67\xbc\x0c\x94\xf5\x36\x39\xd0\x00\xad\x01\x3a\x2a\xdc\x69\x80\xce\x4e\x9a\x2f\xf2\xa7\xe9\x43\x7c\x9e\x9e\x6e\x65\x43\x61\x71\x7a\x55\x7b\x2f\x00\x7f\x53\xc1\x6f\xf1\x36\xd1\x8e\xc5\xb6\xef\xd7\x22\x77\x74\x76\x5b\x9e\x43\x04\x9e\xcf\x87\x3a\xac\xbd\x3a\xf0\xd6\xfb\xe7\x1a\xc0\x37\x3c\xc3\x2e\x45\xf0\xe9\xc9\x6b\xf6\x73\x63\x3a\x7b\x87\xc6\xf0\xdd\xc5\xf0\x5c\x9c\x1b\x43\x82\x8d\x67\x44\xb9\x69\xac\x4e\x27\x13\x12\xf2\xe4\xbd\xd0\xcb\xde\x1a\x98\x0f\x11\x98\x37\x91\xec\xe8\xb2\x24\x3f\x4f\x6b\x7a\xb2\x23\x86\x3c\x14\x6a\xc5\x46\x9e\x43\xc7\x2e\x22\xe2\x35\x25\x77\x78\x60\xe4\x07\xea\x0d\xb5\x39\x38\xfd\x31\x7b\xe5\xf3\x05\x32\x24\x59\xd0\x1a\xa2\x5b\xec\x22\xea\xe7\x1c\x82\xcc\xf3\x77\x0d\xad\xfb\x01\x8b\x12\x4f\xa5\xdc\xb5\xac\xfe\x23\x18\x27\x39\x31\x5a\x31\xe9\x49\x86\x88\xf1\xc3\xdb\x66\xbb\xdb\x79\xad\x0e\x48\x36\xe8\xc3\xd3\x66\xeb\xa2\x06\xcc\x66\xb7\x06\xda\x8d\x1a\x68\x9c\x5d\x76\x55\x77\x6c\xfc\xd0\xec\x76\xad\xf6\x0b\x23\x67\x1d\x73\x05\x10\xe6\xb3\x7b\x29\x76\x4f\x28\x51\x93\x05\x42\x5f\xe2\xe4\xf7\x14\x8b\x88\xdf\xcf\x6c\x34\xd2\x44\x22\xae\x68\xcc\x41\x17\x62\xd7\xfd\x2e\x18\x55\x39\x70\x55\xaf\x78\x0f\xd9\x3d\x62\xa5\x14\xa2\xd4\x3c\x5b\x19\xf3\xcc\x7e\x1c\x3e\x67\x9d\x66\xae\xed\x80\x4d\x05\x86\x10\x43\x79\x61\x6e\xc2\x56\xc2\x51\x0a\xcf\x6a\x15\x31\x17\x17\x7a\x1e\x26\x83\xdb\xd0\x14\xcd\xa2\xf2\x29\xde\x34\x72\xda\xa1\x43\x06\x82\x02\x81\x46\x19\x67\xf5\x10\xeb\x68\xa6\xcf\x8b\x1b\x63\x90\x0c\x66\x34\xd6\x9c\xe2\x98\x5c\x38\x7a\x03\x05\xbc\x89\xb9\x92\x62\x1c\x79\x9e\x66\x51\x42\x90\x25\x90\x92\xc4\x26\xb8\xe6\x36\x78\x72\x66\xc0\x15\x93\x38\xc7\x1f\x60\xf2\x07\x62\x3c\x8a\x83\x7d\x71\xd6\x3c\x6b\x1b\x0a\x63\xe3\xe2\x0e\x8f\xd2\x6a\x88\x0a\xdf\x52\x12\x67\x7e\x33\x3a\x8d\x9f\x94\x7a\x86\xf2\xf7\xc8\xb2\xd2\x5b\xa4\xcc\xde\x43\x6f\x1a\xf2\x85\x3c\x24\x4d\x4d\x65\x8d\x08\xdf\xd6\xf8\x70\xfe\x2a\x53\x41\x27\x37\x4c\x11\x38\xf7\x20\xbb\x77\x42\x5e\xa8\x58\x7e\x30\xc1\x99\xa4\x31\x95\xce\xa4\x65\xd6\x80\x69\x5e\xd6\x80\x79\xd9\x0d\x9c\x89\x79\x99\x72\x26\x77\xbe\x53\xc4\xdb\x83\x96\xd5\x76\xc2\x66\x9a\x8d\x1a\x30\xbb\xad\x54\x03\x53\x93\x6f\x8a\x60\x56\xf3\x9a\x3a\xbe\x9b\xf9\xee\xdb\x42\x0b\x50\xf7\x7e\x1f\xf5\x18\xf2\x9c\xe8\x53\x29\x49\xd6\xcb\x5e\x98\xf4\xb2\x27\x93\x5e\xc6\x97\xf0\xb2\x9c\x9a\x85\x4d\xbc\xfc\xeb\x24\x07\x17\xf5\xcf\xd1\x57\x41\x2b\xff\x20\xe8\x12\xcc\xd6\x30\x6b\x4d\xa3\x88\xdc\x1a\xad\x06\x37\x0a\x49\x6c\xb6\x26\x66\xb1\x3e\x21\x98\x0c\x80\x47\x6d\x9e\x07\x44\x8e\xc9\xc0\x41\x81\x40\x93\x3a\x39\xf4\x55\xfb\xbf\x54\xed\x5f\xd6\x4e\xb7\x7f\x1a\x10\x64\xe3\x65\xb1\xe9\x37\x8a\xfd\xcc\x4c\xdb\x97\x17\x7e\x88\x1c\x57\x30\x43\x5e\x0b\x8e\xdf\xc4\x0e\xa1\x00\xc8\x17\xc0\xf8\x08\xac\x17\xc5\xf8\x88\x1a\x68\x8c\x5f\x05\xe3\x5f\x54\x85\xf1\xcd\x22\x8c\x4f\x59\x94\x46\xf9\xca\x51\x5e\xa3\xf8\xd1\xa0\xb8\x87\xac\x75\xa0\x37\xa8\x03\xcd\x1f\xaa\xe3\x0f\xbf\x13\xf8\x00\xb1\x13\x58\x83\xe6\x10\x7a\x2d\xe0\x70\x78\x42\x6e\x2f\x67\x69\xa2\xd0\xd2\x8b\x01\x7a\x31\x40\xd3\x88\x35\xd1\x08\xb9\x65\x75\x1a\xff\x4b\x04\xc4\x04\xb1\x9e\x8b\x5c\xca\xc6\x3d\x9f\xc3\x01\xea\xf5\xc7\x02\x95\x02\x78\xf8\x51\x8d\x02\xb8\x2e\xf8\xb6\x46\x74\x3c\x93\x50\x1b\x55\x91\x92\x7a\x87\x60\x5c\xae\x91\xdb\x98\x0b\x86\xfb\xbe\x40\x36\xa0\x04\x0c\x29\x17\x1a\xcf\xab\xc4\xf3\x25\xe7\xfd\x76\xbb\x0d\x5b\x50\xe3\xf9\x6a\x78\x7e\x59\x15\x9e\xb7\xf5\xc4\x7f\xf3\x88\xae\x11\xfb\x60\x10\x5b\xc6\x9e\xf8\xee\xa9\x8d\x1c\x01\xc3\x89\xb8\x47\xed\x5e\x02\xde\x93\x09\x38\x17\x90\x89\xe9\xf9\x14\x17\xc3\xef\xcf\x1d\xf7\xcb\xf3\x28\xfe\xd4\xa3\x76\xe5\x18\x9e\x8d\x68\x29\x09\x61\x99\x3f\x81\x72\x75\x08\xff\x1e\x8e\xe4\x04\x1d\xc4\x62\x05\xa7\x0e\xe4\x02\x74\x80\x8b\x89\x2f\x10\x2f\xd8\x0f\x3f\x34\xa8\xdf\x9f\x50\xc3\xec\xa7\xf7\x66\x07\x52\x94\x9c\xe5\x6b\xcd\x48\x81\xb3\xe0\x59\xbe\xb5\xa7\xc9\xdd\xa1\x03\x80\x2e\x1c\xdd\x20\xf6\x51\xf6\xa7\x55\x0e\x6d\xeb\xf8\x54\x40\x27\x5d\xbe\x48\xa4\xe1\x24\x34\x26\x05\xaa\x61\xe9\x1b\xcc\x90\x15\x25\x8a\x4a\x55\xef\xdc\x99\xc2\x2d\x2d\x0e\xaf\x3b\xc2\x51\xda\x71\x3d\xb5\x4e\xaa\x5c\xf7\x0e\x93\xfb\xa2\x38\x30\x65\x66\x98\x2a\x0f\x24\x2d\x35\x32\x93\xf2\x1d\xe8\xce\x79\x56\xbc\x72\x29\xba\x40\xbc\x85\xc0\xbb\xa2\xa8\xf4\xf6\xc4\x92\x4a\xf2\xc9\x4c\x35\xbd\x9e\x5f\x4d\x07\xb3\xfc\x93\x13\x13\x47\x76\x3d\xbd\x04\x93\x16\xd2\x9b\x1d\x89\x83\xbe\xa1\x36\x90\xf2\x07\xa7\xd2\xbd\xd5\x80\xd4\x6f\x0d\xf8\x24\xf8\xff\x39\x80\xc4\x0e\x99\xa7\x7c\x9b\x64\x95\x29\xc0\xa1\xa4\x39\x7d\xa6\x69\xee\xd0\xe9\xe2\xcf\xb1\x6e\x36\x58\x39\xfd\xe0\x3d\x8b\xa8\x56\xd9\xa2\x3e\xeb\x54\x38\x01\x79\xb1\xe8\x04\xa4\x9b\x5f\x4e\x0b\x27\x20\xd5\x66\xf8\xd2\x13\x90\x5d\x3d\xe2\x74\x38\x13\x8f\xad\x2d\x4c\x6d\x6c\x5d\x4a\x9e\xb4\xf2\xa8\xbd\x0f\xe7\xac\x6e\xe6\x5d\xb4\xd2\x87\xaf\xf4\xe1\xab\xca\xa9\xc2\x21\x1f\xbe\xea\x5e\x94\x40\x76\x92\xff\x6b\xe5\xc3\x57\xaf\x6f\x7e\x07\xbf\x07\xd3\xb0\x15\x4f\x60\xed\x0f\x73\x5a\x78\xe9\xb6\x5b\xf2\xf5\xb3\xd6\x8c\x34\x2f\xc7\x91\x86\xed\xb0\x57\x61\x0d\x05\x3f\x76\x92\x0b\x11\x6a\xa3\xde\x84\xd5\xa4\xf9\xd0\x55\xc2\x8c\x2c\xcf\x8f\x56\x5b\xe2\xbc\x6e\xd2\x58\xae\xb8\xef\xf6\x18\x14\x48\x21\x49\x7f\xad\xba\xfe\x52\x0d\x41\x9a\xe3\x28\x7a\x31\x41\xaa\x6c\xad\x76\x9b\xa4\x6a\xe2\x96\xf5\x0a\xcc\x8e\xf3\x27\xbd\xd0\xa2\x94\xef\x14\x7d\x32\x1b\x9d\x12\xe0\xee\x56\xcb\x9f\x7e\xf3\xa9\x80\x1b\xe5\x4f\x96\x8c\xef\xc8\xf4\x7b\x03\xa4\xea\x4e\x89\x2b\x30\x1b\x6a\x60\x41\x75\x74\xcb\x6c\x94\x7c\x2d\xa6\xdd\xd0\x7c\x6b\x2d\x7c\x0b\x0e\x50\xa4\xd4\x14\x18\xed\x2c\x0f\xb3\x18\x95\x76\x99\x4e\xbb\x33\x95\x9d\x0d\xe9\xe3\xaf\x08\xda\xb2\x0b\xe9\xdb\x42\xa0\x54\xec\xc8\xa2\x4e\xc6\xdb\xd8\x88\x5b\xa5\xda\xac\x90\xf8\x71\x31\x76\xa6\xa1\x9a\xf4\x11\x81\x30\x6e\xd3\xa8\x1a\x0e\x66\x94\xb0\xa3\x3f\xff\xfc\xf3\xcf\xfa\xfb\xf7\xf5\x37\x6f\xc0\xaf\xbf\x5e\xb9\xee\x15\xcf\xb0\x2d\x0f\x0a\x81\x18\x29\x6e\x2b\xf6\x55\x43\x6c\xdb\x88\xcc\xde\xea\x9b\x74\x2b\x4f\x5a\x62\x81\x52\x16\xd9\x65\x0e\x88\x92\x38\xdc\x2f\xab\xbc\x90\xb2\xe5\x92\x21\x8e\x21\x0f\xcc\x0c\xd1\xa8\xe2\x76\x42\xa9\x8c\x37\x0c\x3b\x0e\xb0\xe9\x23\x31\x72\x97\xfd\xce\x0a\x3e\xe5\xab\x88\x50\x06\xda\x82\x1f\xb2\x41\x85\xc5\x6c\x31\x25\x62\xe2\xbb\x7d\xc4\xd2\xf7\xf9\x04\x2b\xa4\x60\x31\xe9\x7f\x44\x5f\x7d\x94\xdb\x92\x3c\x16\x05\x5c\xef\x8e\x02\xc0\x4f\xc7\xa9\x82\xd7\xd5\xaa\x20\x82\x20\xf9\x73\x31\x45\xbc\xc3\x2e\x3e\xd6\x71\xf0\x66\xfb\xe3\x20\x14\xff\xb1\x8e\x82\x5f\x76\x61\x14\xdc\x50\x7b\xf7\xa4\x9f\x66\xd0\x4b\x09\xff\xdc\x3e\xff\xfe\x1d\x9c\x7d\x88\xd7\xa8\xc0\xd3\x53\xae\xa0\xde\xe2\x16\x74\x50\xfd\xde\xef\x23\x46\x90\x40\xbc\x6e\x51\xd7\xf3\x05\xaa\x33\x14\xce\x72\x78\xdd\xa3\xf6\xff\x7f\x80\xac\x9e\x2c\x7d\x25\x0b\x5f\x7f\x0b\x2a\x3c\x6a\xbf\xfc\xb1\xd7\xb3\x50\xf6\x20\x81\xa2\x71\x2f\x2b\xe5\x4d\x8f\xb6\x1d\xd2\xb1\x22\x96\xf3\xb3\x9f\xcf\x17\x97\x0b\x17\x0c\x93\xc1\x7c\x72\x89\xfe\x52\x16\xd5\x0e\x79\xd9\x54\x66\x9a\x4d\x55\x62\xc2\x05\x2c\xc8\x27\xbb\xf8\x5a\x6a\xe9\xfe\x72\xaa\x7c\x85\x60\xd6\xf4\x26\x7d\x3c\x00\x7b\x2c\xe2\x4a\x52\xca\x16\x65\xa9\x20\xc0\x83\x13\xe7\x75\x15\xe2\xdc\x51\xb3\x05\xe7\x40\x2b\x3b\x1d\x20\xbb\xe6\xb1\xe3\x48\x7e\x75\x1c\xc2\x7c\x73\xdc\x23\xe7\x98\x54\xfd\xcb\x66\x36\xe5\x66\xef\xbd\xc9\x25\xfd\xad\xc4\x2e\x31\x48\x78\xa0\x84\xbc\x0a\x26\x34\x29\x53\xac\xf7\xe5\x8e\x65\x5f\xee\x19\xd8\xd6\x56\x9a\xd9\xca\xa9\x3a\xdc\x93\x49\xf6\x2a\x56\xde\x4a\x7b\x2f\x0f\x85\xe8\x68\xa4\x59\xaa\x28\x39\x49\xda\x4e\x62\x83\x8f\x78\x7b\x6c\xd7\x03\x8f\x76\x32\x88\x68\xce\x83\x59\xcb\xd3\x8d\x1a\x98\x3c\xe2\xff\xbd\x3c\xd1\x81\x42\xd5\x05\x0a\xa9\x4e\x53\xc7\x0a\xad\xc6\x49\xa4\xbd\x6b\x4e\x72\x94\xb1\x42\xcd\x92\xe3\x51\xed\x56\xe5\x04\x47\x87\x0b\x55\xca\x87\x9a\x25\xdf\xf0\x6a\xb7\x35\x1f\xda\xdf\xc0\xa0\x6d\x05\xf9\x14\x26\x35\xd3\x31\x3e\x93\x6e\x15\xf2\x8d\x58\xa4\x5b\xdf\x5e\xdc\xc3\x30\x1f\x1b\x59\x21\xef\x58\x58\x07\x3a\xd8\x67\xa7\xd4\x70\xac\xc1\x0e\x3b\x11\xf2\x13\xe9\x42\x47\xfd\x54\xa6\x87\xe5\x07\x84\x8e\xfd\xa9\x50\x0d\x3a\xf6\x47\xc7\xfe\xe8\xd8\x9f\x4a\x63\x7f\x76\x71\xb5\xf3\xa0\xe3\x7b\x22\x31\x57\x27\xe0\x1d\x95\x68\x25\x21\x3e\x5b\xb4\xce\x45\xc2\x78\x8e\x45\xa7\x9b\x8a\xe4\x39\x16\x79\x56\x12\xcc\xb3\xdb\x63\xe4\xc8\x34\xba\x13\x31\x3b\xea\xd2\xba\x0e\xdb\xd1\x61\x3b\x3b\xb5\x45\xf6\x0c\x6c\x6d\x57\xab\x9b\x53\x75\xb8\x37\xa2\xac\xcc\xaf\xba\xab\xf5\x01\x89\x47\xca\xee\x75\xdc\xce\x0c\x5d\xb4\x4a\x3e\xa5\xdf\xae\x36\x01\xe3\x21\xed\x53\x55\x15\xaf\xb3\x42\xd6\xc4\x9d\x8c\xd7\xc1\x0c\x0a\xa4\xb0\x20\x12\x0e\xc1\x1e\x43\x16\xc2\x0f\x11\x11\xca\x65\x3f\x5c\x81\x79\x54\x9e\xfe\x70\xe9\xa0\x9d\x9d\x4b\x7f\xf8\x31\x94\x39\xb8\x86\xc4\x0e\x2d\x7d\x25\xfa\x71\xac\x51\x38\xca\xc2\x52\x0e\x5d\x13\x03\xbb\xf6\xd6\x1a\x9f\xb3\xe7\xb9\x10\x75\xda\xe4\x75\xc0\x76\x49\x12\xc6\xf6\x85\x86\x6d\x0d\xdb\x95\xc0\xb6\x9c\x3c\xba\x58\x68\xdc\xde\x20\x6e\xdf\x46\x42\xd7\xc0\x5d\xd5\xda\x80\x06\xe7\x9d\x04\xe7\x8d\x2c\x34\xb4\x4b\x52\xed\xa9\x1f\x9d\x5c\x79\xa5\xe1\x95\x25\x02\xa6\xfd\x11\x59\x94\xd9\x9b\x0d\xa0\x9d\xf2\x0d\xda\x6d\xd2\x99\xcb\xc5\x15\x55\x96\x5c\x4f\x27\x33\x5e\x03\x9d\x99\xf9\x9d\xf3\x43\xa6\x3b\xd2\xaf\xca\x2f\x60\x41\x0b\x9d\x32\x88\x1d\xde\x8b\xbe\x33\x8b\x29\xe9\x79\x94\x3a\xca\xef\x8a\xf6\x48\x6a\x27\x49\x93\x27\x35\x70\xf2\xa3\x19\xfc\xcb\x05\x14\x28\xf8\xe3\xf4\xec\xe7\xe7\x27\x11\x23\x4a\x2e\x5c\x9e\x18\xc9\xc8\x76\xd7\xa8\x81\xa9\xac\x69\x8e\x8f\x3d\x24\x9d\xd9\x07\xf2\xf4\x31\x50\x26\xb8\xa1\x36\x78\x9d\x28\x70\x2b\xfb\x2e\x7b\xcf\x9f\xe6\xf8\x58\x94\x43\x2d\x98\xdd\xd6\x8b\x49\x4b\x66\x57\xee\x18\x99\xd5\xb6\x3e\x01\xb1\x3f\xab\x1e\x59\x9a\x60\x36\x67\xd1\x84\x4e\xc9\x21\xe3\xce\xd6\x0f\x19\x67\x8b\x0f\x82\x27\x1c\x32\x0f\x00\x25\xd0\xcf\xf1\xb7\xaa\xd2\xa9\x2c\x01\xdf\x71\x50\x44\x3e\x24\x38\x0b\xed\x2b\x20\xfb\x5e\xa0\x78\x82\xe0\xe0\x86\x52\x07\xc8\x13\x5f\x1a\xca\x57\x83\x72\x73\x4b\x50\x3e\x1f\x46\x6a\x2c\x3f\x60\x2c\x4f\x15\x4c\x07\x73\x53\x83\xb9\x06\xf3\x4a\xc0\xfc\x11\x62\x81\xc9\x60\x7b\x78\x7e\xdc\x98\xfd\xef\x50\xfc\x1a\xb6\xf5\x0c\xfc\x80\x50\xbb\x93\x86\xec\x67\x51\x9b\xc1\xa0\x0c\xc6\x9b\x1c\xec\x8d\xd0\x13\x18\xdc\x1a\x22\x17\x26\x4b\xad\xe6\x65\x58\x2c\xc6\xe1\xb8\xb1\x21\xbb\x0f\xaf\x14\x70\x90\x18\x83\x11\x1e\x16\x8b\x84\x66\xf0\x31\x17\xc8\x35\x26\x4f\x12\xc8\xf5\x1c\x28\x87\x56\xdc\x7d\xc3\xc1\x5c\x28\xc6\xa4\xc2\x33\x96\x76\xac\x8e\x23\x4c\x2c\xc7\xb7\xd1\x2b\xa7\x08\xd5\x8a\x95\x63\xb8\xbe\x23\x70\xc1\xe5\xd1\x70\x30\x0a\xf8\x43\x0a\xa6\xd4\x03\x56\xc6\x57\x1f\xb1\xb1\x3c\xac\xc6\xa8\x8b\xc4\x10\xf9\xaa\xeb\x54\x44\x69\xa6\x4a\x07\x68\x94\x31\x6d\x83\xdf\x63\xef\x77\xe6\x7c\x1a\x13\xab\x08\xb9\x23\x37\xa0\x74\x2e\x3b\x0e\x53\xea\x77\xe4\x71\xc9\xfc\xcb\x27\xf4\x22\x65\xe4\xb1\xd2\x32\x67\xc7\xd0\x28\x5a\x41\x4d\x1f\x01\x4c\x8f\x81\x87\xe8\x49\xf9\xcb\x8a\xc6\xd6\xd2\x5a\x34\x26\x70\x67\x2c\xa0\xcc\xc2\x9b\x14\x5d\x2a\x2f\xa2\x0a\x44\xd2\x03\x07\x59\xa2\xc0\x9f\xcf\x2f\x9a\xf9\x84\x93\x0c\x69\x65\x50\xab\xa6\x35\xe5\x19\x73\x5a\x8d\xe5\x73\x41\xdd\x39\x2d\x26\x65\x95\xb3\xb9\xb5\xf4\xf0\x77\x98\xe0\xf8\x53\x42\xe1\xbe\x44\x08\x18\xc9\x41\x0e\x4c\xee\xe8\xf4\x4f\x45\x87\xce\xa1\x7e\xf6\xf3\xc9\x53\x0d\x64\xc2\x29\x66\x5a\x4d\x06\xae\x26\x46\x53\xba\xed\x39\x8f\x23\x98\x72\xef\x2c\x77\xb0\x16\x21\xcc\xf0\x26\xe7\xa7\x01\xc9\x8b\xb8\xde\x97\xbf\x3f\xaf\x7f\x0e\x08\x5f\xea\xb0\xe8\x0c\x7b\x89\x98\x8a\xda\xba\x80\x03\x69\x18\xfc\xb7\xf8\xd5\x8c\x74\x6d\x4e\x06\x41\x59\xf1\xc5\x91\x35\x86\x42\x52\x2a\x7c\x8e\x6e\xc3\x86\x52\xf3\x0f\xf9\x7f\x40\x37\x9e\x42\xb0\xc0\x52\x2d\x11\x4c\xdc\x85\xbc\xcf\x20\xf4\xb1\x6e\xc6\xc4\xc8\x10\x34\x2a\x33\x52\xb7\x79\xd8\xba\x97\x53\x80\xe8\xe6\x48\x90\xbd\x98\xbb\xaa\xae\xc0\xe8\x28\x3b\x2d\x31\xfe\xc9\x1f\x2d\xf5\x87\x42\x83\x8d\x8e\xf2\xb7\xa9\xfe\x68\x35\xd4\x1a\x85\xbe\x35\x95\xbf\x4d\x3b\x1c\x98\x5f\xe2\x77\x08\x58\x78\xde\x45\x95\x3f\x45\x6d\xf8\x85\xda\xb0\xfa\x94\x66\x5b\xfd\x91\x1c\xac\x36\x2e\x6c\xb5\xbf\x71\x5f\x52\xe2\xfb\x46\x49\xe2\x18\x12\xae\x9c\xf5\x4b\xe0\x1c\x84\x70\x0f\xce\xc1\xa7\x10\xe9\xe5\x0d\x0f\x09\x6b\x78\xf6\xf4\xec\xff\x02\x00\x00\xff\xff\xd4\xcf\x92\x4b\x64\x4d\x01\x00")
 
 func monitoringSystemGrafanaDashboard1JsonTplBytes() ([]byte, error) {
@@ -281,6 +323,8 @@ var _bindata = map[string]func() (*asset, error){
 	"monitoring/backend-grafana-dashboard-1.json.tpl":                           monitoringBackendGrafanaDashboard1JsonTpl,
 	"monitoring/kubernetes-resources-by-namespace-grafana-dashboard-1.json.tpl": monitoringKubernetesResourcesByNamespaceGrafanaDashboard1JsonTpl,
 	"monitoring/kubernetes-resources-by-pod-grafana-dashboard-1.json.tpl":       monitoringKubernetesResourcesByPodGrafanaDashboard1JsonTpl,
+	"monitoring/product-grafana-dashboard-1.json.tpl":                           monitoringProductGrafanaDashboard1JsonTpl,
+	"monitoring/slo-grafana-dashboard-1.json.tpl":                               monitoringSloGrafanaDashboard1JsonTpl,
 	"monitoring/system-grafana-dashboard-1.json.tpl":                            monitoringSystemGrafanaDashboard1JsonTpl,
 	"monitoring/zync-grafana-dashboard-1.json.tpl":                              monitoringZyncGrafanaDashboard1JsonTpl,
 }
@@ -332,6 +376,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"backend-grafana-dashboard-1.json.tpl":                           &bintree{monitoringBackendGrafanaDashboard1JsonTpl, map[string]*bintree{}},
 		"kubernetes-resources-by-namespace-grafana-dashboard-1.json.tpl": &bintree{monitoringKubernetesResourcesByNamespaceGrafanaDashboard1JsonTpl, map[string]*bintree{}},
 		"kubernetes-resources-by-pod-grafana-dashboard-1.json.tpl":       &bintree{monitoringKubernetesResourcesByPodGrafanaDashboard1JsonTpl, map[string]*bintree{}},
+		"product-grafana-dashboard-1.json.tpl":                           &bintree{monitoringProductGrafanaDashboard1JsonTpl, map[string]*bintree{}},
+		"slo-grafana-dashboard-1.json.tpl":                               &bintree{monitoringSloGrafanaDashboard1JsonTpl, map[string]*bintree{}},
 		"system-grafana-dashboard-1.json.tpl":                            &bintree{monitoringSystemGrafanaDashboard1JsonTpl, map[string]*bintree{}},
 		"zync-grafana-dashboard-1.json.tpl":                              &bintree{monitoringZyncGrafanaDashboard1JsonTpl, map[string]*bintree{}},
 	}},
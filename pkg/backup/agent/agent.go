@@ -0,0 +1,181 @@
+// Package agent implements the backup-agent subcommand of the operator
+// binary. It is run by the APIManagerBackup and APIManagerRestore Jobs, and
+// by the upgrade pre-flight Jobs, using the operator image. Progress is
+// logged as JSON lines
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+// CommandName is the name of the operator binary subcommand running the agent
+const CommandName = "backup-agent"
+
+const usage = `Usage: backup-agent <operation> [flags]
+
+Operations:
+  encrypt                Encrypt the backup data of a directory into another directory
+  decrypt                Decrypt the backup data of a directory into another directory
+  backup-objects         Back up secrets and configmaps
+  backup-apimanager      Back up an APIManager custom resource
+  backup-capabilities    Back up the capabilities custom resources and the secrets they reference
+  write-manifest         Write the manifest of the backup data
+  verify-manifest        Verify the backup data against its manifest
+  restore-objects        Restore secrets and configmaps
+  share-apimanager       Store the backed up APIManager custom resource in a secret
+  restore-capabilities   Restore the capabilities custom resources and the secrets they reference
+  measure-free-space     Measure the free space of a mounted volume
+`
+
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// agent runs a backup agent operation. The Kubernetes clients are only
+// created by the operations using them
+type agent struct {
+	logger        logr.Logger
+	clientFactory func() (*clients, error)
+}
+
+// Run runs the agent operation given in args
+func Run(args []string) error {
+	return run(args, zap.New(zap.WriteTo(os.Stdout)), newClients)
+}
+
+func run(args []string, logger logr.Logger, clientFactory func() (*clients, error)) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	a := &agent{
+		logger:        logger.WithValues("operation", args[0]),
+		clientFactory: clientFactory,
+	}
+
+	var operation func([]string) error
+	switch args[0] {
+	case "encrypt":
+		operation = a.runEncrypt
+	case "decrypt":
+		operation = a.runDecrypt
+	case "backup-objects":
+		operation = a.runBackupObjects
+	case "backup-apimanager":
+		operation = a.runBackupAPIManager
+	case "backup-capabilities":
+		operation = a.runBackupCapabilities
+	case "write-manifest":
+		operation = a.runWriteManifest
+	case "verify-manifest":
+		operation = a.runVerifyManifest
+	case "restore-objects":
+		operation = a.runRestoreObjects
+	case "share-apimanager":
+		operation = a.runShareAPIManager
+	case "restore-capabilities":
+		operation = a.runRestoreCapabilities
+	case "measure-free-space":
+		operation = a.runMeasureFreeSpace
+	default:
+		return fmt.Errorf("Unknown operation '%s'\n%s", args[0], usage)
+	}
+
+	err := operation(args[1:])
+	if err != nil {
+		a.logger.Error(err, "Operation failed")
+	}
+	return err
+}
+
+func newFlagSet(operation string) *flag.FlagSet {
+	return flag.NewFlagSet(operation, flag.ContinueOnError)
+}
+
+func requiredFlags(flags *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if flags.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("Flags --%s are required", strings.Join(names, ", --"))
+		}
+	}
+	return nil
+}
+
+func (a *agent) runEncrypt(args []string) error {
+	flags := newFlagSet("encrypt")
+	keyFile := flags.String("key-file", "", "File containing the base64 encoded encryption key")
+	source := flags.String("source", "", "Directory containing the backup data to encrypt")
+	destination := flags.String("destination", "", "Directory where the encrypted backup data is written")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "key-file", "source", "destination"); err != nil {
+		return err
+	}
+
+	key, err := readEncryptionKey(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	err = backup.EncryptDirectory(key, *source, *destination)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Backup data encrypted", "source", *source, "destination", *destination)
+	return nil
+}
+
+func (a *agent) runDecrypt(args []string) error {
+	var subdirs stringSliceFlag
+	flags := newFlagSet("decrypt")
+	keyFile := flags.String("key-file", "", "File containing the base64 encoded encryption key")
+	source := flags.String("source", "", "Directory containing the encrypted backup data")
+	destination := flags.String("destination", "", "Directory where the decrypted backup data is written")
+	flags.Var(&subdirs, "subdir", "Subdirectory of the backup data to decrypt. Can be repeated. All the backup data is decrypted when not set")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "key-file", "source", "destination"); err != nil {
+		return err
+	}
+
+	key, err := readEncryptionKey(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	err = backup.DecryptDirectory(key, *source, *destination, subdirs...)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Backup data decrypted", "source", *source, "destination", *destination)
+	return nil
+}
+
+func readEncryptionKey(keyFile string) ([]byte, error) {
+	value, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return backup.ParseEncryptionKey(value)
+}
@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestRunEncryptDecrypt(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(tmpDir, "key")
+	if err := ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plainDir := filepath.Join(tmpDir, "plain")
+	if err := os.MkdirAll(filepath.Join(plainDir, "secrets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(plainDir, "secrets", "system-seed.json"), []byte("seed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	encryptedDir := filepath.Join(tmpDir, "encrypted")
+	err = Run([]string{"encrypt", "--key-file", keyFile, "--source", plainDir, "--destination", encryptedDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decryptedDir := filepath.Join(tmpDir, "decrypted")
+	err = Run([]string{"decrypt", "--key-file", keyFile, "--source", encryptedDir, "--destination", decryptedDir, "--subdir", "secrets"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(decryptedDir, "secrets", "system-seed.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "seed" {
+		t.Errorf("decrypted content differs: got: %s", content)
+	}
+}
+
+func TestRunInvalidArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+	}{
+		{"no operation", []string{}},
+		{"unknown operation", []string{"compress"}},
+		{"missing flags", []string{"encrypt", "--source", "/backup"}},
+		{"missing key file", []string{"decrypt", "--key-file", "/nonexistent", "--source", "/a", "--destination", "/b"}},
+		{"missing path", []string{"measure-free-space", "--path", "/nonexistent"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if err := Run(tc.args); err == nil {
+				subT.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestRunMeasureFreeSpace(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	terminationLog := filepath.Join(tmpDir, "termination-log")
+	err = Run([]string{"measure-free-space", "--path", tmpDir, "--termination-log", terminationLog})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := ioutil.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	availableBytes, err := strconv.ParseUint(string(message), 10, 64)
+	if err != nil {
+		t.Fatalf("termination message is not a number of bytes: %s", message)
+	}
+	if availableBytes == 0 {
+		t.Error("no free space measured")
+	}
+}
@@ -0,0 +1,239 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+// The status of the custom resources is stored in the
+// BackupStatusAnnotation. Secrets referenced from the same namespace, and the
+// default provider account secret, are backed up along the custom resources
+func (a *agent) runBackupCapabilities(args []string) error {
+	flags := newFlagSet("backup-capabilities")
+	namespace := flags.String("namespace", "", "Namespace of the custom resources")
+	destination := flags.String("destination", "", "Directory where the custom resources are written")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "destination"); err != nil {
+		return err
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	capabilitiesDir := filepath.Join(*destination, backup.CapabilitiesBackupSubdir)
+	secretNames := map[string]bool{backup.CapabilitiesDefaultProviderAccountSecretName: true}
+
+	for _, resource := range backup.CapabilitiesResources {
+		list, err := c.dynamic.Resource(resource).Namespace(*namespace).List(context.TODO(), metav1.ListOptions{})
+		if errors.IsNotFound(err) {
+			a.logger.Info("Resource not available. Skipping backup of the resource", "resource", resource.Resource)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		resourceDir := filepath.Join(capabilitiesDir, backup.CapabilitiesResourceBackupSubdir(resource))
+		err = os.MkdirAll(resourceDir, 0755)
+		if err != nil {
+			return err
+		}
+
+		for idx := range list.Items {
+			obj := &list.Items[idx]
+			for _, name := range referencedSecretNames(obj, *namespace) {
+				secretNames[name] = true
+			}
+
+			if status, ok := obj.Object["status"]; ok {
+				serializedStatus, err := json.Marshal(status)
+				if err != nil {
+					return err
+				}
+				annotations := obj.GetAnnotations()
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+				annotations[appsv1alpha1.BackupStatusAnnotation] = string(serializedStatus)
+				obj.SetAnnotations(annotations)
+			}
+
+			err = writeObject(filepath.Join(resourceDir, obj.GetName()+".json"), obj)
+			if err != nil {
+				return err
+			}
+		}
+		a.logger.Info("Resource backed up", "resource", resource.Resource, "count", len(list.Items))
+	}
+
+	for _, name := range sortedKeys(secretNames) {
+		secret, err := c.kubernetes.CoreV1().Secrets(*namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			a.logger.Info("Referenced secret not found. Skipping backup of the secret", "secret", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+		err = writeObject(filepath.Join(capabilitiesDir, backup.CapabilitiesSecretsBackupSubdir, name+".json"), secret)
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Referenced secret backed up", "secret", name)
+	}
+
+	return nil
+}
+
+// Custom resources and secrets that already exist are kept. Secret
+// references to the namespace of the backup are moved to the namespace of
+// the restore
+func (a *agent) runRestoreCapabilities(args []string) error {
+	flags := newFlagSet("restore-capabilities")
+	namespace := flags.String("namespace", "", "Namespace where the custom resources are restored")
+	source := flags.String("source", "", "Directory containing the backed up custom resources")
+	sourceNamespace := flags.String("source-namespace", "", "Namespace of the backed up custom resources")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "source"); err != nil {
+		return err
+	}
+
+	capabilitiesDir := filepath.Join(*source, backup.CapabilitiesBackupSubdir)
+	if _, err := os.Stat(capabilitiesDir); os.IsNotExist(err) {
+		a.logger.Info("Capabilities custom resources not found in the backup data. Skipping restore of the capabilities custom resources")
+		return nil
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	secretFiles, err := jsonFiles(filepath.Join(capabilitiesDir, backup.CapabilitiesSecretsBackupSubdir))
+	if err != nil {
+		return err
+	}
+	for _, path := range secretFiles {
+		err := a.restoreSecret(c, *namespace, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range backup.CapabilitiesResources {
+		files, err := jsonFiles(filepath.Join(capabilitiesDir, backup.CapabilitiesResourceBackupSubdir(resource)))
+		if err != nil {
+			return err
+		}
+
+		for _, path := range files {
+			obj := &unstructured.Unstructured{}
+			err := readObject(path, &obj.Object)
+			if err != nil {
+				return err
+			}
+			obj.SetNamespace(*namespace)
+			if *sourceNamespace != "" {
+				moveSecretReferences(obj, *sourceNamespace, *namespace)
+			}
+
+			// Custom resources are restored with the version they were
+			// backed up with
+			gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+			if err != nil {
+				return err
+			}
+			_, err = c.dynamic.Resource(gv.WithResource(resource.Resource)).Namespace(*namespace).Create(context.TODO(), obj, metav1.CreateOptions{})
+			if errors.IsAlreadyExists(err) {
+				a.logger.Info("Custom resource already exists. Skipping restore of the custom resource", "resource", resource.Resource, "name", obj.GetName())
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			a.logger.Info("Custom resource restored", "resource", resource.Resource, "name", obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+// referencedSecretNames returns the names of the secrets referenced from the
+// given namespace by the spec of the custom resource
+func referencedSecretNames(obj *unstructured.Unstructured, namespace string) []string {
+	names := []string{}
+	for _, field := range backup.CapabilitiesSecretReferenceFields {
+		ref, found, err := unstructured.NestedStringMap(obj.Object, "spec", field)
+		if err != nil || !found || ref["name"] == "" {
+			continue
+		}
+		if refNamespace, ok := ref["namespace"]; ok && refNamespace != namespace {
+			continue
+		}
+		names = append(names, ref["name"])
+	}
+	return names
+}
+
+// moveSecretReferences replaces the namespace of the secret references of
+// the spec of the custom resource from sourceNamespace to namespace
+func moveSecretReferences(obj *unstructured.Unstructured, sourceNamespace, namespace string) {
+	for _, field := range backup.CapabilitiesSecretReferenceFields {
+		refNamespace, found, err := unstructured.NestedString(obj.Object, "spec", field, "namespace")
+		if err != nil || !found || refNamespace != sourceNamespace {
+			continue
+		}
+		// The reference exists so it cannot fail
+		_ = unstructured.SetNestedField(obj.Object, namespace, "spec", field, "namespace")
+	}
+}
+
+// jsonFiles returns the sorted paths of the JSON files of the directory. No
+// error is returned when the directory does not exist
+func jsonFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
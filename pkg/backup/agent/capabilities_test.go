@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestRunBackupAndRestoreCapabilities(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	product := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "capabilities.3scale.net/v1beta1",
+		"kind":       "Product",
+		"metadata": map[string]interface{}{
+			"name":      "products",
+			"namespace": "source",
+		},
+		"spec": map[string]interface{}{
+			"name": "Products",
+			"providerAccountRef": map[string]interface{}{
+				"name": "tenant-account",
+			},
+		},
+		"status": map[string]interface{}{
+			"productId": int64(3),
+		},
+	}}
+	developerAccount := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "capabilities.3scale.net/v1beta1",
+		"kind":       "DeveloperAccount",
+		"metadata": map[string]interface{}{
+			"name":      "developer",
+			"namespace": "source",
+		},
+		"spec": map[string]interface{}{
+			"orgName": "Developer",
+			"providerAccountRef": map[string]interface{}{
+				"name": "other-account",
+			},
+		},
+	}}
+	developerUser := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "capabilities.3scale.net/v1beta1",
+		"kind":       "DeveloperUser",
+		"metadata": map[string]interface{}{
+			"name":      "user",
+			"namespace": "source",
+		},
+		"spec": map[string]interface{}{
+			"username": "user",
+			"passwordCredentialsRef": map[string]interface{}{
+				"name":      "user-password",
+				"namespace": "source",
+			},
+		},
+	}}
+	secrets := []runtime.Object{
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tenant-account", Namespace: "source"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-password", Namespace: "source"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "threescale-provider-account", Namespace: "source"}},
+	}
+	source := newFakeClients(secrets, product, developerAccount, developerUser)
+
+	err := runWithClients([]string{"backup-capabilities", "--namespace", "source", "--destination", dir}, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFiles := []string{
+		"capabilities/products.capabilities.3scale.net/products.json",
+		"capabilities/developeraccounts.capabilities.3scale.net/developer.json",
+		"capabilities/developerusers.capabilities.3scale.net/user.json",
+		"capabilities/secrets/tenant-account.json",
+		"capabilities/secrets/user-password.json",
+		"capabilities/secrets/threescale-provider-account.json",
+	}
+	for _, path := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("file not backed up: %v", err)
+		}
+	}
+	// Referenced secrets not found are skipped
+	if _, err := os.Stat(filepath.Join(dir, "capabilities/secrets/other-account.json")); !os.IsNotExist(err) {
+		t.Errorf("unexpected backed up secret: %v", err)
+	}
+
+	target := newFakeClients(nil)
+
+	err = runWithClients([]string{"restore-capabilities", "--namespace", "target", "--source", dir,
+		"--source-namespace", "source"}, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"tenant-account", "user-password", "threescale-provider-account"} {
+		if _, err := target.kubernetes.CoreV1().Secrets("target").Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+			t.Errorf("secret not restored: %v", err)
+		}
+	}
+
+	restoredProduct, err := target.dynamic.Resource(capabilitiesv1beta1.GroupVersion.WithResource("products")).
+		Namespace("target").Get(context.TODO(), "products", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restoredProduct.GetAnnotations()[appsv1alpha1.BackupStatusAnnotation] != `{"productId":3}` {
+		t.Errorf("unexpected backup status annotation: %v", restoredProduct.GetAnnotations())
+	}
+
+	restoredUser, err := target.dynamic.Resource(capabilitiesv1beta1.GroupVersion.WithResource("developerusers")).
+		Namespace("target").Get(context.TODO(), "user", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refNamespace, _, _ := unstructured.NestedString(restoredUser.Object, "spec", "passwordCredentialsRef", "namespace")
+	if refNamespace != "target" {
+		t.Errorf("secret reference not moved to the target namespace: %s", refNamespace)
+	}
+}
+
+func TestRunRestoreCapabilitiesWithoutBackup(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	err := runWithClients([]string{"restore-capabilities", "--namespace", "target", "--source", dir}, newFakeClients(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// clients are the Kubernetes clients used by the agent. Custom resources are
+// managed with the dynamic client
+type clients struct {
+	kubernetes kubernetes.Interface
+	dynamic    dynamic.Interface
+}
+
+// newClients creates the clients from the in-cluster configuration of the
+// Job pods, or from the kubeconfig when the agent is run out of a cluster
+func newClients() (*clients, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	kubernetesClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients{kubernetes: kubernetesClient, dynamic: dynamicClient}, nil
+}
+
+func (a *agent) clients() (*clients, error) {
+	return a.clientFactory()
+}
+
+// Attributes of the backed up objects that are set by the cluster and
+// cannot be set when the objects are restored
+var objectMetadataAttrsToDelete = []string{
+	"ownerReferences", "selfLink", "uid", "resourceVersion", "creationTimestamp",
+	"namespace", "clusterName", "generation", "managedFields",
+}
+
+// cleanupObject removes the status and the metadata attributes set by the
+// cluster from the unstructured object
+func cleanupObject(obj map[string]interface{}) {
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, attr := range objectMetadataAttrsToDelete {
+			delete(metadata, attr)
+		}
+	}
+}
+
+// writeObject writes the cleaned up JSON serialization of the object into
+// path. The object is written to a temporary file first so a failed backup
+// never leaves a partial file
+func writeObject(path string, obj runtime.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	cleanupObject(content)
+
+	serialized, err := json.MarshalIndent(content, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(path+".tmp", serialized, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func readObject(path string, obj interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, obj)
+}
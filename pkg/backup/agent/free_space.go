@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"io/ioutil"
+	"strconv"
+	"syscall"
+)
+
+// The available bytes of the filesystem are written as termination message.
+// The operator reads them to verify the storage requirements of the upgrades
+func (a *agent) runMeasureFreeSpace(args []string) error {
+	flags := newFlagSet("measure-free-space")
+	path := flags.String("path", "", "Directory where the volume to measure is mounted")
+	terminationLog := flags.String("termination-log", defaultTerminationLogPath, "File where the available bytes are written")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "path"); err != nil {
+		return err
+	}
+
+	stat := syscall.Statfs_t{}
+	err := syscall.Statfs(*path, &stat)
+	if err != nil {
+		return err
+	}
+
+	// Bavail excludes the blocks reserved to the root user
+	availableBytes := uint64(stat.Bavail) * uint64(stat.Bsize)
+	err = ioutil.WriteFile(*terminationLog, []byte(strconv.FormatUint(availableBytes, 10)), 0644)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Free space measured", "path", *path, "availableBytes", availableBytes)
+	return nil
+}
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+const defaultTerminationLogPath = "/dev/termination-log"
+
+// The manifest summary is written as termination message. The controller
+// reads it into the status of the APIManagerBackup
+func (a *agent) runWriteManifest(args []string) error {
+	manifest := &backup.BackupManifest{}
+	flags := newFlagSet("write-manifest")
+	source := flags.String("source", "", "Directory containing the backup data")
+	terminationLog := flags.String("termination-log", defaultTerminationLogPath, "File where the manifest summary is written")
+	flags.StringVar(&manifest.ThreescaleRelease, "threescale-release", "", "3scale release of the backed up APIManager")
+	flags.StringVar(&manifest.OperatorVersion, "operator-version", "", "Version of the operator performing the backup")
+	flags.StringVar(&manifest.APIManagerName, "apimanager-name", "", "Name of the backed up APIManager")
+	flags.StringVar(&manifest.APIManagerSpecHash, "apimanager-spec-hash", "", "Hash of the spec of the backed up APIManager")
+	flags.StringVar(&manifest.Namespace, "namespace", "", "Namespace of the backed up APIManager")
+	flags.StringVar(&manifest.WildcardDomain, "wildcard-domain", "", "Wildcard domain of the backed up APIManager")
+	flags.BoolVar(&manifest.Encrypted, "encrypted", false, "Whether the backup data is encrypted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "source"); err != nil {
+		return err
+	}
+
+	summary, err := backup.WriteBackupManifest(*source, manifest)
+	if err != nil {
+		return err
+	}
+
+	serializedSummary, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(*terminationLog, serializedSummary, 0644)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Backup manifest written", "fileCount", summary.FileCount, "totalSize", summary.TotalSize)
+	return nil
+}
+
+// The manifest summary, or the verification error, is written as
+// termination message. Backups without manifest are only accepted, without
+// verification, when allowed
+func (a *agent) runVerifyManifest(args []string) error {
+	flags := newFlagSet("verify-manifest")
+	source := flags.String("source", "", "Directory containing the backup data")
+	terminationLog := flags.String("termination-log", defaultTerminationLogPath, "File where the manifest summary is written")
+	threescaleRelease := flags.String("threescale-release", "", "3scale release of the operator performing the restore")
+	encrypted := flags.Bool("encrypted", false, "Whether an encryption key is provided to decrypt the backup data")
+	allowMissingManifest := flags.Bool("allow-missing-manifest", false, "Whether backup data without manifest is accepted without verification")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "source", "threescale-release"); err != nil {
+		return err
+	}
+
+	summary, err := backup.VerifyBackupManifest(*source, *threescaleRelease, *encrypted)
+	if errors.Is(err, backup.ErrBackupManifestNotFound) && *allowMissingManifest {
+		a.logger.Info("Backup manifest not found. Skipping backup verification")
+		return ioutil.WriteFile(*terminationLog, []byte("{}"), 0644)
+	}
+	if err != nil {
+		if writeErr := ioutil.WriteFile(*terminationLog, []byte(err.Error()), 0644); writeErr != nil {
+			a.logger.Error(writeErr, "Termination message not written")
+		}
+		return err
+	}
+
+	serializedSummary, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(*terminationLog, serializedSummary, 0644)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Backup data verified", "fileCount", summary.FileCount, "totalSize", summary.TotalSize)
+	return nil
+}
@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+func TestRunWriteAndVerifyManifest(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	dataDir := filepath.Join(dir, "data")
+	if err := os.MkdirAll(filepath.Join(dataDir, "secrets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dataDir, "secrets", "system-seed.json"), []byte("seed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	terminationLog := filepath.Join(dir, "termination-log")
+
+	err := runWithClients([]string{"write-manifest", "--source", dataDir, "--termination-log", terminationLog,
+		"--threescale-release", "2.10", "--namespace", "source"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := ioutil.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := backup.ParseBackupManifestSummary(string(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary == nil || summary.FileCount != 1 || summary.Namespace != "source" {
+		t.Fatalf("unexpected summary: %v", summary)
+	}
+
+	err = runWithClients([]string{"verify-manifest", "--source", dataDir, "--termination-log", terminationLog,
+		"--threescale-release", "2.10"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = runWithClients([]string{"verify-manifest", "--source", dataDir, "--termination-log", terminationLog,
+		"--threescale-release", "2.10", "--encrypted"}, nil)
+	if err == nil {
+		t.Fatal("expected verification error")
+	}
+	message, err = ioutil.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(message), "Backup data is not encrypted") {
+		t.Errorf("unexpected termination message: %s", message)
+	}
+
+	// Backup data without manifest is only accepted when allowed
+	if err := os.Remove(filepath.Join(dataDir, backup.BackupManifestFileName)); err != nil {
+		t.Fatal(err)
+	}
+	err = runWithClients([]string{"verify-manifest", "--source", dataDir, "--termination-log", terminationLog,
+		"--threescale-release", "2.10"}, nil)
+	if err == nil {
+		t.Fatal("expected verification error")
+	}
+	message, err = ioutil.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(message) != backup.ErrBackupManifestNotFound.Error() {
+		t.Errorf("unexpected termination message: %s", message)
+	}
+
+	err = runWithClients([]string{"verify-manifest", "--source", dataDir, "--termination-log", terminationLog,
+		"--threescale-release", "2.10", "--allow-missing-manifest"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err = ioutil.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err = backup.ParseBackupManifestSummary(string(message))
+	if err != nil || summary != nil {
+		t.Errorf("unexpected summary: %v, %v", summary, err)
+	}
+}
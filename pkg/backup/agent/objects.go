@@ -0,0 +1,239 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+const (
+	secretsSubdir    = "secrets"
+	configMapsSubdir = "configmaps"
+	apimanagerSubdir = "apimanager"
+
+	systemEnvironmentConfigMapName = "system-environment"
+	systemSuperdomainKey           = "THREESCALE_SUPERDOMAIN"
+)
+
+var apimanagerResource = appsv1alpha1.GroupVersion.WithResource("apimanagers")
+
+func (a *agent) runBackupObjects(args []string) error {
+	var secrets, configMaps stringSliceFlag
+	flags := newFlagSet("backup-objects")
+	namespace := flags.String("namespace", "", "Namespace of the objects")
+	destination := flags.String("destination", "", "Directory where the objects are written")
+	flags.Var(&secrets, "secret", "Name of a secret to back up. Can be repeated")
+	flags.Var(&configMaps, "configmap", "Name of a configmap to back up. Can be repeated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "destination"); err != nil {
+		return err
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range secrets {
+		secret, err := c.kubernetes.CoreV1().Secrets(*namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+		err = writeObject(filepath.Join(*destination, secretsSubdir, name+".json"), secret)
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Secret backed up", "secret", name)
+	}
+
+	for _, name := range configMaps {
+		configMap, err := c.kubernetes.CoreV1().ConfigMaps(*namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		configMap.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+		err = writeObject(filepath.Join(*destination, configMapsSubdir, name+".json"), configMap)
+		if err != nil {
+			return err
+		}
+		a.logger.Info("ConfigMap backed up", "configmap", name)
+	}
+
+	return nil
+}
+
+func (a *agent) runBackupAPIManager(args []string) error {
+	flags := newFlagSet("backup-apimanager")
+	namespace := flags.String("namespace", "", "Namespace of the APIManager")
+	name := flags.String("name", "", "Name of the APIManager")
+	destination := flags.String("destination", "", "Directory where the APIManager is written")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "name", "destination"); err != nil {
+		return err
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	apimanager, err := c.dynamic.Resource(apimanagerResource).Namespace(*namespace).Get(context.TODO(), *name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = writeObject(filepath.Join(*destination, apimanagerSubdir, backup.APIManagerSerializedBackupFileName), apimanager)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("APIManager backed up", "apimanager", *name)
+	return nil
+}
+
+// Existing objects are kept. When the wildcard domain is overridden the
+// system-environment configmap is updated with it
+func (a *agent) runRestoreObjects(args []string) error {
+	var secrets, configMaps stringSliceFlag
+	flags := newFlagSet("restore-objects")
+	namespace := flags.String("namespace", "", "Namespace where the objects are restored")
+	source := flags.String("source", "", "Directory containing the backed up objects")
+	wildcardDomain := flags.String("wildcard-domain", "", "Wildcard domain of the restored APIManager, when overridden")
+	flags.Var(&secrets, "secret", "Name of a secret to restore. Can be repeated")
+	flags.Var(&configMaps, "configmap", "Name of a configmap to restore. Can be repeated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "source"); err != nil {
+		return err
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range secrets {
+		err := a.restoreSecret(c, *namespace, filepath.Join(*source, secretsSubdir, name+".json"))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range configMaps {
+		configMap := &v1.ConfigMap{}
+		err := readObject(filepath.Join(*source, configMapsSubdir, name+".json"), configMap)
+		if err != nil {
+			return err
+		}
+		configMap.Namespace = *namespace
+		_, err = c.kubernetes.CoreV1().ConfigMaps(*namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			a.logger.Info("ConfigMap already exists. Skipping restore of the ConfigMap", "configmap", configMap.Name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		a.logger.Info("ConfigMap restored", "configmap", configMap.Name)
+	}
+
+	if *wildcardDomain != "" {
+		configMaps := c.kubernetes.CoreV1().ConfigMaps(*namespace)
+		configMap, err := configMaps.Get(context.TODO(), systemEnvironmentConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[systemSuperdomainKey] = *wildcardDomain
+		_, err = configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Wildcard domain overridden", "configmap", systemEnvironmentConfigMapName, "wildcardDomain", *wildcardDomain)
+	}
+
+	return nil
+}
+
+func (a *agent) restoreSecret(c *clients, namespace, path string) error {
+	secret := &v1.Secret{}
+	err := readObject(path, secret)
+	if err != nil {
+		return err
+	}
+	secret.Namespace = namespace
+
+	_, err = c.kubernetes.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		a.logger.Info("Secret already exists. Skipping restore of the secret", "secret", secret.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Secret restored", "secret", secret.Name)
+	return nil
+}
+
+// The APIManagerRestore controller reads the backed up APIManager from the
+// secret
+func (a *agent) runShareAPIManager(args []string) error {
+	flags := newFlagSet("share-apimanager")
+	namespace := flags.String("namespace", "", "Namespace where the secret is created")
+	source := flags.String("source", "", "Directory containing the backed up APIManager")
+	secretName := flags.String("secret-name", "", "Name of the secret")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requiredFlags(flags, "namespace", "source", "secret-name"); err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(*source, apimanagerSubdir, backup.APIManagerSerializedBackupFileName))
+	if err != nil {
+		return err
+	}
+
+	c, err := a.clients()
+	if err != nil {
+		return err
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *secretName,
+			Namespace: *namespace,
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			backup.APIManagerSerializedBackupFileName: content,
+		},
+	}
+	_, err = c.kubernetes.CoreV1().Secrets(*namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		a.logger.Info("Secret already exists", "secret", *secretName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("Backed up APIManager stored in secret", "secret", *secretName)
+	return nil
+}
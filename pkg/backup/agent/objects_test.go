@@ -0,0 +1,186 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+func runWithClients(args []string, c *clients) error {
+	return run(args, zap.New(zap.WriteTo(ioutil.Discard)), func() (*clients, error) {
+		return c, nil
+	})
+}
+
+func newFakeClients(objects []runtime.Object, customResources ...runtime.Object) *clients {
+	return &clients{
+		kubernetes: fake.NewSimpleClientset(objects...),
+		dynamic:    dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), customResources...),
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup-agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRunBackupAndRestoreObjects(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	source := newFakeClients([]runtime.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "system-seed",
+				Namespace:       "source",
+				ResourceVersion: "12",
+				UID:             "1234",
+			},
+			Data: map[string][]byte{"MASTER_PASSWORD": []byte("secret")},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-environment", Namespace: "source"},
+			Data:       map[string]string{"THREESCALE_SUPERDOMAIN": "source.example.com"},
+		},
+	})
+
+	err := runWithClients([]string{"backup-objects", "--namespace", "source", "--destination", dir,
+		"--secret", "system-seed", "--configmap", "system-environment"}, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backedUpSecret := map[string]interface{}{}
+	if err := readObject(filepath.Join(dir, "secrets", "system-seed.json"), &backedUpSecret); err != nil {
+		t.Fatal(err)
+	}
+	if backedUpSecret["kind"] != "Secret" {
+		t.Errorf("unexpected kind: %v", backedUpSecret["kind"])
+	}
+	metadata := backedUpSecret["metadata"].(map[string]interface{})
+	for _, attr := range []string{"namespace", "resourceVersion", "uid"} {
+		if _, ok := metadata[attr]; ok {
+			t.Errorf("metadata attribute '%s' not removed", attr)
+		}
+	}
+
+	// Backing up a missing object fails
+	err = runWithClients([]string{"backup-objects", "--namespace", "source", "--destination", dir,
+		"--secret", "missing"}, source)
+	if err == nil {
+		t.Error("expected error backing up a missing secret")
+	}
+
+	target := newFakeClients([]runtime.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-seed", Namespace: "target"},
+			Data:       map[string][]byte{"MASTER_PASSWORD": []byte("existing")},
+		},
+	})
+
+	err = runWithClients([]string{"restore-objects", "--namespace", "target", "--source", dir,
+		"--wildcard-domain", "target.example.com",
+		"--secret", "system-seed", "--configmap", "system-environment"}, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := target.kubernetes.CoreV1().Secrets("target").Get(context.TODO(), "system-seed", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["MASTER_PASSWORD"]) != "existing" {
+		t.Error("existing secret has been overwritten")
+	}
+
+	configMap, err := target.kubernetes.CoreV1().ConfigMaps("target").Get(context.TODO(), "system-environment", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configMap.Data["THREESCALE_SUPERDOMAIN"] != "target.example.com" {
+		t.Errorf("unexpected superdomain: %s", configMap.Data["THREESCALE_SUPERDOMAIN"])
+	}
+}
+
+func TestRunBackupAndShareAPIManager(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	apimanager := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps.3scale.net/v1alpha1",
+		"kind":       "APIManager",
+		"metadata": map[string]interface{}{
+			"name":      "example-apimanager",
+			"namespace": "source",
+			"uid":       "1234",
+		},
+		"spec": map[string]interface{}{
+			"wildcardDomain": "source.example.com",
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{},
+		},
+	}}
+	c := newFakeClients(nil, apimanager)
+
+	err := runWithClients([]string{"backup-apimanager", "--namespace", "source", "--name", "example-apimanager",
+		"--destination", dir}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backedUp := &unstructured.Unstructured{}
+	if err := readObject(filepath.Join(dir, "apimanager", backup.APIManagerSerializedBackupFileName), &backedUp.Object); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := backedUp.Object["status"]; ok {
+		t.Error("status not removed")
+	}
+	if backedUp.GetNamespace() != "" || backedUp.GetUID() != "" {
+		t.Error("metadata not cleaned up")
+	}
+
+	err = runWithClients([]string{"share-apimanager", "--namespace", "target", "--source", dir,
+		"--secret-name", "apimanager-restore"}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := c.kubernetes.CoreV1().Secrets("target").Get(context.TODO(), "apimanager-restore", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret.Data[backup.APIManagerSerializedBackupFileName]) == 0 {
+		t.Error("shared secret does not contain the APIManager")
+	}
+}
+
+func TestRunMissingFlags(t *testing.T) {
+	operations := []string{
+		"backup-objects", "backup-apimanager", "backup-capabilities", "write-manifest",
+		"verify-manifest", "restore-objects", "share-apimanager", "restore-capabilities",
+	}
+	for _, operation := range operations {
+		t.Run(operation, func(subT *testing.T) {
+			err := runWithClients([]string{operation}, newFakeClients(nil))
+			if err == nil {
+				subT.Fatal("expected error")
+			}
+		})
+	}
+}
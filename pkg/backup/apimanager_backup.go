@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -15,6 +16,9 @@ const BackupPVCMountPath = "/backup"
 const SystemFileStoragePVCMountPath = "/system-filestorage-pvc"
 const APIManagerSerializedBackupFileName = "apimanager-backup.json"
 
+const backupDataVolumeName = "backup-data"
+const backupPlaintextDataVolumeName = "backup-plaintext-data"
+
 var secretsToBackup map[string]string = map[string]string{
 	"SystemSMTP":          "system-smtp",
 	"SystemSeed":          "system-seed",
@@ -98,7 +102,7 @@ func (b *APIManagerBackup) BackupDestinationPVC() *v1.PersistentVolumeClaim {
 }
 
 func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+	if !b.backupDestinationSet() {
 		return nil
 	}
 
@@ -108,7 +112,93 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withBackupDestinationUpload(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDataPodVolume(),
+					},
+					Containers: []v1.Container{
+						BackupAgentContainer("backup-cfgmaps-secrets", b.options.BackupAgentImageURL,
+							b.backupSecretsAndConfigMapsAgentArgs(),
+							b.backupDataContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	})
+}
+
+func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job {
+	if !b.backupDestinationSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-apimanager-cr", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return b.withBackupDestinationUpload(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDataPodVolume(),
+					},
+					Containers: []v1.Container{
+						BackupAgentContainer("backup-apimanager-cr", b.options.BackupAgentImageURL,
+							b.backupAPIManagerCustomResourceAgentArgs(),
+							b.backupDataContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	})
+}
+
+func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
+	if !b.backupDestinationSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-system-fs-pvc", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return b.withBackupDestinationUpload(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -118,16 +208,18 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 			Namespace: b.options.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
+						b.backupDataPodVolume(),
+						b.systemFileStoragePodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "backup-cfgmaps-secrets",
+							Name:  "backup-system-filestorage-pvc",
 							Image: b.options.OCCLIImageURL,
 							Command: []string{
 								"/bin/bash",
@@ -135,11 +227,12 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 							Args: []string{
 								"-c",
 								"-e",
-								b.backupSecretsAndConfigMapsContainerArgs(),
+								b.backupSystemFilestoragePVCContainerArgs(),
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
+								b.backupDataContainerVolumeMount(),
+								b.systemFileStorageContainerVolumeMount(),
 							},
 						},
 					},
@@ -148,21 +241,33 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 				},
 			},
 		},
-	}
+	})
 }
 
-func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+// BackupSystemDatabaseToPVCJob dumps the internal system database into the
+// backup destination. It returns nil when the system database is external
+func (b *APIManagerBackup) BackupSystemDatabaseToPVCJob() *batchv1.Job {
+	if !b.backupDestinationSet() {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("backup-apimanager-cr", b.options.APIManagerBackupUID)
+	var containerArgs string
+	switch b.options.SystemDatabaseType {
+	case component.SystemDatabaseTypeInternalMySQL:
+		containerArgs = b.backupSystemMySQLContainerArgs()
+	case component.SystemDatabaseTypeInternalPostgreSQL:
+		containerArgs = b.backupSystemPostgreSQLContainerArgs()
+	default:
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-system-database", b.options.APIManagerBackupUID)
 	if err != nil {
 		panic(err)
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withBackupDestinationUpload(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -172,28 +277,31 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 			Namespace: b.options.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
+						b.backupDataPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "backup-apimanager-cr",
-							Image: b.options.OCCLIImageURL,
+							Name:  "backup-system-database",
+							Image: b.options.SystemDatabaseImageURL,
 							Command: []string{
 								"/bin/bash",
 							},
 							Args: []string{
 								"-c",
 								"-e",
-								b.backupAPIManagerCustomResourceContainerArgs(),
+								containerArgs,
+							},
+							Env: []v1.EnvVar{
+								helper.EnvVarFromSecret("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
 							},
-							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
+								b.backupDataContainerVolumeMount(),
 							},
 						},
 					},
@@ -202,21 +310,24 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 				},
 			},
 		},
-	}
+	})
 }
 
-func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+// BackupRedisToPVCJob copies the data of the operator-managed backend-redis
+// and system-redis into the backup destination. It returns nil when the
+// Redis databases are external
+func (b *APIManagerBackup) BackupRedisToPVCJob() *batchv1.Job {
+	if !b.backupDestinationSet() || !b.options.InternalRedisDatabases {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("backup-system-fs-pvc", b.options.APIManagerBackupUID)
+	jobName, err := helper.UIDBasedJobName("backup-redis", b.options.APIManagerBackupUID)
 	if err != nil {
 		panic(err)
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withBackupDestinationUpload(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -226,17 +337,17 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 			Namespace: b.options.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried. Should we limit to 1?
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
-						b.systemFileStoragePodVolume(),
+						b.backupDataPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "backup-system-filestorage-pvc",
+							Name:  "backup-redis",
 							Image: b.options.OCCLIImageURL,
 							Command: []string{
 								"/bin/bash",
@@ -244,12 +355,11 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 							Args: []string{
 								"-c",
 								"-e",
-								b.backupSystemFilestoragePVCContainerArgs(),
+								b.backupRedisContainerArgs(),
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
-								b.systemFileStorageContainerVolumeMount(),
+								b.backupDataContainerVolumeMount(),
 							},
 						},
 					},
@@ -258,6 +368,111 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 				},
 			},
 		},
+	})
+}
+
+func (b *APIManagerBackup) BackupCapabilitiesToPVCJob() *batchv1.Job {
+	if !b.backupDestinationSet() || !b.options.IncludeCapabilities {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-capabilities", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return b.withBackupDestinationUpload(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDataPodVolume(),
+					},
+					Containers: []v1.Container{
+						BackupAgentContainer("backup-capabilities", b.options.BackupAgentImageURL,
+							b.backupCapabilitiesAgentArgs(),
+							b.backupDataContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	})
+}
+
+// BackupManifestJob writes the backup manifest at the root of the backup
+// data. It has to be run once all the other backup jobs have finished. The
+// manifest summary is written as termination message of the manifest
+// container
+func (b *APIManagerBackup) BackupManifestJob() *batchv1.Job {
+	if !b.backupDestinationSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-manifest", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	manifestContainer := BackupAgentContainer(BackupManifestContainerName, b.options.BackupAgentImageURL,
+		b.backupManifestAgentArgs(),
+		b.backupDestinationContainerVolumeMount(),
+	)
+
+	var initContainers []v1.Container
+	containers := []v1.Container{manifestContainer}
+	// When S3 is used the whole backup data is downloaded to compute the
+	// checksums and only the manifest is uploaded
+	if s3Options := b.options.APIManagerBackupS3Options; s3Options != nil {
+		initContainers = []v1.Container{
+			s3Options.DownloadContainer(b.backupDestinationContainerVolumeMount()),
+			manifestContainer,
+		}
+		containers = []v1.Container{
+			s3Options.UploadFileContainer(b.backupDestinationContainerVolumeMount(), BackupManifestFileName),
+		}
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDestinationPodVolume(),
+					},
+					InitContainers:     initContainers,
+					Containers:         containers,
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
 	}
 }
 
@@ -280,7 +495,22 @@ func (b *APIManagerBackup) systemFileStorageContainerVolumeMount() v1.VolumeMoun
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
+func (b *APIManagerBackup) backupDestinationSet() bool {
+	return b.options.APIManagerBackupPVCOptions != nil || b.options.APIManagerBackupS3Options != nil
+}
+
+// When S3 is used the backup data is written to an emptyDir volume and
+// uploaded once the job containers have finished
+func (b *APIManagerBackup) backupDestinationPodVolume() v1.Volume {
+	if b.options.APIManagerBackupS3Options != nil {
+		return v1.Volume{
+			Name: backupDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
 	return v1.Volume{
 		Name: b.BackupDestinationPVC().Name,
 		VolumeSource: v1.VolumeSource{
@@ -291,66 +521,134 @@ func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationContainerVolumeMount() v1.VolumeMount {
+func (b *APIManagerBackup) backupDestinationContainerVolumeMount() v1.VolumeMount {
+	if b.options.APIManagerBackupS3Options != nil {
+		return v1.VolumeMount{
+			Name:      backupDataVolumeName,
+			MountPath: BackupPVCMountPath,
+		}
+	}
+
 	return v1.VolumeMount{
 		Name:      b.BackupDestinationPVC().Name,
 		MountPath: BackupPVCMountPath,
 	}
 }
 
-func (b *APIManagerBackup) backupSecretsAndConfigMapsContainerArgs() string {
-	pythonCleanupSubscriptContent := b.pythonCleanupK8sObjectScript()
-	return fmt.Sprintf(`
-SECRETS="%s";
-CONFIGMAPS="%s";
-BASEPATH="%s";
-PYTHON_CLEANUP_SUBSCRIPT="%s"
-mkdir -p $BASEPATH/secrets;
-mkdir -p $BASEPATH/configmaps;
-for i in $(echo -n $SECRETS); do oc get secret -o json $i | python -c "${PYTHON_CLEANUP_SUBSCRIPT}" > $BASEPATH/secrets/$i.json; done;
-for i in $(echo -n $CONFIGMAPS); do oc get configmap -o json $i | python -c "${PYTHON_CLEANUP_SUBSCRIPT}" > $BASEPATH/configmaps/$i.json; done;
-`,
-		strings.Join(helper.SortedMapStringStringValues(secretsToBackup), " "),
-		strings.Join(helper.SortedMapStringStringValues(configMapsToBackup), " "),
-		BackupPVCMountPath,
-		pythonCleanupSubscriptContent,
-	)
+// When the backup data is encrypted the job containers write the backup data
+// to an emptyDir volume, which is encrypted into the backup destination once
+// they have finished. Otherwise they write to the backup destination volume
+func (b *APIManagerBackup) backupDataPodVolume() v1.Volume {
+	if b.options.EncryptionOptions != nil {
+		return v1.Volume{
+			Name: backupPlaintextDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return b.backupDestinationPodVolume()
 }
 
-func (b *APIManagerBackup) backupAPIManagerCustomResourceContainerArgs() string {
-	pythonCleanupSubscriptContent := b.pythonCleanupK8sObjectScript()
-	return fmt.Sprintf(`
-BASEPATH="%s";
-PYTHON_CLEANUP_SUBSCRIPT="%s"
-APIMANAGER_NAME="%s"
-APIMANAGER_BACKUP_FILENAME="%s"
-APIMANAGER_SUBDIR="$BASEPATH/apimanager";
-mkdir -p ${APIMANAGER_SUBDIR};
-oc get apimanager -o json ${APIMANAGER_NAME} | python -c "${PYTHON_CLEANUP_SUBSCRIPT}" > ${APIMANAGER_SUBDIR}/${APIMANAGER_BACKUP_FILENAME};
-`,
-		BackupPVCMountPath,
-		pythonCleanupSubscriptContent,
-		b.options.APIManagerName,
-		APIManagerSerializedBackupFileName,
-	)
+func (b *APIManagerBackup) backupDataContainerVolumeMount() v1.VolumeMount {
+	if b.options.EncryptionOptions != nil {
+		return v1.VolumeMount{
+			Name:      backupPlaintextDataVolumeName,
+			MountPath: BackupPVCMountPath,
+		}
+	}
+
+	return b.backupDestinationContainerVolumeMount()
 }
 
-func (b *APIManagerBackup) pythonCleanupK8sObjectScript() string {
-	return `
-import sys, json
+// withBackupDestinationUpload turns the job containers into init containers
+// followed by a container encrypting the backup data, when encryption is
+// enabled, and a container uploading it, when S3 is used
+func (b *APIManagerBackup) withBackupDestinationUpload(job *batchv1.Job) *batchv1.Job {
+	encryptionOptions := b.options.EncryptionOptions
+	s3Options := b.options.APIManagerBackupS3Options
+	if encryptionOptions == nil && s3Options == nil {
+		return job
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	finalContainers := []v1.Container{}
+	destinationVolumeMount := b.backupDestinationContainerVolumeMount()
+
+	if encryptionOptions != nil {
+		destinationVolumeMount.MountPath = EncryptedBackupMountPath
+		podSpec.Volumes = append(podSpec.Volumes, b.backupDestinationPodVolume(), encryptionOptions.KeyPodVolume())
+		finalContainers = append(finalContainers,
+			encryptionOptions.EncryptContainer(b.backupDataContainerVolumeMount(), destinationVolumeMount),
+		)
+	}
+
+	if s3Options != nil {
+		finalContainers = append(finalContainers, s3Options.UploadContainer(destinationVolumeMount))
+	}
+
+	// Only the last container is kept as regular container so the containers
+	// are run in order
+	podSpec.InitContainers = append(podSpec.InitContainers, podSpec.Containers...)
+	podSpec.InitContainers = append(podSpec.InitContainers, finalContainers[:len(finalContainers)-1]...)
+	podSpec.Containers = finalContainers[len(finalContainers)-1:]
+	return job
+}
 
-parsed=json.load(sys.stdin)
-if 'status' in parsed:
-  del parsed['status']
+// BackupS3Location returns the location of the backup data objects. Empty
+// when S3 is not the backup destination
+func (b *APIManagerBackup) BackupS3Location() string {
+	if b.options.APIManagerBackupS3Options == nil {
+		return ""
+	}
+	return b.options.APIManagerBackupS3Options.URL()
+}
 
-metadataAttrsToDelete = ['ownerReferences', 'selfLink', 'uid', 'resourceVersion', 'creationTimestamp', 'namespace', 'clusterName', 'generation']
-metadata=parsed['metadata']
-for metadataAttr in metadataAttrsToDelete:
-  if metadataAttr in metadata:
-    del metadata[metadataAttr]
+func (b *APIManagerBackup) backupSecretsAndConfigMapsAgentArgs() []string {
+	args := []string{
+		"backup-objects",
+		"--namespace", b.options.Namespace,
+		"--destination", BackupPVCMountPath,
+	}
+	args = append(args, BackupAgentRepeatedFlagArgs("--secret", helper.SortedMapStringStringValues(secretsToBackup))...)
+	args = append(args, BackupAgentRepeatedFlagArgs("--configmap", helper.SortedMapStringStringValues(configMapsToBackup))...)
+	return args
+}
 
-print(json.dumps(parsed, indent=4, sort_keys=True))
-`
+func (b *APIManagerBackup) backupAPIManagerCustomResourceAgentArgs() []string {
+	return []string{
+		"backup-apimanager",
+		"--namespace", b.options.Namespace,
+		"--name", b.options.APIManagerName,
+		"--destination", BackupPVCMountPath,
+	}
+}
+
+// The status of the capabilities custom resources, which contains their
+// 3scale IDs, is kept in an annotation because the status is not restored
+// when the objects are created. The secrets referenced by the custom
+// resources in the same namespace are backed up too
+func (b *APIManagerBackup) backupCapabilitiesAgentArgs() []string {
+	return []string{
+		"backup-capabilities",
+		"--namespace", b.options.Namespace,
+		"--destination", BackupPVCMountPath,
+	}
+}
+
+func (b *APIManagerBackup) backupManifestAgentArgs() []string {
+	return []string{
+		"write-manifest",
+		"--source", BackupPVCMountPath,
+		"--threescale-release", b.options.ThreescaleRelease,
+		"--operator-version", b.options.OperatorVersion,
+		"--apimanager-name", b.options.APIManagerName,
+		"--apimanager-spec-hash", b.options.APIManagerSpecHash,
+		"--namespace", b.options.Namespace,
+		"--wildcard-domain", b.options.APIManager.Spec.WildcardDomain,
+		fmt.Sprintf("--encrypted=%t", b.options.EncryptionOptions != nil),
+	}
 }
 
 func (b *APIManagerBackup) backupSystemFilestoragePVCContainerArgs() string {
@@ -365,3 +663,94 @@ rsync -av ${SYSTEM_FILESTORAGE_PVC_DIR}/ ${PVC_BACKUP_FILESTORAGE_SUBDIR};
 		SystemFileStoragePVCMountPath,
 	)
 }
+
+// The dump is done in a single transaction so a consistent snapshot of the
+// InnoDB tables is obtained without locking the database. It is written
+// to a temporary file first so a failed dump never replaces a previous one.
+// The database URL is split at its last '@' as the password can contain it
+func (b *APIManagerBackup) backupSystemMySQLContainerArgs() string {
+	return fmt.Sprintf(`
+set -o pipefail;
+BASEPATH='%s';
+SYSTEM_DATABASE_SUBDIR="${BASEPATH}/%s";
+DUMP_FILE="${SYSTEM_DATABASE_SUBDIR}/%s";
+URL_NO_SCHEME="${DATABASE_URL#*://}";
+DB_CREDENTIALS="${URL_NO_SCHEME%%@*}";
+DB_HOST_AND_NAME="${URL_NO_SCHEME##*@}";
+DB_USER="${DB_CREDENTIALS%%%%:*}";
+export MYSQL_PWD="${DB_CREDENTIALS#*:}";
+DB_HOST="${DB_HOST_AND_NAME%%%%/*}";
+DB_NAME="${DB_HOST_AND_NAME#*/}";
+mkdir -p ${SYSTEM_DATABASE_SUBDIR};
+mysqldump -h "${DB_HOST}" -u "${DB_USER}" --single-transaction --routines --triggers "${DB_NAME}" | gzip > ${DUMP_FILE}.tmp;
+mv ${DUMP_FILE}.tmp ${DUMP_FILE};
+`,
+		BackupPVCMountPath,
+		SystemDatabaseBackupSubdir,
+		SystemMySQLBackupFileName,
+	)
+}
+
+// pg_dump always works on a consistent snapshot of the database. The custom
+// format is used so the dump can be loaded with pg_restore
+func (b *APIManagerBackup) backupSystemPostgreSQLContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+SYSTEM_DATABASE_SUBDIR="${BASEPATH}/%s";
+DUMP_FILE="${SYSTEM_DATABASE_SUBDIR}/%s";
+mkdir -p ${SYSTEM_DATABASE_SUBDIR};
+pg_dump --format=custom --no-owner --no-privileges --file=${DUMP_FILE}.tmp "${DATABASE_URL}";
+mv ${DUMP_FILE}.tmp ${DUMP_FILE};
+`,
+		BackupPVCMountPath,
+		SystemDatabaseBackupSubdir,
+		SystemPostgreSQLBackupFileName,
+	)
+}
+
+// The operator-managed Redis instances run with appendonly enabled, so they
+// load the append only file and not the RDB snapshot at startup. A rewrite
+// of the append only file is triggered and, once finished, the compacted file
+// is streamed from the Redis pod into the backup destination. The backup
+// fails when the rewrite does not finish in time
+func (b *APIManagerBackup) backupRedisContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+REDIS_DCS='%s';
+REDIS_CLI='%s';
+REDIS_AOF_FILE='%s/%s';
+REDIS_SUBDIR="${BASEPATH}/%s";
+REDIS_AOF_REWRITE_TIMEOUT=%d;
+mkdir -p ${REDIS_SUBDIR};
+for dcname in $(echo -n $REDIS_DCS); do
+	dcpods=$(oc get pods --ignore-not-found=true -l deploymentconfig=${dcname} --field-selector=status.phase=Running --no-headers=true -o custom-columns=:metadata.name)
+	if [ -z "${dcpods}" ]; then
+		echo "No running pods found for Deployment ${dcname}"
+		exit 1
+	fi
+	podname=$(echo -n $dcpods | awk '{print $1}')
+	oc exec ${podname} -- ${REDIS_CLI} BGREWRITEAOF || true
+	waited=0
+	until oc exec ${podname} -- ${REDIS_CLI} INFO persistence | grep -q "aof_rewrite_in_progress:0" && \
+		oc exec ${podname} -- ${REDIS_CLI} INFO persistence | grep -q "aof_rewrite_scheduled:0"; do
+		if [ ${waited} -ge ${REDIS_AOF_REWRITE_TIMEOUT} ]; then
+			echo "Append only file rewrite of ${dcname} not finished after ${REDIS_AOF_REWRITE_TIMEOUT} seconds"
+			exit 1
+		fi
+		echo "Waiting for the append only file rewrite of ${dcname} to finish"
+		sleep 2
+		waited=$((waited + 2))
+	done
+	oc exec ${podname} -- cat ${REDIS_AOF_FILE} > ${REDIS_SUBDIR}/${dcname}.aof.tmp
+	mv ${REDIS_SUBDIR}/${dcname}.aof.tmp ${REDIS_SUBDIR}/${dcname}.aof
+done;
+`,
+		BackupPVCMountPath,
+		strings.Join([]string{component.BackendRedisDeploymentName, component.SystemRedisDeploymentName}, " "),
+		RedisCLICommand,
+		component.RedisDataPath,
+		RedisAOFFileName,
+		RedisBackupSubdir,
+		RedisAOFRewriteTimeoutSeconds,
+	)
+}
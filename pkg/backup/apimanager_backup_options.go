@@ -2,6 +2,7 @@ package backup
 
 import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	validator "github.com/go-playground/validator/v10"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -12,8 +13,19 @@ type APIManagerBackupOptions struct {
 	APIManagerBackupUID        types.UID                   `validate:"required"` // UID of the APIManagerBackup CR
 	APIManagerName             string                      `validate:"required"` // Name of the APIManager CR. NOT the APIManagerBackup cr name
 	APIManager                 *appsv1alpha1.APIManager    `validate:"required"`
-	APIManagerBackupPVCOptions *APIManagerBackupPVCOptions `validate:"required"`
-	OCCLIImageURL              string                      `validate:"required"`
+	APIManagerBackupPVCOptions *APIManagerBackupPVCOptions // Only one backup destination is set
+	APIManagerBackupS3Options  *S3Options
+	EncryptionOptions          *EncryptionOptions           // Nil when the backup data is not encrypted
+	JobOptions                 *JobOptions                  `validate:"required"`
+	OCCLIImageURL              string                       `validate:"required"`
+	BackupAgentImageURL        string                       `validate:"required"` // Operator image running the backup agent
+	SystemDatabaseType         component.SystemDatabaseType `validate:"required"`
+	SystemDatabaseImageURL     string                       // Empty when the system database is external
+	InternalRedisDatabases     bool                         // backend-redis and system-redis are deployed by the operator
+	IncludeCapabilities        bool                         // Capabilities custom resources are backed up
+	ThreescaleRelease          string                       `validate:"required"` // 3scale release recorded in the backup manifest
+	OperatorVersion            string                       `validate:"required"` // Operator version recorded in the backup manifest
+	APIManagerSpecHash         string                       `validate:"required"` // Hash of the APIManager spec recorded in the backup manifest
 }
 
 func NewAPIManagerBackupOptions() *APIManagerBackupOptions {
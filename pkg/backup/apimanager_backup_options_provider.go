@@ -3,10 +3,16 @@ package backup
 import (
 	"context"
 	"fmt"
+	"path"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/version"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -38,19 +44,48 @@ func (a *APIManagerBackupOptionsProvider) Options() (*APIManagerBackupOptions, e
 	}
 	res.APIManager = apiManager
 	res.APIManagerName = apiManager.Name
-	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.OCCLIImageURL = operator.RegistryImageURL(apiManager.Spec.ImageRegistry, a.ocCLIImageURL())
+	res.BackupAgentImageURL = operator.RegistryImageURL(apiManager.Spec.ImageRegistry, operator.BackupAgentImageURL())
+	res.JobOptions = JobOptionsFromSpec(a.APIManagerBackupCR.Spec.Jobs)
+	res.SystemDatabaseType = SystemDatabaseType(apiManager)
+	res.SystemDatabaseImageURL = SystemDatabaseImageURL(apiManager)
+	res.InternalRedisDatabases = !apiManager.IsExternalDatabaseEnabled()
+	res.IncludeCapabilities = a.APIManagerBackupCR.CapabilitiesIncluded()
+	res.ThreescaleRelease = product.ThreescaleRelease
+	res.OperatorVersion = version.Version
+
+	apiManagerSpecHash, err := APIManagerSpecHash(apiManager)
+	if err != nil {
+		return nil, err
+	}
+	res.APIManagerSpecHash = apiManagerSpecHash
 
 	pvcOptions, err := a.pvcBackupOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.S3BackupOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerBackup struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one backup destination has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one backup destination can be specified")
+	}
 
 	res.APIManagerBackupPVCOptions = pvcOptions
+	res.APIManagerBackupS3Options = s3Options
+
+	encryptionOptions, err := EncryptionOptionsFromSecretRef(a.Client, a.APIManagerBackupCR.Namespace, a.APIManagerBackupCR.Spec.EncryptionKeySecretRef, apiManager.Spec.ImageRegistry)
+	if err != nil {
+		return nil, err
+	}
+	res.EncryptionOptions = encryptionOptions
 
 	return res, res.Validate()
 }
@@ -71,6 +106,54 @@ func (a *APIManagerBackupOptionsProvider) pvcBackupOptions() (*APIManagerBackupP
 	return res, res.Validate()
 }
 
+// S3BackupOptions returns the S3 location of the backup data. Nil when S3 is
+// not the backup destination. Unlike Options, it does not require the
+// APIManager to exist, so it can be used once the backup has completed
+func (a *APIManagerBackupOptionsProvider) S3BackupOptions() (*S3Options, error) {
+	s3Spec := a.APIManagerBackupCR.Spec.BackupDestination.S3
+	if s3Spec == nil {
+		return nil, nil
+	}
+
+	secret := &v1.Secret{}
+	err := a.Client.Get(context.TODO(), types.NamespacedName{Name: s3Spec.CredentialsSecretRef.Name, Namespace: a.APIManagerBackupCR.Namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = S3CredentialsSecretIsValid(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewS3Options()
+	res.Bucket = s3Spec.Bucket
+	// Each backup is stored under its own key prefix
+	res.Prefix = a.APIManagerBackupCR.Name
+	if s3Spec.Prefix != nil {
+		res.Prefix = path.Join(*s3Spec.Prefix, a.APIManagerBackupCR.Name)
+	}
+	res.Region = s3Spec.Region
+	res.Endpoint = s3Spec.Endpoint
+	res.CredentialsSecretName = s3Spec.CredentialsSecretRef.Name
+	if s3Spec.ServerSideEncryption != nil {
+		res.ServerSideEncryptionAlgorithm = &s3Spec.ServerSideEncryption.Algorithm
+		res.ServerSideEncryptionKMSKeyID = s3Spec.ServerSideEncryption.KMSKeyID
+	}
+	res.AWSCLIImageURL = operator.RegistryImageURL(a.imageRegistry(), AWSCLIImageURL())
+
+	return res, res.Validate()
+}
+
+// imageRegistry returns the image registry of the backed up APIManager. Nil
+// when the APIManager cannot be found, i.e. once it has been deleted
+func (a *APIManagerBackupOptionsProvider) imageRegistry() *appsv1alpha1.ImageRegistrySpec {
+	apiManager, err := a.apiManager()
+	if err != nil {
+		return nil
+	}
+	return apiManager.Spec.ImageRegistry
+}
+
 func (a *APIManagerBackupOptionsProvider) apiManager() (*appsv1alpha1.APIManager, error) {
 	return a.autodiscoveredAPIManager()
 }
@@ -95,6 +178,37 @@ func (a *APIManagerBackupOptionsProvider) autodiscoveredAPIManager() (*appsv1alp
 
 }
 
+// EncryptionOptionsFromSecretRef returns the options to encrypt and decrypt
+// the backup data with the key of the given secret. Nil when no secret is
+// referenced
+func EncryptionOptionsFromSecretRef(k8sClient client.Client, namespace string, secretRef *v1.LocalObjectReference, imageRegistry *appsv1alpha1.ImageRegistrySpec) (*EncryptionOptions, error) {
+	if secretRef == nil {
+		return nil, nil
+	}
+
+	secret := &v1.Secret{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: secretRef.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = EncryptionKeySecretIsValid(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewEncryptionOptions()
+	res.KeySecretName = secretRef.Name
+	res.BackupAgentImageURL = operator.RegistryImageURL(imageRegistry, operator.BackupAgentImageURL())
+
+	return res, res.Validate()
+}
+
+// AWSCLIImageURL returns the image used to upload and download the backup
+// data objects when S3 is used
+func AWSCLIImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_AWS_CLI", component.AWSCLIImageURL())
+}
+
 func (a *APIManagerBackupOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("OSE_CLI_IMAGE", component.OCCLIImageURL())
 }
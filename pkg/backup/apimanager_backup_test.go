@@ -0,0 +1,145 @@
+package backup
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testBackupOptions() *APIManagerBackupOptions {
+	var backoffLimit int32 = 3
+	return &APIManagerBackupOptions{
+		Namespace:            "operator-test",
+		APIManagerBackupName: "example-apimanagerbackup",
+		APIManagerBackupUID:  "5c4f4e5f-7e0e-4bd8-8d59-8b1a3f3e9a11",
+		APIManagerName:       "example-apimanager",
+		APIManager: &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-test"},
+		},
+		APIManagerBackupPVCOptions: &APIManagerBackupPVCOptions{
+			BackupDestinationPVC: BackupDestinationPVC{Name: "example-apimanagerbackup"},
+		},
+		JobOptions:             &JobOptions{BackoffLimit: &backoffLimit},
+		OCCLIImageURL:          "quay.io/openshift/origin-cli:4.7",
+		BackupAgentImageURL:    "quay.io/3scale/3scale-operator:master",
+		SystemDatabaseType:     component.SystemDatabaseTypeInternalMySQL,
+		SystemDatabaseImageURL: "centos/mysql-57-centos7",
+		InternalRedisDatabases: true,
+		ThreescaleRelease:      "2.10",
+		OperatorVersion:        "0.7.0",
+		APIManagerSpecHash:     "0123456789abcdef",
+	}
+}
+
+func TestBackupRedisToPVCJob(t *testing.T) {
+	options := testBackupOptions()
+	options.InternalRedisDatabases = false
+	if job := NewAPIManagerBackup(options).BackupRedisToPVCJob(); job != nil {
+		t.Errorf("job '%s' created for external Redis databases", job.Name)
+	}
+
+	job := NewAPIManagerBackup(testBackupOptions()).BackupRedisToPVCJob()
+	if job == nil {
+		t.Fatal("job not created for internal Redis databases")
+	}
+	if job.Namespace != "operator-test" {
+		t.Errorf("unexpected namespace: %s", job.Namespace)
+	}
+	if *job.Spec.Completions != 1 || *job.Spec.BackoffLimit != 3 {
+		t.Errorf("unexpected completions %d and backoff limit %d", *job.Spec.Completions, *job.Spec.BackoffLimit)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	if podSpec.ServiceAccountName != "3scale-operator" || podSpec.RestartPolicy != v1.RestartPolicyNever {
+		t.Errorf("unexpected service account '%s' and restart policy '%s'", podSpec.ServiceAccountName, podSpec.RestartPolicy)
+	}
+	expectedVolumes := []v1.Volume{{
+		Name: "example-apimanagerbackup",
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "example-apimanagerbackup"},
+		},
+	}}
+	if !reflect.DeepEqual(podSpec.Volumes, expectedVolumes) {
+		t.Errorf("unexpected volumes: %v", podSpec.Volumes)
+	}
+	if len(podSpec.InitContainers) != 0 || len(podSpec.Containers) != 1 {
+		t.Fatalf("unexpected containers: %v, %v", podSpec.InitContainers, podSpec.Containers)
+	}
+
+	container := podSpec.Containers[0]
+	if container.Name != "backup-redis" || container.Image != "quay.io/openshift/origin-cli:4.7" {
+		t.Errorf("unexpected container '%s' image: %s", container.Name, container.Image)
+	}
+	if !reflect.DeepEqual(container.Command, []string{"/bin/bash"}) {
+		t.Errorf("unexpected command: %v", container.Command)
+	}
+	if len(container.Args) != 3 || container.Args[0] != "-c" || container.Args[1] != "-e" {
+		t.Fatalf("unexpected args: %v", container.Args)
+	}
+	if !reflect.DeepEqual(container.VolumeMounts, []v1.VolumeMount{{Name: "example-apimanagerbackup", MountPath: "/backup"}}) {
+		t.Errorf("unexpected volume mounts: %v", container.VolumeMounts)
+	}
+
+	checkScriptLines(t, container.Args[2],
+		"REDIS_DCS='backend-redis system-redis';",
+		"REDIS_CLI='/opt/rh/rh-redis5/root/usr/bin/redis-cli';",
+		"REDIS_AOF_FILE='/var/lib/redis/data/appendonly.aof';",
+		`REDIS_SUBDIR="${BASEPATH}/redis";`,
+		"REDIS_AOF_REWRITE_TIMEOUT=600;",
+		"	oc exec ${podname} -- ${REDIS_CLI} BGREWRITEAOF || true",
+		"		if [ ${waited} -ge ${REDIS_AOF_REWRITE_TIMEOUT} ]; then",
+		`			echo "Append only file rewrite of ${dcname} not finished after ${REDIS_AOF_REWRITE_TIMEOUT} seconds"`,
+		"			exit 1",
+		"		waited=$((waited + 2))",
+		"	oc exec ${podname} -- cat ${REDIS_AOF_FILE} > ${REDIS_SUBDIR}/${dcname}.aof.tmp",
+		"	mv ${REDIS_SUBDIR}/${dcname}.aof.tmp ${REDIS_SUBDIR}/${dcname}.aof",
+	)
+}
+
+func TestBackupRedisToPVCJobS3(t *testing.T) {
+	options := testBackupOptions()
+	options.APIManagerBackupPVCOptions = nil
+	options.APIManagerBackupS3Options = testS3Options()
+
+	podSpec := NewAPIManagerBackup(options).BackupRedisToPVCJob().Spec.Template.Spec
+	// The Redis data is uploaded once it has been copied
+	if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != "backup-redis" {
+		t.Fatalf("unexpected init containers: %v", podSpec.InitContainers)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "s3-upload" {
+		t.Fatalf("unexpected containers: %v", podSpec.Containers)
+	}
+	if !reflect.DeepEqual(podSpec.InitContainers[0].VolumeMounts, []v1.VolumeMount{testS3VolumeMount()}) {
+		t.Errorf("unexpected volume mounts: %v", podSpec.InitContainers[0].VolumeMounts)
+	}
+}
+
+// The MySQL client is given the host, user, password and database of the
+// system database URL
+func TestBackupSystemMySQLDatabaseURL(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not found")
+	}
+
+	script := NewAPIManagerBackup(testBackupOptions()).backupSystemMySQLContainerArgs()
+	// Nothing is dumped, the connection parameters are printed instead
+	script = strings.Replace(script, "mkdir -p ${SYSTEM_DATABASE_SUBDIR};", "", 1)
+	script = strings.Replace(script, `mysqldump -h "${DB_HOST}" -u "${DB_USER}" --single-transaction --routines --triggers "${DB_NAME}" | gzip > ${DUMP_FILE}.tmp;`,
+		`echo "${DB_HOST} ${DB_USER} ${MYSQL_PWD} ${DB_NAME}"; exit 0;`, 1)
+
+	cmd := exec.Command("bash", "-c", "-e", script)
+	cmd.Env = []string{"DATABASE_URL=mysql2://app:p@ss:w0rd@system-mysql:3306/system"}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+	if strings.TrimSpace(string(out)) != "system-mysql:3306 app p@ss:w0rd system" {
+		t.Errorf("unexpected connection parameters: %s", out)
+	}
+}
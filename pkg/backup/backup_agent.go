@@ -0,0 +1,27 @@
+package backup
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// BackupAgentContainer returns a container running the given operation of
+// the backup agent, the backup-agent subcommand of the operator binary
+func BackupAgentContainer(name, imageURL string, args []string, volumeMounts ...v1.VolumeMount) v1.Container {
+	return v1.Container{
+		Name:         name,
+		Image:        imageURL,
+		Command:      []string{"/manager", "backup-agent"},
+		Args:         args,
+		VolumeMounts: volumeMounts,
+	}
+}
+
+// BackupAgentRepeatedFlagArgs returns the given flag repeated for each of
+// the values
+func BackupAgentRepeatedFlagArgs(flag string, values []string) []string {
+	args := []string{}
+	for _, value := range values {
+		args = append(args, flag, value)
+	}
+	return args
+}
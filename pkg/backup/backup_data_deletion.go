@@ -0,0 +1,42 @@
+package backup
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeleteS3BackupDataJob returns a Job deleting the backup data objects of the
+// given APIManagerBackup from the S3 location described by s3Options
+func DeleteS3BackupDataJob(cr *appsv1alpha1.APIManagerBackup, s3Options *S3Options) *batchv1.Job {
+	jobName, err := helper.UIDBasedJobName("delete-backup-data", cr.UID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: cr.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						s3Options.DeleteContainer(),
+					},
+					ServiceAccountName: "3scale-operator",
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package backup
+
+import (
+	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	CapabilitiesBackupSubdir = "capabilities"
+	// CapabilitiesSecretsBackupSubdir is the subdirectory of
+	// CapabilitiesBackupSubdir where the referenced secrets are stored
+	CapabilitiesSecretsBackupSubdir = "secrets"
+
+	// CapabilitiesDefaultProviderAccountSecretName is the provider account
+	// secret used by the capabilities custom resources without
+	// providerAccountRef
+	CapabilitiesDefaultProviderAccountSecretName = "threescale-provider-account"
+)
+
+// CapabilitiesResources are the capabilities custom resources included in
+// the backups, in the order they are restored. Resources referenced by others
+// are restored first
+var CapabilitiesResources = []schema.GroupVersionResource{
+	capabilitiesv1alpha1.GroupVersion.WithResource("tenants"),
+	capabilitiesv1beta1.GroupVersion.WithResource("developeraccounts"),
+	capabilitiesv1beta1.GroupVersion.WithResource("developerusers"),
+	capabilitiesv1beta1.GroupVersion.WithResource("backends"),
+	capabilitiesv1beta1.GroupVersion.WithResource("products"),
+	capabilitiesv1beta1.GroupVersion.WithResource("openapis"),
+	capabilitiesv1beta1.GroupVersion.WithResource("activedocs"),
+	capabilitiesv1beta1.GroupVersion.WithResource("custompolicydefinitions"),
+}
+
+// CapabilitiesSecretReferenceFields are the spec fields of the capabilities
+// custom resources referencing secrets
+var CapabilitiesSecretReferenceFields = []string{
+	"providerAccountRef",
+	"secretRef",
+	"passwordCredentialsRef",
+	"tenantSecretRef",
+	"masterCredentialsRef",
+}
+
+// CapabilitiesResourceBackupSubdir returns the subdirectory of
+// CapabilitiesBackupSubdir where the custom resources of the given resource
+// are stored, i.e. products.capabilities.3scale.net
+func CapabilitiesResourceBackupSubdir(resource schema.GroupVersionResource) string {
+	return resource.GroupResource().String()
+}
@@ -0,0 +1,379 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// EncryptionKeySecretKey is the key of the encryption key secret holding
+	// the base64 encoded 256 bit key used to encrypt the backup data
+	EncryptionKeySecretKey = "BACKUP_ENCRYPTION_KEY"
+
+	// EncryptedFileSuffix is appended to the name of the encrypted backup files
+	EncryptedFileSuffix = ".enc"
+
+	// EncryptedBackupMountPath is where the encrypted backup data is mounted
+	// in the containers encrypting and decrypting it
+	EncryptedBackupMountPath = "/encrypted-backup"
+
+	encryptionKeyVolumeName = "backup-encryption-key"
+	encryptionKeyMountPath  = "/backup-encryption-key"
+
+	encryptionKeySize = 32
+	// Encrypted files start with the magic string followed by the random
+	// nonce prefix. Both are authenticated with every chunk
+	encryptionMagic           = "3SCALEBACKUPENC1"
+	encryptionNoncePrefixSize = 7
+	// The plaintext is encrypted in chunks with AES-256-GCM. Every chunk is
+	// preceded by a flag byte, set in the last chunk, and its length. The
+	// nonce of each chunk is made of the nonce prefix, the chunk counter and
+	// the last chunk flag, so chunks cannot be reordered, dropped or truncated
+	encryptionChunkSize       = 64 * 1024
+	encryptionChunkHeaderSize = 5
+)
+
+// ParseEncryptionKey decodes a base64 encoded 256 bit encryption key
+func ParseEncryptionKey(value []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(value)))
+	if err != nil {
+		return nil, fmt.Errorf("Encryption key is not base64 encoded: %v", err)
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("Encryption key has %d bytes. A %d bytes key is expected", len(key), encryptionKeySize)
+	}
+	return key, nil
+}
+
+// EncryptionKeySecretIsValid checks the given secret contains a valid
+// encryption key
+func EncryptionKeySecretIsValid(secret *v1.Secret) error {
+	value, ok := secret.Data[EncryptionKeySecretKey]
+	if !ok {
+		return fmt.Errorf("Secret '%s' does not contain the '%s' key", secret.Name, EncryptionKeySecretKey)
+	}
+	_, err := ParseEncryptionKey(value)
+	if err != nil {
+		return fmt.Errorf("Secret '%s' does not contain a valid encryption key: %v", secret.Name, err)
+	}
+	return nil
+}
+
+func newEncryptionAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptionChunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, encryptionNoncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = append(nonce, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(nonce[encryptionNoncePrefixSize:], counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// EncryptStream encrypts the content of src into dst
+func EncryptStream(key []byte, dst io.Writer, src io.Reader) error {
+	aead, err := newEncryptionAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	prefix := make([]byte, encryptionNoncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return err
+	}
+	header := append([]byte(encryptionMagic), prefix...)
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(src)
+	plaintext := make([]byte, encryptionChunkSize)
+	chunkHeader := make([]byte, encryptionChunkHeaderSize)
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(reader, plaintext)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last := err != nil
+		if !last {
+			if _, err := reader.Peek(1); err == io.EOF {
+				last = true
+			} else if err != nil {
+				return err
+			}
+		}
+		if !last && counter == math.MaxUint32 {
+			return fmt.Errorf("Content too large to be encrypted")
+		}
+
+		ciphertext := aead.Seal(nil, encryptionChunkNonce(prefix, counter, last), plaintext[:n], header)
+		chunkHeader[0] = 0
+		if last {
+			chunkHeader[0] = 1
+		}
+		binary.BigEndian.PutUint32(chunkHeader[1:], uint32(len(ciphertext)))
+		if _, err := dst.Write(chunkHeader); err != nil {
+			return err
+		}
+		if _, err := dst.Write(ciphertext); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// DecryptStream decrypts the content of src, encrypted by EncryptStream,
+// into dst. An error is returned when the content has been tampered with or
+// the key is not the one used to encrypt it
+func DecryptStream(key []byte, dst io.Writer, src io.Reader) error {
+	aead, err := newEncryptionAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, len(encryptionMagic)+encryptionNoncePrefixSize)
+	if _, err := io.ReadFull(src, header); err != nil || string(header[:len(encryptionMagic)]) != encryptionMagic {
+		return fmt.Errorf("Content is not encrypted backup data")
+	}
+	prefix := header[len(encryptionMagic):]
+
+	chunkHeader := make([]byte, encryptionChunkHeaderSize)
+	ciphertext := make([]byte, encryptionChunkSize+aead.Overhead())
+	for counter := uint32(0); ; counter++ {
+		if _, err := io.ReadFull(src, chunkHeader); err != nil {
+			return fmt.Errorf("Encrypted content is truncated")
+		}
+		if chunkHeader[0] > 1 {
+			return fmt.Errorf("Encrypted content is corrupted")
+		}
+		last := chunkHeader[0] == 1
+		length := binary.BigEndian.Uint32(chunkHeader[1:])
+		if length > uint32(len(ciphertext)) {
+			return fmt.Errorf("Encrypted content is corrupted")
+		}
+		if _, err := io.ReadFull(src, ciphertext[:length]); err != nil {
+			return fmt.Errorf("Encrypted content is truncated")
+		}
+
+		plaintext, err := aead.Open(nil, encryptionChunkNonce(prefix, counter, last), ciphertext[:length], header)
+		if err != nil {
+			return fmt.Errorf("Encrypted content cannot be authenticated. Either the encryption key is not the one used to encrypt it or the content has been modified")
+		}
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if last {
+			if n, _ := src.Read(make([]byte, 1)); n != 0 {
+				return fmt.Errorf("Encrypted content has trailing data")
+			}
+			return nil
+		}
+		if counter == math.MaxUint32 {
+			return fmt.Errorf("Encrypted content is corrupted")
+		}
+	}
+}
+
+// EncryptDirectory encrypts every file in srcDir into dstDir. The directory
+// layout is kept and the EncryptedFileSuffix is appended to the file names
+func EncryptDirectory(key []byte, srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dstDir, relPath), 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return transformFile(path, filepath.Join(dstDir, relPath+EncryptedFileSuffix), func(dst io.Writer, src io.Reader) error {
+			return EncryptStream(key, dst, src)
+		})
+	})
+}
+
+// DecryptDirectory decrypts the given subdirectories of srcDir, encrypted by
+// EncryptDirectory, into dstDir. The whole srcDir is decrypted when no
+// subdirectory is given. The backup manifest is not encrypted and is skipped.
+// Any other file not encrypted is rejected
+func DecryptDirectory(key []byte, srcDir, dstDir string, subdirs ...string) error {
+	roots := []string{srcDir}
+	if len(subdirs) > 0 {
+		roots = nil
+		for _, subdir := range subdirs {
+			roots = append(roots, filepath.Join(srcDir, subdir))
+		}
+	}
+
+	for _, root := range roots {
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			// Subdirectories are optional in backups
+			continue
+		}
+
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(srcDir, path)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if relPath == "lost+found" {
+					return filepath.SkipDir
+				}
+				return os.MkdirAll(filepath.Join(dstDir, relPath), 0755)
+			}
+			if !info.Mode().IsRegular() || relPath == BackupManifestFileName {
+				return nil
+			}
+			if !strings.HasSuffix(relPath, EncryptedFileSuffix) {
+				return fmt.Errorf("File '%s' of the backup data is not encrypted", relPath)
+			}
+			return transformFile(path, filepath.Join(dstDir, strings.TrimSuffix(relPath, EncryptedFileSuffix)), func(dst io.Writer, src io.Reader) error {
+				err := DecryptStream(key, dst, src)
+				if err != nil {
+					return fmt.Errorf("File '%s': %v", relPath, err)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transformFile writes the transformed content of srcPath to dstPath. The
+// destination file only appears once it has been completely written
+func transformFile(srcPath, dstPath string, transform func(io.Writer, io.Reader) error) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := ioutil.TempFile(filepath.Dir(dstPath), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dst.Name())
+
+	// Backup files are read and written by containers of different images,
+	// which might run with different users
+	err = dst.Chmod(0644)
+	if err == nil {
+		err = transform(dst, src)
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(dst.Name(), dstPath)
+}
+
+// EncryptionOptions describes how the backup data is encrypted. Backup data
+// is encrypted and decrypted by the backup agent, run from the operator image
+type EncryptionOptions struct {
+	KeySecretName       string `validate:"required"`
+	BackupAgentImageURL string `validate:"required"`
+}
+
+func NewEncryptionOptions() *EncryptionOptions {
+	return &EncryptionOptions{}
+}
+
+func (e *EncryptionOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(e)
+}
+
+// KeyPodVolume returns the volume with the encryption key
+func (e *EncryptionOptions) KeyPodVolume() v1.Volume {
+	return v1.Volume{
+		Name: encryptionKeyVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: e.KeySecretName,
+				Items: []v1.KeyToPath{
+					{Key: EncryptionKeySecretKey, Path: EncryptionKeySecretKey},
+				},
+			},
+		},
+	}
+}
+
+// EncryptContainer returns a container encrypting the content of the
+// directory where plainVolumeMount is mounted into the directory where
+// encryptedVolumeMount is mounted
+func (e *EncryptionOptions) EncryptContainer(plainVolumeMount, encryptedVolumeMount v1.VolumeMount) v1.Container {
+	return e.backupAgentContainer("backup-encrypt", []string{
+		"encrypt",
+		"--source", plainVolumeMount.MountPath,
+		"--destination", encryptedVolumeMount.MountPath,
+	}, plainVolumeMount, encryptedVolumeMount)
+}
+
+// DecryptContainer returns a container decrypting the given subdirectories
+// of the directory where encryptedVolumeMount is mounted into the directory
+// where plainVolumeMount is mounted. All the backup data is decrypted when no
+// subdirectory is given
+func (e *EncryptionOptions) DecryptContainer(encryptedVolumeMount, plainVolumeMount v1.VolumeMount, subdirs ...string) v1.Container {
+	args := []string{
+		"decrypt",
+		"--source", encryptedVolumeMount.MountPath,
+		"--destination", plainVolumeMount.MountPath,
+	}
+	for _, subdir := range subdirs {
+		args = append(args, "--subdir", subdir)
+	}
+	return e.backupAgentContainer("backup-decrypt", args, encryptedVolumeMount, plainVolumeMount)
+}
+
+func (e *EncryptionOptions) backupAgentContainer(name string, args []string, volumeMounts ...v1.VolumeMount) v1.Container {
+	args = append(args, "--key-file", path.Join(encryptionKeyMountPath, EncryptionKeySecretKey))
+	volumeMounts = append(volumeMounts, v1.VolumeMount{
+		Name:      encryptionKeyVolumeName,
+		MountPath: encryptionKeyMountPath,
+		ReadOnly:  true,
+	})
+	return BackupAgentContainer(name, e.BackupAgentImageURL, args, volumeMounts...)
+}
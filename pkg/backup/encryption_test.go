@@ -0,0 +1,179 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestEncryptDecryptStream(t *testing.T) {
+	key := testEncryptionKey(t)
+
+	cases := []struct {
+		name string
+		size int
+	}{
+		{"empty", 0},
+		{"small", 100},
+		{"one chunk", encryptionChunkSize},
+		{"several chunks", 3*encryptionChunkSize + 10},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			plaintext := make([]byte, tc.size)
+			if _, err := rand.Read(plaintext); err != nil {
+				subT.Fatal(err)
+			}
+
+			encrypted := &bytes.Buffer{}
+			if err := EncryptStream(key, encrypted, bytes.NewReader(plaintext)); err != nil {
+				subT.Fatal(err)
+			}
+			if tc.size > 0 && bytes.Contains(encrypted.Bytes(), plaintext) {
+				subT.Fatal("encrypted content contains the plaintext")
+			}
+
+			decrypted := &bytes.Buffer{}
+			if err := DecryptStream(key, decrypted, bytes.NewReader(encrypted.Bytes())); err != nil {
+				subT.Fatal(err)
+			}
+			if !bytes.Equal(decrypted.Bytes(), plaintext) {
+				subT.Fatal("decrypted content differs from the plaintext")
+			}
+		})
+	}
+}
+
+func TestDecryptStreamRejectsTamperedContent(t *testing.T) {
+	key := testEncryptionKey(t)
+	plaintext := make([]byte, 2*encryptionChunkSize+10)
+
+	encrypted := &bytes.Buffer{}
+	if err := EncryptStream(key, encrypted, bytes.NewReader(plaintext)); err != nil {
+		t.Fatal(err)
+	}
+	content := encrypted.Bytes()
+	chunkLength := encryptionChunkHeaderSize + encryptionChunkSize + 16
+	headerLength := len(encryptionMagic) + encryptionNoncePrefixSize
+
+	modified := append([]byte{}, content...)
+	modified[len(modified)-1] ^= 1
+
+	cases := []struct {
+		name    string
+		key     []byte
+		content []byte
+	}{
+		{"wrong key", testEncryptionKey(t), content},
+		{"modified", key, modified},
+		{"truncated", key, content[:headerLength+chunkLength]},
+		{"last chunk dropped", key, content[:headerLength+2*chunkLength]},
+		{"trailing data", key, append(append([]byte{}, content...), 0)},
+		{"not encrypted", key, plaintext},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if err := DecryptStream(tc.key, ioutil.Discard, bytes.NewReader(tc.content)); err == nil {
+				subT.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestEncryptDecryptDirectory(t *testing.T) {
+	key := testEncryptionKey(t)
+	tmpDir, err := ioutil.TempDir("", "backup-encryption")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	plainDir := filepath.Join(tmpDir, "plain")
+	encryptedDir := filepath.Join(tmpDir, "encrypted")
+	decryptedDir := filepath.Join(tmpDir, "decrypted")
+	files := map[string]string{
+		"secrets/system-seed.json":          "seed",
+		"redis/backend-redis.aof":           "aof",
+		"apimanager/apimanager-backup.json": "apimanager",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(plainDir, path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(plainDir, path), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := EncryptDirectory(key, plainDir, encryptedDir); err != nil {
+		t.Fatal(err)
+	}
+	for path := range files {
+		if _, err := os.Stat(filepath.Join(encryptedDir, path+EncryptedFileSuffix)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The manifest is not encrypted
+	if err := ioutil.WriteFile(filepath.Join(encryptedDir, BackupManifestFileName), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DecryptDirectory(key, encryptedDir, decryptedDir, "secrets", "redis", "missing"); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		decrypted, err := ioutil.ReadFile(filepath.Join(decryptedDir, path))
+		if path == "apimanager/apimanager-backup.json" {
+			if !os.IsNotExist(err) {
+				t.Errorf("file '%s' not expected to be decrypted", path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(decrypted) != content {
+			t.Errorf("file '%s' differs: got: %s; expected: %s", path, decrypted, content)
+		}
+	}
+
+	// Files not encrypted are rejected
+	if err := ioutil.WriteFile(filepath.Join(encryptedDir, "secrets", "injected.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := DecryptDirectory(key, encryptedDir, filepath.Join(tmpDir, "rejected")); err == nil {
+		t.Error("expected error decrypting a file not encrypted")
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key := testEncryptionKey(t)
+	parsed, err := ParseEncryptionKey([]byte(base64.StdEncoding.EncodeToString(key) + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsed, key) {
+		t.Error("parsed key differs")
+	}
+
+	if _, err := ParseEncryptionKey([]byte(base64.StdEncoding.EncodeToString(key[:16]))); err == nil {
+		t.Error("expected error parsing a 128 bit key")
+	}
+	if _, err := ParseEncryptionKey([]byte("not base64!")); err == nil {
+		t.Error("expected error parsing a key not base64 encoded")
+	}
+}
@@ -0,0 +1,35 @@
+package backup
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// DefaultJobBackoffLimit is the number of retries of the Job of a backup or
+// restore step when it is not set in the custom resource
+const DefaultJobBackoffLimit int32 = 3
+
+// JobOptions are the retries and timeout of the Jobs performing the backup
+// and restore steps
+type JobOptions struct {
+	BackoffLimit          *int32
+	ActiveDeadlineSeconds *int64 // Nil when the Jobs are not time limited
+}
+
+// JobOptionsFromSpec returns the Job options set in the given spec, with the
+// defaults applied
+func JobOptionsFromSpec(spec *appsv1alpha1.BackupJobsSpec) *JobOptions {
+	backoffLimit := DefaultJobBackoffLimit
+	res := &JobOptions{BackoffLimit: &backoffLimit}
+	if spec == nil {
+		return res
+	}
+
+	if spec.BackoffLimit != nil {
+		backoffLimit = *spec.BackoffLimit
+	}
+	if spec.ActiveDeadlineSeconds != nil {
+		activeDeadlineSeconds := *spec.ActiveDeadlineSeconds
+		res.ActiveDeadlineSeconds = &activeDeadlineSeconds
+	}
+	return res
+}
@@ -0,0 +1,268 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// BackupManifestFileName is the name of the file, at the root of the backup
+// data, describing the content of the backup
+const BackupManifestFileName = "manifest.json"
+
+// BackupManifestContainerName is the name of the container of the backup
+// manifest Job writing the manifest summary as its termination message
+const BackupManifestContainerName = "backup-manifest"
+
+const backupManifestVersion = 1
+
+// ErrBackupManifestNotFound is returned when verifying backup data without
+// manifest, i.e. backups performed before manifests were written
+var ErrBackupManifestNotFound = errors.New("Backup manifest not found")
+
+// BackupManifest describes the content of the backup data. It lists every
+// backed up file with its size and SHA-256 checksum
+type BackupManifest struct {
+	ManifestVersion    int                  `json:"manifestVersion"`
+	ThreescaleRelease  string               `json:"threescaleRelease"`
+	OperatorVersion    string               `json:"operatorVersion"`
+	APIManagerName     string               `json:"apiManagerName"`
+	APIManagerSpecHash string               `json:"apiManagerSpecHash"`
+	Namespace          string               `json:"namespace"`
+	WildcardDomain     string               `json:"wildcardDomain"`
+	Encrypted          bool                 `json:"encrypted"`
+	Files              []BackupManifestFile `json:"files"`
+}
+
+// BackupManifestFile describes a backed up file. The path is relative to
+// the root of the backup data
+type BackupManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// APIManagerSpecHash returns the hex encoded SHA-256 hash of the JSON
+// serialization of the spec of the given APIManager
+func APIManagerSpecHash(apimanager *appsv1alpha1.APIManager) (string, error) {
+	serializedSpec, err := json.Marshal(apimanager.Spec)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(serializedSpec)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ParseBackupManifestSummary parses the manifest summary written as
+// termination message by the backup manifest and backup verification Jobs.
+// It returns nil when the message does not describe any manifest, which is
+// the case of backups performed before manifests were written
+func ParseBackupManifestSummary(message string) (*appsv1alpha1.BackupManifestSummary, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, nil
+	}
+
+	summary := &appsv1alpha1.BackupManifestSummary{}
+	err := json.Unmarshal([]byte(message), summary)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.ManifestSHA256 == "" {
+		return nil, nil
+	}
+
+	return summary, nil
+}
+
+// WriteBackupManifest lists the files of the backup data in dir into the
+// given manifest and writes it at the root of dir. The manifest file itself
+// is not listed
+func WriteBackupManifest(dir string, manifest *BackupManifest) (*appsv1alpha1.BackupManifestSummary, error) {
+	files, err := backupDataFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.ManifestVersion = backupManifestVersion
+	manifest.Files = files
+
+	content, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	manifestPath := filepath.Join(dir, BackupManifestFileName)
+	err = ioutil.WriteFile(manifestPath+".tmp", content, 0644)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(manifestPath+".tmp", manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return backupManifestSummary(manifest, content), nil
+}
+
+// VerifyBackupManifest verifies the backup data in dir against its manifest.
+// Every file has to be listed in the manifest. It returns
+// ErrBackupManifestNotFound when the backup data has no manifest
+func VerifyBackupManifest(dir, threescaleRelease string, encryptionKeyProvided bool) (*appsv1alpha1.BackupManifestSummary, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, BackupManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrBackupManifestNotFound
+		}
+		return nil, err
+	}
+
+	manifest := &BackupManifest{}
+	err = json.Unmarshal(content, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid backup manifest: %v", err)
+	}
+
+	if manifest.ThreescaleRelease != threescaleRelease {
+		return nil, fmt.Errorf("Backup performed from 3scale release %s cannot be restored to 3scale release %s", manifest.ThreescaleRelease, threescaleRelease)
+	}
+
+	if manifest.Encrypted && !encryptionKeyProvided {
+		return nil, fmt.Errorf("Backup data is encrypted. The encryption key secret has to be referenced in the restore")
+	}
+	if !manifest.Encrypted && encryptionKeyProvided {
+		return nil, fmt.Errorf("Backup data is not encrypted. No encryption key secret has to be referenced in the restore")
+	}
+
+	errors := []string{}
+	listedPaths := map[string]bool{}
+	for _, file := range manifest.Files {
+		listedPaths[file.Path] = true
+		path := filepath.Join(dir, filepath.FromSlash(file.Path))
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			errors = append(errors, fmt.Sprintf("missing file %s", file.Path))
+			continue
+		}
+		if info.Size() != file.Size {
+			errors = append(errors, fmt.Sprintf("size mismatch in file %s", file.Path))
+			continue
+		}
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != file.SHA256 {
+			errors = append(errors, fmt.Sprintf("checksum mismatch in file %s", file.Path))
+		}
+	}
+	err = walkBackupData(dir, func(relPath, _ string, _ os.FileInfo) error {
+		if !listedPaths[relPath] {
+			errors = append(errors, fmt.Sprintf("unexpected file %s", relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(errors) > 10 {
+		errors = errors[:10]
+	}
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("Backup data does not match its manifest: %s", strings.Join(errors, ", "))
+	}
+
+	return backupManifestSummary(manifest, content), nil
+}
+
+func backupManifestSummary(manifest *BackupManifest, content []byte) *appsv1alpha1.BackupManifestSummary {
+	var totalSize int64
+	for _, file := range manifest.Files {
+		totalSize += file.Size
+	}
+	manifestHash := sha256.Sum256(content)
+
+	return &appsv1alpha1.BackupManifestSummary{
+		ThreescaleRelease:  manifest.ThreescaleRelease,
+		OperatorVersion:    manifest.OperatorVersion,
+		APIManagerSpecHash: manifest.APIManagerSpecHash,
+		FileCount:          int64(len(manifest.Files)),
+		TotalSize:          totalSize,
+		ManifestSHA256:     hex.EncodeToString(manifestHash[:]),
+		Encrypted:          manifest.Encrypted,
+		Namespace:          manifest.Namespace,
+		WildcardDomain:     manifest.WildcardDomain,
+	}
+}
+
+// backupDataFiles lists the files of the backup data in dir sorted by path
+func backupDataFiles(dir string) ([]BackupManifestFile, error) {
+	files := []BackupManifestFile{}
+	err := walkBackupData(dir, func(relPath, path string, info os.FileInfo) error {
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, BackupManifestFile{
+			Path:   relPath,
+			Size:   info.Size(),
+			SHA256: checksum,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// walkBackupData calls fn for every regular file in dir with its slash
+// separated path relative to dir, except the manifest and the lost+found
+// directory of the PVC destinations
+func walkBackupData(dir string, fn func(relPath, path string, info os.FileInfo) error) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if relPath == "lost+found" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if relPath == BackupManifestFileName || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return fn(filepath.ToSlash(relPath), path, info)
+	})
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,207 @@
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func TestAPIManagerSpecHash(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Spec.WildcardDomain = "example.com"
+
+	hash, err := APIManagerSpecHash(apimanager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("unexpected hash length: %d", len(hash))
+	}
+
+	sameHash, err := APIManagerSpecHash(apimanager.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != sameHash {
+		t.Errorf("hash of the same spec differs: %s, %s", hash, sameHash)
+	}
+
+	apimanager.Spec.WildcardDomain = "other.example.com"
+	otherHash, err := APIManagerSpecHash(apimanager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == otherHash {
+		t.Errorf("hash of different specs does not differ: %s", hash)
+	}
+}
+
+func TestParseBackupManifestSummary(t *testing.T) {
+	cases := []struct {
+		name     string
+		message  string
+		expected *appsv1alpha1.BackupManifestSummary
+		err      bool
+	}{
+		{"empty message", "", nil, false},
+		{"no manifest", "{}", nil, false},
+		{"invalid message", "checksum mismatch", nil, true},
+		{"summary",
+			`{"threescaleRelease":"2.10","operatorVersion":"0.7.0","apiManagerSpecHash":"abc","fileCount":3,"totalSize":1024,"manifestSHA256":"def"}`,
+			&appsv1alpha1.BackupManifestSummary{
+				ThreescaleRelease:  "2.10",
+				OperatorVersion:    "0.7.0",
+				APIManagerSpecHash: "abc",
+				FileCount:          3,
+				TotalSize:          1024,
+				ManifestSHA256:     "def",
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			summary, err := ParseBackupManifestSummary(tc.message)
+			if tc.err {
+				if err == nil {
+					subT.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if tc.expected == nil {
+				if summary != nil {
+					subT.Fatalf("expected nil summary, got: %v", summary)
+				}
+				return
+			}
+			if summary == nil || *summary != *tc.expected {
+				subT.Fatalf("summary differs: got: %v; expected: %v", summary, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWriteAndVerifyBackupManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup-manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"secrets/system-seed.json":          "seed",
+		"configmaps/system.json":            "system",
+		"lost+found/ignored":                "ignored",
+		"apimanager/apimanager-backup.json": "apimanager",
+	}
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summary, err := WriteBackupManifest(dir, &BackupManifest{
+		ThreescaleRelease: "2.10",
+		Namespace:         "source",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.FileCount != 3 || summary.TotalSize != 20 || summary.Namespace != "source" {
+		t.Errorf("unexpected summary: %v", summary)
+	}
+
+	verifiedSummary, err := VerifyBackupManifest(dir, "2.10", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verifiedSummary == nil || *verifiedSummary != *summary {
+		t.Errorf("verified summary differs: got: %v; expected: %v", verifiedSummary, summary)
+	}
+
+	cases := []struct {
+		name              string
+		threescaleRelease string
+		encrypted         bool
+		expectedError     string
+	}{
+		{"release mismatch", "2.11", false, "cannot be restored to 3scale release 2.11"},
+		{"encryption key provided", "2.10", true, "Backup data is not encrypted"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			_, err := VerifyBackupManifest(dir, tc.threescaleRelease, tc.encrypted)
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "secrets", "system-seed.json"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "configmaps", "system.json")); err != nil {
+		t.Fatal(err)
+	}
+	_, err = VerifyBackupManifest(dir, "2.10", false)
+	expectedError := "Backup data does not match its manifest: missing file configmaps/system.json, size mismatch in file secrets/system-seed.json"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyBackupManifestWithoutManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup-manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	summary, err := VerifyBackupManifest(dir, "2.10", false)
+	if err != ErrBackupManifestNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary != nil {
+		t.Errorf("expected nil summary, got: %v", summary)
+	}
+}
+
+func TestVerifyBackupManifestUnlistedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup-manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "secrets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "secrets", "system-seed.json"), []byte("seed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = WriteBackupManifest(dir, &BackupManifest{ThreescaleRelease: "2.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "secrets", "injected.json"), []byte("injected"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = VerifyBackupManifest(dir, "2.10", false)
+	expectedError := "Backup data does not match its manifest: unexpected file secrets/injected.json"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// ExpiredAPIManagerBackups returns the completed backups that are not kept by
+// any of the given retention rules, and the failed backups older than the
+// most recent completed backup. Running backups are never expired. Nothing is
+// expired when no retention rule is set
+func ExpiredAPIManagerBackups(backups []appsv1alpha1.APIManagerBackup, retention *appsv1alpha1.APIManagerBackupRetention) []appsv1alpha1.APIManagerBackup {
+	if retention == nil || (retention.KeepLast == nil && retention.KeepDaily == nil && retention.KeepWeekly == nil) {
+		return nil
+	}
+
+	completed := []appsv1alpha1.APIManagerBackup{}
+	for _, b := range backups {
+		if b.BackupCompleted() {
+			completed = append(completed, b)
+		}
+	}
+
+	// Most recent backups first
+	sort.SliceStable(completed, func(i, j int) bool {
+		return APIManagerBackupTime(&completed[i]).After(APIManagerBackupTime(&completed[j]))
+	})
+
+	kept := map[string]bool{}
+
+	if retention.KeepLast != nil {
+		for idx := 0; idx < len(completed) && idx < int(*retention.KeepLast); idx++ {
+			kept[completed[idx].Name] = true
+		}
+	}
+
+	keepMostRecentPerPeriod := func(keep *int32, period func(time.Time) string) {
+		if keep == nil {
+			return
+		}
+		periods := map[string]bool{}
+		for _, b := range completed {
+			if len(periods) >= int(*keep) {
+				return
+			}
+			p := period(APIManagerBackupTime(&b))
+			if !periods[p] {
+				periods[p] = true
+				kept[b.Name] = true
+			}
+		}
+	}
+
+	keepMostRecentPerPeriod(retention.KeepDaily, func(t time.Time) string {
+		return t.UTC().Format("2006-01-02")
+	})
+	keepMostRecentPerPeriod(retention.KeepWeekly, func(t time.Time) string {
+		year, week := t.UTC().ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	})
+
+	expired := []appsv1alpha1.APIManagerBackup{}
+	for _, b := range completed {
+		if !kept[b.Name] {
+			expired = append(expired, b)
+		}
+	}
+
+	// Failed backups are kept until a more recent backup completes
+	if len(completed) > 0 {
+		lastCompletedTime := APIManagerBackupTime(&completed[0])
+		for _, b := range backups {
+			if b.BackupFailed() && APIManagerBackupTime(&b).Before(lastCompletedTime) {
+				expired = append(expired, b)
+			}
+		}
+	}
+	return expired
+}
+
+// APIManagerBackupTime returns the time a backup was scheduled at when it
+// has been created by an APIManagerBackupSchedule, or its creation time
+// otherwise
+func APIManagerBackupTime(b *appsv1alpha1.APIManagerBackup) time.Time {
+	if value, ok := b.Annotations[appsv1alpha1.APIManagerBackupScheduledTimeAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return b.CreationTimestamp.Time
+}
@@ -0,0 +1,116 @@
+package backup
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testScheduledBackup(name, scheduledTime string, completed bool) appsv1alpha1.APIManagerBackup {
+	return appsv1alpha1.APIManagerBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduledTimeAnnotation: scheduledTime,
+			},
+		},
+		Status: appsv1alpha1.APIManagerBackupStatus{
+			Completed: &completed,
+		},
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestExpiredAPIManagerBackups(t *testing.T) {
+	// Two backups a day, from Monday 2020-07-06 to Sunday 2020-07-19
+	backups := []appsv1alpha1.APIManagerBackup{}
+	start := time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 14; day++ {
+		for _, hour := range []int{2, 14} {
+			t := start.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+			backups = append(backups, testScheduledBackup(t.Format("0102-15"), t.Format(time.RFC3339), true))
+		}
+	}
+	// A running backup is never expired
+	backups = append(backups, testScheduledBackup("0720-02", "2020-07-20T02:00:00Z", false))
+
+	cases := []struct {
+		name      string
+		retention *appsv1alpha1.APIManagerBackupRetention
+		kept      []string
+	}{
+		{"keep last", &appsv1alpha1.APIManagerBackupRetention{KeepLast: int32Ptr(3)},
+			[]string{"0718-14", "0719-02", "0719-14"}},
+		{"keep daily", &appsv1alpha1.APIManagerBackupRetention{KeepDaily: int32Ptr(2)},
+			[]string{"0718-14", "0719-14"}},
+		{"keep weekly", &appsv1alpha1.APIManagerBackupRetention{KeepWeekly: int32Ptr(5)},
+			[]string{"0712-14", "0719-14"}},
+		{"combined", &appsv1alpha1.APIManagerBackupRetention{KeepLast: int32Ptr(2), KeepDaily: int32Ptr(2), KeepWeekly: int32Ptr(2)},
+			[]string{"0712-14", "0718-14", "0719-02", "0719-14"}},
+		{"keep none", &appsv1alpha1.APIManagerBackupRetention{KeepLast: int32Ptr(0)},
+			[]string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			expired := map[string]bool{}
+			for _, b := range ExpiredAPIManagerBackups(backups, tc.retention) {
+				expired[b.Name] = true
+			}
+			kept := []string{}
+			for _, b := range backups {
+				if b.BackupCompleted() && !expired[b.Name] {
+					kept = append(kept, b.Name)
+				}
+			}
+			sort.Strings(kept)
+			if len(kept) != len(tc.kept) {
+				subT.Fatalf("kept backups differ: got: %v; expected: %v", kept, tc.kept)
+			}
+			for idx := range kept {
+				if kept[idx] != tc.kept[idx] {
+					subT.Fatalf("kept backups differ: got: %v; expected: %v", kept, tc.kept)
+				}
+			}
+		})
+	}
+}
+
+func TestExpiredAPIManagerBackupsNoRetention(t *testing.T) {
+	backups := []appsv1alpha1.APIManagerBackup{
+		testScheduledBackup("a", "2020-07-06T02:00:00Z", true),
+	}
+
+	if expired := ExpiredAPIManagerBackups(backups, nil); len(expired) != 0 {
+		t.Errorf("expected no expired backups, got: %v", expired)
+	}
+	if expired := ExpiredAPIManagerBackups(backups, &appsv1alpha1.APIManagerBackupRetention{}); len(expired) != 0 {
+		t.Errorf("expected no expired backups, got: %v", expired)
+	}
+}
+
+func TestExpiredAPIManagerBackupsFailed(t *testing.T) {
+	failed := true
+	failedBackup := func(name, scheduledTime string) appsv1alpha1.APIManagerBackup {
+		b := testScheduledBackup(name, scheduledTime, false)
+		b.Status.Failed = &failed
+		return b
+	}
+
+	backups := []appsv1alpha1.APIManagerBackup{
+		failedBackup("0706-02", "2020-07-06T02:00:00Z"),
+		testScheduledBackup("0707-02", "2020-07-07T02:00:00Z", true),
+		failedBackup("0708-02", "2020-07-08T02:00:00Z"),
+	}
+
+	expired := ExpiredAPIManagerBackups(backups, &appsv1alpha1.APIManagerBackupRetention{KeepLast: int32Ptr(1)})
+	if len(expired) != 1 || expired[0].Name != "0706-02" {
+		t.Errorf("unexpected expired backups: %v", expired)
+	}
+}
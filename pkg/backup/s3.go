@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	validator "github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+)
+
+// S3Options describes where the backup data objects are located in an S3 API
+// compatible object storage. Backup and restore Jobs keep working on a local
+// directory, which is uploaded to or downloaded from this location
+type S3Options struct {
+	Bucket                        string `validate:"required"`
+	Prefix                        string // Key prefix of the backup data objects. Can be empty
+	Region                        *string
+	Endpoint                      *string
+	CredentialsSecretName         string `validate:"required"`
+	ServerSideEncryptionAlgorithm *string
+	ServerSideEncryptionKMSKeyID  *string
+	AWSCLIImageURL                string `validate:"required"`
+}
+
+func NewS3Options() *S3Options {
+	return &S3Options{}
+}
+
+func (s *S3Options) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+// URL returns the s3:// URL of the backup data objects
+func (s *S3Options) URL() string {
+	prefix := strings.Trim(s.Prefix, "/")
+	if prefix == "" {
+		return fmt.Sprintf("s3://%s", s.Bucket)
+	}
+	return fmt.Sprintf("s3://%s/%s", s.Bucket, prefix)
+}
+
+// UploadContainer returns a container uploading the content of the directory
+// where the given volume is mounted
+func (s *S3Options) UploadContainer(volumeMount v1.VolumeMount) v1.Container {
+	args := fmt.Sprintf(`
+LOCAL_DIR='%s';
+S3_URL='%s';
+aws %s s3 cp --recursive --no-progress ${LOCAL_DIR}/ ${S3_URL}/ %s;
+`,
+		volumeMount.MountPath,
+		s.URL(),
+		s.awsCLIGlobalArgs(),
+		s.serverSideEncryptionArgs(),
+	)
+	return s.awsCLIContainer("s3-upload", args, volumeMount)
+}
+
+// UploadFileContainer returns a container uploading the given file, relative
+// to the directory where the given volume is mounted
+func (s *S3Options) UploadFileContainer(volumeMount v1.VolumeMount, filePath string) v1.Container {
+	args := fmt.Sprintf(`
+LOCAL_DIR='%s';
+S3_URL='%s';
+FILE_PATH='%s';
+aws %s s3 cp --no-progress ${LOCAL_DIR}/${FILE_PATH} ${S3_URL}/${FILE_PATH} %s;
+`,
+		volumeMount.MountPath,
+		s.URL(),
+		filePath,
+		s.awsCLIGlobalArgs(),
+		s.serverSideEncryptionArgs(),
+	)
+	return s.awsCLIContainer("s3-upload", args, volumeMount)
+}
+
+// DownloadContainer returns a container downloading the given subdirectories
+// of the backup data into the directory where the given volume is mounted.
+// All the backup data is downloaded when no subdirectory is given
+func (s *S3Options) DownloadContainer(volumeMount v1.VolumeMount, subdirs ...string) v1.Container {
+	if len(subdirs) == 0 {
+		args := fmt.Sprintf(`
+LOCAL_DIR='%s';
+S3_URL='%s';
+aws %s s3 cp --recursive --no-progress ${S3_URL}/ ${LOCAL_DIR}/;
+`,
+			volumeMount.MountPath,
+			s.URL(),
+			s.awsCLIGlobalArgs(),
+		)
+		return s.awsCLIContainer("s3-download", args, volumeMount)
+	}
+
+	args := fmt.Sprintf(`
+LOCAL_DIR='%s';
+S3_URL='%s';
+SUBDIRS='%s';
+for i in $(echo -n $SUBDIRS); do
+	aws %s s3 cp --recursive --no-progress ${S3_URL}/${i}/ ${LOCAL_DIR}/${i}/;
+done;
+`,
+		volumeMount.MountPath,
+		s.URL(),
+		strings.Join(subdirs, " "),
+		s.awsCLIGlobalArgs(),
+	)
+	return s.awsCLIContainer("s3-download", args, volumeMount)
+}
+
+// DeleteContainer returns a container deleting all the backup data objects
+func (s *S3Options) DeleteContainer() v1.Container {
+	args := fmt.Sprintf(`
+S3_URL='%s';
+aws %s s3 rm --recursive ${S3_URL}/;
+`,
+		s.URL(),
+		s.awsCLIGlobalArgs(),
+	)
+	return s.awsCLIContainer("s3-delete", args)
+}
+
+func (s *S3Options) awsCLIContainer(name, args string, volumeMounts ...v1.VolumeMount) v1.Container {
+	return v1.Container{
+		Name:  name,
+		Image: s.AWSCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			args,
+		},
+		Env: []v1.EnvVar{
+			helper.EnvVarFromSecret(component.AwsAccessKeyID, s.CredentialsSecretName, component.AwsAccessKeyID),
+			helper.EnvVarFromSecret(component.AwsSecretAccessKey, s.CredentialsSecretName, component.AwsSecretAccessKey),
+			// The container might run with an arbitrary UID without a writable home
+			helper.EnvVarFromValue("HOME", "/tmp"),
+		},
+		VolumeMounts: volumeMounts,
+	}
+}
+
+func (s *S3Options) awsCLIGlobalArgs() string {
+	var args []string
+	if s.Region != nil {
+		args = append(args, fmt.Sprintf("--region '%s'", *s.Region))
+	}
+	if s.Endpoint != nil {
+		args = append(args, fmt.Sprintf("--endpoint-url '%s'", *s.Endpoint))
+	}
+	return strings.Join(args, " ")
+}
+
+func (s *S3Options) serverSideEncryptionArgs() string {
+	if s.ServerSideEncryptionAlgorithm == nil {
+		return ""
+	}
+	args := fmt.Sprintf("--sse '%s'", *s.ServerSideEncryptionAlgorithm)
+	if s.ServerSideEncryptionKMSKeyID != nil {
+		args = fmt.Sprintf("%s --sse-kms-key-id '%s'", args, *s.ServerSideEncryptionKMSKeyID)
+	}
+	return args
+}
+
+// S3CredentialsSecretIsValid checks the given credentials secret contains
+// the keys used by the S3 upload and download containers
+func S3CredentialsSecretIsValid(secret *v1.Secret) error {
+	for _, key := range []string{component.AwsAccessKeyID, component.AwsSecretAccessKey} {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("Secret '%s' does not contain the '%s' key", secret.Name, key)
+		}
+	}
+	return nil
+}
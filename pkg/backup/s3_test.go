@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func testS3Options() *S3Options {
+	region := "us-east-1"
+	endpoint := "http://minio.minio.svc:9000"
+	return &S3Options{
+		Bucket:                "3scale-backups",
+		Prefix:                "/daily/example-apimanagerbackup/",
+		Region:                &region,
+		Endpoint:              &endpoint,
+		CredentialsSecretName: "s3-credentials",
+		AWSCLIImageURL:        "amazon/aws-cli:2.0.6",
+	}
+}
+
+func testS3VolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{Name: "backup-data", MountPath: "/backup"}
+}
+
+// checkAWSCLIContainer checks the common attributes of the containers
+// running the AWS CLI and returns the script they run
+func checkAWSCLIContainer(t *testing.T, container v1.Container, name string, volumeMounts []v1.VolumeMount) string {
+	if container.Name != name {
+		t.Errorf("unexpected container name: %s", container.Name)
+	}
+	if container.Image != "amazon/aws-cli:2.0.6" {
+		t.Errorf("unexpected image: %s", container.Image)
+	}
+	if !reflect.DeepEqual(container.Command, []string{"/bin/bash"}) {
+		t.Errorf("unexpected command: %v", container.Command)
+	}
+	if len(container.Args) != 3 || container.Args[0] != "-c" || container.Args[1] != "-e" {
+		t.Fatalf("unexpected args: %v", container.Args)
+	}
+	if !reflect.DeepEqual(container.VolumeMounts, volumeMounts) {
+		t.Errorf("unexpected volume mounts: %v", container.VolumeMounts)
+	}
+
+	expectedEnv := []v1.EnvVar{
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "s3-credentials"}, Key: "AWS_ACCESS_KEY_ID",
+		}}},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "s3-credentials"}, Key: "AWS_SECRET_ACCESS_KEY",
+		}}},
+		{Name: "HOME", Value: "/tmp"},
+	}
+	if !reflect.DeepEqual(container.Env, expectedEnv) {
+		t.Errorf("unexpected env vars: %v", container.Env)
+	}
+
+	return container.Args[2]
+}
+
+func checkScriptLines(t *testing.T, script string, expectedLines ...string) {
+	for _, line := range expectedLines {
+		if !strings.Contains(script, line+"\n") {
+			t.Errorf("script does not contain %q:\n%s", line, script)
+		}
+	}
+}
+
+func TestS3OptionsURL(t *testing.T) {
+	cases := []struct {
+		prefix   string
+		expected string
+	}{
+		{"", "s3://3scale-backups"},
+		{"/", "s3://3scale-backups"},
+		{"daily", "s3://3scale-backups/daily"},
+		{"/daily/example-apimanagerbackup/", "s3://3scale-backups/daily/example-apimanagerbackup"},
+	}
+
+	for _, tc := range cases {
+		s3Options := &S3Options{Bucket: "3scale-backups", Prefix: tc.prefix}
+		if s3Options.URL() != tc.expected {
+			t.Errorf("prefix %q: expected %s, got %s", tc.prefix, tc.expected, s3Options.URL())
+		}
+	}
+}
+
+func TestS3UploadContainer(t *testing.T) {
+	volumeMount := testS3VolumeMount()
+
+	t.Run("custom endpoint", func(subT *testing.T) {
+		script := checkAWSCLIContainer(subT, testS3Options().UploadContainer(volumeMount), "s3-upload", []v1.VolumeMount{volumeMount})
+		checkScriptLines(subT, script,
+			"LOCAL_DIR='/backup';",
+			"S3_URL='s3://3scale-backups/daily/example-apimanagerbackup';",
+			"aws --region 'us-east-1' --endpoint-url 'http://minio.minio.svc:9000' s3 cp --recursive --no-progress ${LOCAL_DIR}/ ${S3_URL}/ ;",
+		)
+	})
+
+	t.Run("server side encryption", func(subT *testing.T) {
+		s3Options := testS3Options()
+		sseAlgorithm := "aws:kms"
+		sseKMSKeyID := "arn:aws:kms:us-east-1:123456789012:key/backups"
+		s3Options.Endpoint = nil
+		s3Options.ServerSideEncryptionAlgorithm = &sseAlgorithm
+		s3Options.ServerSideEncryptionKMSKeyID = &sseKMSKeyID
+
+		script := checkAWSCLIContainer(subT, s3Options.UploadContainer(volumeMount), "s3-upload", []v1.VolumeMount{volumeMount})
+		checkScriptLines(subT, script,
+			"aws --region 'us-east-1' s3 cp --recursive --no-progress ${LOCAL_DIR}/ ${S3_URL}/ --sse 'aws:kms' --sse-kms-key-id 'arn:aws:kms:us-east-1:123456789012:key/backups';",
+		)
+	})
+
+	t.Run("single file", func(subT *testing.T) {
+		s3Options := testS3Options()
+		sseAlgorithm := "AES256"
+		s3Options.ServerSideEncryptionAlgorithm = &sseAlgorithm
+
+		script := checkAWSCLIContainer(subT, s3Options.UploadFileContainer(volumeMount, BackupManifestFileName), "s3-upload", []v1.VolumeMount{volumeMount})
+		checkScriptLines(subT, script,
+			"FILE_PATH='manifest.json';",
+			"aws --region 'us-east-1' --endpoint-url 'http://minio.minio.svc:9000' s3 cp --no-progress ${LOCAL_DIR}/${FILE_PATH} ${S3_URL}/${FILE_PATH} --sse 'AES256';",
+		)
+	})
+}
+
+func TestS3DownloadContainer(t *testing.T) {
+	volumeMount := testS3VolumeMount()
+
+	// Objects are decrypted by the object storage on download, so no
+	// server side encryption args are needed
+	s3Options := testS3Options()
+	sseAlgorithm := "AES256"
+	s3Options.ServerSideEncryptionAlgorithm = &sseAlgorithm
+
+	t.Run("all the backup data", func(subT *testing.T) {
+		script := checkAWSCLIContainer(subT, s3Options.DownloadContainer(volumeMount), "s3-download", []v1.VolumeMount{volumeMount})
+		checkScriptLines(subT, script,
+			"LOCAL_DIR='/backup';",
+			"S3_URL='s3://3scale-backups/daily/example-apimanagerbackup';",
+			"aws --region 'us-east-1' --endpoint-url 'http://minio.minio.svc:9000' s3 cp --recursive --no-progress ${S3_URL}/ ${LOCAL_DIR}/;",
+		)
+		if strings.Contains(script, "--sse") {
+			subT.Errorf("download script contains server side encryption args:\n%s", script)
+		}
+	})
+
+	t.Run("subdirectories", func(subT *testing.T) {
+		script := checkAWSCLIContainer(subT, s3Options.DownloadContainer(volumeMount, "secrets", "configmaps"), "s3-download", []v1.VolumeMount{volumeMount})
+		checkScriptLines(subT, script,
+			"SUBDIRS='secrets configmaps';",
+			"\taws --region 'us-east-1' --endpoint-url 'http://minio.minio.svc:9000' s3 cp --recursive --no-progress ${S3_URL}/${i}/ ${LOCAL_DIR}/${i}/;",
+		)
+	})
+}
+
+func TestS3DeleteContainer(t *testing.T) {
+	script := checkAWSCLIContainer(t, testS3Options().DeleteContainer(), "s3-delete", nil)
+	checkScriptLines(t, script,
+		"aws --region 'us-east-1' --endpoint-url 'http://minio.minio.svc:9000' s3 rm --recursive ${S3_URL}/;",
+	)
+}
@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+)
+
+const (
+	SystemDatabaseBackupSubdir     = "system-database"
+	SystemMySQLBackupFileName      = "system-mysql.sql.gz"
+	SystemPostgreSQLBackupFileName = "system-postgresql.dump"
+)
+
+const (
+	RedisBackupSubdir = "redis"
+	// RedisAOFFileName is the append only file loaded by the operator-managed
+	// Redis instances at startup
+	RedisAOFFileName = "appendonly.aof"
+	RedisCLICommand  = "/opt/rh/rh-redis5/root/usr/bin/redis-cli"
+	// RedisAOFRewriteTimeoutSeconds is how long the Redis backup waits for
+	// the rewrite of the append only file of each instance to finish
+	RedisAOFRewriteTimeoutSeconds = 600
+)
+
+// RedisBackupFileName returns the name of the file where the append only
+// file of the given Redis DeploymentConfig is stored in the backup
+func RedisBackupFileName(deploymentName string) string {
+	return fmt.Sprintf("%s.aof", deploymentName)
+}
+
+// SystemDatabaseType returns the kind of system database deployed by the
+// given APIManager. MySQL is the default internal database
+func SystemDatabaseType(apimanager *appsv1alpha1.APIManager) component.SystemDatabaseType {
+	if apimanager.IsExternalDatabaseEnabled() {
+		return component.SystemDatabaseTypeExternal
+	}
+	if apimanager.IsSystemPostgreSQLEnabled() {
+		return component.SystemDatabaseTypeInternalPostgreSQL
+	}
+	return component.SystemDatabaseTypeInternalMySQL
+}
+
+// SystemDatabaseImageURL returns the image of the internal system database
+// deployed by the given APIManager, pulled through its image registry. The
+// database client tools used to dump and load the database are taken from
+// it. Empty for external databases
+func SystemDatabaseImageURL(apimanager *appsv1alpha1.APIManager) string {
+	imageRegistry := apimanager.Spec.ImageRegistry
+	switch SystemDatabaseType(apimanager) {
+	case component.SystemDatabaseTypeInternalPostgreSQL:
+		if apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image != nil {
+			return operator.RegistryImageURL(imageRegistry, *apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image)
+		}
+		return operator.RegistryImageURL(imageRegistry, operator.SystemPostgreSQLImageURL())
+	case component.SystemDatabaseTypeInternalMySQL:
+		if apimanager.Spec.System != nil &&
+			apimanager.Spec.System.DatabaseSpec != nil &&
+			apimanager.Spec.System.DatabaseSpec.MySQL != nil &&
+			apimanager.Spec.System.DatabaseSpec.MySQL.Image != nil {
+			return operator.RegistryImageURL(imageRegistry, *apimanager.Spec.System.DatabaseSpec.MySQL.Image)
+		}
+		return operator.RegistryImageURL(imageRegistry, operator.SystemMySQLImageURL())
+	default:
+		return ""
+	}
+}
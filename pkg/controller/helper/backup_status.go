@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileBackupStatus restores the status that the capabilities custom
+// resources recreated by an APIManagerRestore had when they were backed up.
+// The status, with the 3scale IDs of the resource, is read from the backup
+// status annotation and decoded into the given status field of the object.
+// The annotation is removed afterwards. Both steps can be retried: the
+// status is only updated when it differs from the backed up one, and
+// removing an absent annotation is a no-op. The object is requeued when it
+// has been updated
+func ReconcileBackupStatus(k8sClient client.Client, logger logr.Logger, obj common.KubernetesObject, status interface{}) (reconcile.Result, error) {
+	value, ok := obj.GetAnnotations()[appsv1alpha1.BackupStatusAnnotation]
+	if !ok {
+		return reconcile.Result{}, nil
+	}
+
+	backupStatus := reflect.New(reflect.TypeOf(status).Elem())
+	err := json.Unmarshal([]byte(value), backupStatus.Interface())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !reflect.DeepEqual(backupStatus.Elem().Interface(), reflect.ValueOf(status).Elem().Interface()) {
+		reflect.ValueOf(status).Elem().Set(backupStatus.Elem())
+		err = k8sClient.Status().Update(context.TODO(), obj)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, appsv1alpha1.BackupStatusAnnotation)
+	err = k8sClient.Patch(context.TODO(), obj, client.RawPatch(types.MergePatchType, []byte(patch)))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	logger.Info("Status restored from backup")
+	return reconcile.Result{Requeue: true}, nil
+}
@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcileBackupStatus(t *testing.T) {
+	ns := "some_namespace"
+
+	s := scheme.Scheme
+	err := capabilitiesv1beta1.AddToScheme(s)
+	ok(t, err)
+
+	account := &capabilitiesv1beta1.DeveloperAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "account",
+			Namespace: ns,
+			Annotations: map[string]string{
+				appsv1alpha1.BackupStatusAnnotation: `{"accountID": 3, "accountState": "approved"}`,
+				"other":                             "value",
+			},
+		},
+	}
+
+	cl := fake.NewFakeClient(account)
+	logger := logf.Log.WithName("backup_status_test")
+
+	res, err := ReconcileBackupStatus(cl, logger, account, &account.Status)
+	ok(t, err)
+	assert(t, res.Requeue, "account not updated")
+
+	existing := &capabilitiesv1beta1.DeveloperAccount{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "account", Namespace: ns}, existing)
+	ok(t, err)
+	assert(t, existing.Status.ID != nil, "account ID not restored")
+	equals(t, *existing.Status.ID, int64(3))
+	_, annotationExists := existing.Annotations[appsv1alpha1.BackupStatusAnnotation]
+	assert(t, !annotationExists, "backup status annotation not removed")
+	equals(t, existing.Annotations["other"], "value")
+
+	res, err = ReconcileBackupStatus(cl, logger, existing, &existing.Status)
+	ok(t, err)
+	assert(t, !res.Requeue, "account without backup status annotation updated")
+}
+
+func TestReconcileBackupStatusRetry(t *testing.T) {
+	ns := "some_namespace"
+
+	s := scheme.Scheme
+	err := capabilitiesv1beta1.AddToScheme(s)
+	ok(t, err)
+
+	// Status restored by a previous attempt which failed to remove the
+	// annotation
+	var accountID int64 = 3
+	account := &capabilitiesv1beta1.DeveloperAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "account",
+			Namespace: ns,
+			Annotations: map[string]string{
+				appsv1alpha1.BackupStatusAnnotation: `{"accountID": 3}`,
+			},
+		},
+		Status: capabilitiesv1beta1.DeveloperAccountStatus{ID: &accountID},
+	}
+
+	cl := fake.NewFakeClient(account)
+	logger := logf.Log.WithName("backup_status_test")
+
+	// Stale object, as read before the previous attempt
+	stale := account.DeepCopy()
+	stale.ResourceVersion = "0"
+
+	res, err := ReconcileBackupStatus(cl, logger, stale, &stale.Status)
+	ok(t, err)
+	assert(t, res.Requeue, "account not updated")
+
+	existing := &capabilitiesv1beta1.DeveloperAccount{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "account", Namespace: ns}, existing)
+	ok(t, err)
+	_, annotationExists := existing.Annotations[appsv1alpha1.BackupStatusAnnotation]
+	assert(t, !annotationExists, "backup status annotation not removed")
+	equals(t, *existing.Status.ID, int64(3))
+}
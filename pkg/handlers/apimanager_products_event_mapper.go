@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ handler.Mapper = &APIManagerProductsEventMapper{}
+
+// APIManagerProductsEventMapper is an EventHandler that maps a Product to
+// the APIManagers of its namespace, which generate the product dashboards.
+// This handler should only be used on Product objects.
+type APIManagerProductsEventMapper struct {
+	K8sClient client.Client
+	Logger    logr.Logger
+}
+
+func (h *APIManagerProductsEventMapper) Map(mapObject handler.MapObject) []reconcile.Request {
+	h.Logger.V(2).Info("Processing meta object", "Name", mapObject.Meta.GetName(), "Namespace", mapObject.Meta.GetNamespace())
+
+	apimanagerList := &appsv1alpha1.APIManagerList{}
+	err := h.K8sClient.List(context.Background(), apimanagerList, client.InNamespace(mapObject.Meta.GetNamespace()))
+	if err != nil {
+		h.Logger.Error(err, "Could not list APIManagers", "Namespace", mapObject.Meta.GetNamespace())
+		return nil
+	}
+
+	var res []reconcile.Request
+	for _, apimanager := range apimanagerList.Items {
+		h.Logger.V(2).Info("Reenqueuing as APIManager event", "APIManager name", apimanager.Name, "APIManager namespace", apimanager.Namespace)
+		res = append(res, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      apimanager.Name,
+			Namespace: apimanager.Namespace,
+		}})
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+
+	logrtesting "github.com/go-logr/logr/testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/deprecated/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestAPIManagerProductsEventMapperMap(t *testing.T) {
+	apimanagerName := "apimanagerName"
+	apimanagerNamespace := "examplenamespace"
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apimanagerName,
+			Namespace: apimanagerNamespace,
+		},
+	}
+
+	objs := []runtime.Object{apimanager}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager, &appsv1alpha1.APIManagerList{})
+	err := capabilitiesv1beta1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClientWithScheme(s, objs...)
+
+	apimanagerProductsEventMapper := APIManagerProductsEventMapper{
+		K8sClient: cl,
+		Logger:    logrtesting.NullLogger{},
+	}
+
+	cases := []struct {
+		testName string
+		input    *capabilitiesv1beta1.Product
+		expected []reconcile.Request
+	}{
+		{
+			testName: "Event with product in the APIManager namespace is converted to an APIManager event",
+			input: &capabilitiesv1beta1.Product{
+				ObjectMeta: metav1.ObjectMeta{Name: "aproduct", Namespace: apimanagerNamespace},
+			},
+			expected: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: apimanagerNamespace, Name: apimanagerName}},
+			},
+		},
+		{
+			testName: "Event with product in a namespace without APIManager is discarded",
+			input: &capabilitiesv1beta1.Product{
+				ObjectMeta: metav1.ObjectMeta{Name: "aproduct", Namespace: "othernamespace"},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			res := apimanagerProductsEventMapper.Map(handler.MapObject{Meta: tc.input, Object: tc.input})
+			if !reflect.DeepEqual(res, tc.expected) {
+				subT.Errorf("Unexpected result: %v. Expected: %v", res, tc.expected)
+			}
+		})
+	}
+}
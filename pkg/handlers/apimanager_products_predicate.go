@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// APIManagerProductsPredicate filters the Product events that change the
+// product dashboards of the APIManagers: creations, deletions, and updates
+// of the name, the labels or the spec of the product. Status updates, made
+// on every Product reconciliation, are discarded.
+// This predicate should only be used on Product objects.
+func APIManagerProductsPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.MetaOld == nil || e.MetaNew == nil {
+				return false
+			}
+			// Generation is only increased on spec updates
+			return e.MetaOld.GetName() != e.MetaNew.GetName() ||
+				!reflect.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels()) ||
+				e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+func TestAPIManagerProductsPredicate(t *testing.T) {
+	productsPredicate := APIManagerProductsPredicate()
+
+	product := func(name string, labels map[string]string, generation int64, statusID int64) *capabilitiesv1beta1.Product {
+		return &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "examplenamespace", Labels: labels, Generation: generation},
+			Status:     capabilitiesv1beta1.ProductStatus{ID: &statusID},
+		}
+	}
+	oldProduct := product("aproduct", map[string]string{"team": "a"}, 1, 1)
+
+	if !productsPredicate.Create(event.CreateEvent{Meta: oldProduct, Object: oldProduct}) {
+		t.Error("create event discarded")
+	}
+	if !productsPredicate.Delete(event.DeleteEvent{Meta: oldProduct, Object: oldProduct}) {
+		t.Error("delete event discarded")
+	}
+	if productsPredicate.Generic(event.GenericEvent{Meta: oldProduct, Object: oldProduct}) {
+		t.Error("generic event not discarded")
+	}
+
+	cases := []struct {
+		testName   string
+		newProduct *capabilitiesv1beta1.Product
+		expected   bool
+	}{
+		{"Status update is discarded", product("aproduct", map[string]string{"team": "a"}, 1, 2), false},
+		{"Name update is accepted", product("otherproduct", map[string]string{"team": "a"}, 1, 1), true},
+		{"Label update is accepted", product("aproduct", map[string]string{"team": "b"}, 1, 1), true},
+		{"Label removal is accepted", product("aproduct", nil, 1, 1), true},
+		{"Spec update is accepted", product("aproduct", map[string]string{"team": "a"}, 2, 1), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			res := productsPredicate.Update(event.UpdateEvent{
+				MetaOld: oldProduct, ObjectOld: oldProduct,
+				MetaNew: tc.newProduct, ObjectNew: tc.newProduct,
+			})
+			if res != tc.expected {
+				subT.Errorf("Unexpected result: %t. Expected: %t", res, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronSchedule is a parsed standard cron expression. All the times are
+// evaluated in UTC
+type CronSchedule struct {
+	schedule cron.Schedule
+}
+
+// ParseCronSchedule parses a standard 5 fields cron expression
+// ("minute hour day-of-month month day-of-week") with the robfig/cron
+// standard parser. Each field accepts '*', values, ranges ("1-5"), lists
+// ("1,3,5") and steps ("*/15", "0-30/10"). Month and day of week fields also
+// accept three letter names ("jan", "mon"). The @yearly, @annually,
+// @monthly, @weekly, @daily, @midnight and @hourly macros are accepted too.
+// Intervals ("@every") and time zones are not, as they are not standard
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every") || strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid cron expression '%s': not a standard cron expression", expr)
+	}
+
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': %w", expr, err)
+	}
+
+	return &CronSchedule{schedule: schedule}, nil
+}
+
+// Next returns the first time matching the schedule strictly after the
+// given time. A zero time is returned when there is no matching time in the
+// following years, like with the "0 0 30 2 *" expression
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	return c.schedule.Next(t.UTC())
+}
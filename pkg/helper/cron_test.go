@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	cases := []struct {
+		name     string
+		expr     string
+		from     string
+		expected string
+	}{
+		{"every minute", "* * * * *", "2020-07-15T10:20:30Z", "2020-07-15T10:21:00Z"},
+		{"daily macro", "@daily", "2020-07-15T10:20:00Z", "2020-07-16T00:00:00Z"},
+		{"hourly at minute 30", "30 * * * *", "2020-07-15T10:30:00Z", "2020-07-15T11:30:00Z"},
+		{"every 15 minutes", "*/15 * * * *", "2020-07-15T10:46:00Z", "2020-07-15T11:00:00Z"},
+		{"hour range", "0 9-17 * * *", "2020-07-15T17:30:00Z", "2020-07-16T09:00:00Z"},
+		{"weekdays names", "0 2 * * mon-fri", "2020-07-17T03:00:00Z", "2020-07-20T02:00:00Z"},
+		{"sunday", "0 0 * * 0", "2020-07-15T00:00:00Z", "2020-07-19T00:00:00Z"},
+		{"month wrap", "0 0 1 * *", "2020-12-15T00:00:00Z", "2021-01-01T00:00:00Z"},
+		{"leap day", "0 0 29 feb *", "2021-01-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"dom or dow", "0 0 1 * mon", "2020-07-15T00:00:00Z", "2020-07-20T00:00:00Z"},
+		{"list", "0 0,12 * * *", "2020-07-15T00:00:00Z", "2020-07-15T12:00:00Z"},
+		{"non UTC input", "0 0 * * *", "2020-07-15T23:30:00-02:00", "2020-07-17T00:00:00Z"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			schedule, err := ParseCronSchedule(tc.expr)
+			if err != nil {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			from, _ := time.Parse(time.RFC3339, tc.from)
+			expected, _ := time.Parse(time.RFC3339, tc.expected)
+			next := schedule.Next(from)
+			if !next.Equal(expected) {
+				subT.Errorf("next time differs: got: %s; expected: %s", next, expected)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextNoMatch(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected zero time, got: %s", next)
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"@every 5m",
+		"CRON_TZ=Europe/Madrid 0 0 * * *",
+	}
+
+	for _, expr := range cases {
+		t.Run(expr, func(subT *testing.T) {
+			if _, err := ParseCronSchedule(expr); err == nil {
+				subT.Errorf("expected error parsing '%s'", expr)
+			}
+		})
+	}
+}
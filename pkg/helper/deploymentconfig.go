@@ -16,3 +16,13 @@ func IsDeploymentConfigAvailable(dc *appsv1.DeploymentConfig) bool {
 	}
 	return false
 }
+
+// IsDeploymentConfigRolledOut returns true when the latest spec of the
+// provided DeploymentConfig has been observed and all its replicas have
+// been updated and are available
+func IsDeploymentConfigRolledOut(dc *appsv1.DeploymentConfig) bool {
+	return dc.Status.ObservedGeneration >= dc.Generation &&
+		dc.Status.UpdatedReplicas == dc.Spec.Replicas &&
+		dc.Status.AvailableReplicas == dc.Spec.Replicas &&
+		IsDeploymentConfigAvailable(dc)
+}
@@ -1,11 +1,15 @@
 package helper
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // UIDBasedJobName returns a Job name that is compromised of the provided prefix,
@@ -26,3 +30,27 @@ func UIDBasedJobName(prefix string, uid types.UID) (string, error) {
 
 	return jobName, err
 }
+
+// JobContainerTerminationMessage returns the termination message of the
+// given container, or init container, in the pods of the given job
+func JobContainerTerminationMessage(k8sClient client.Client, job *batchv1.Job, containerName string) (string, error) {
+	podList := &v1.PodList{}
+	err := k8sClient.List(context.TODO(), podList,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, pod := range podList.Items {
+		statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name == containerName && status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return status.State.Terminated.Message, nil
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -114,10 +114,16 @@ func DeploymentConfigContainerResourcesMutator(desired, existing *appsv1.Deploym
 // Updated when in desired and in existing but not equal
 // Removed when not in desired and exists in existing DC
 func DeploymentConfigEnvVarReconciler(desired, existing *appsv1.DeploymentConfig, envVar string) bool {
+	return DeploymentConfigContainerEnvVarReconciler(desired, existing, 0, envVar)
+}
+
+// DeploymentConfigContainerEnvVarReconciler implements the env var reconcilliation of
+// DeploymentConfigEnvVarReconciler for the container at the given index
+func DeploymentConfigContainerEnvVarReconciler(desired, existing *appsv1.DeploymentConfig, containerIdx int, envVar string) bool {
 	update := false
 
-	existingContainer := &existing.Spec.Template.Spec.Containers[0]
-	desiredContainer := desired.Spec.Template.Spec.Containers[0]
+	existingContainer := &existing.Spec.Template.Spec.Containers[containerIdx]
+	desiredContainer := desired.Spec.Template.Spec.Containers[containerIdx]
 
 	desiredIdx := helper.FindEnvVar(desiredContainer.Env, envVar)
 	existingIdx := helper.FindEnvVar(existingContainer.Env, envVar)
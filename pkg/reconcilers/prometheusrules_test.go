@@ -0,0 +1,42 @@
+package reconcilers
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestGenericPrometheusRulesMutator(t *testing.T) {
+	desired := &monitoringv1.PrometheusRule{
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name:  "ns/apicast.rules",
+					Rules: []monitoringv1.Rule{{Alert: "SomeAlert", Expr: intstr.FromString("up == 0"), For: "5m"}},
+				},
+			},
+		},
+	}
+
+	existing := desired.DeepCopy()
+	update, err := GenericPrometheusRulesMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when existing and desired are cloned, reconciler reported update needed")
+	}
+
+	existing.Spec.Groups[0].Rules[0].For = "1m"
+	update, err = GenericPrometheusRulesMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when existing and desired are different, reconciler reported not update needed")
+	}
+	if existing.Spec.Groups[0].Rules[0].For != "5m" {
+		t.Errorf("rule for does not match. got [%s], expected [5m]", existing.Spec.Groups[0].Rules[0].For)
+	}
+}
@@ -37,6 +37,28 @@ func DefaultsOnlySecretMutator(existingObj, desiredObj common.KubernetesObject)
 	return updated, nil
 }
 
+// SecretStringDataMutator reconciles every field of the desired secret, overwriting existing values.
+// Useful for secrets whose content is entirely derived from the custom resource spec
+func SecretStringDataMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*v1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", existingObj)
+	}
+	desired, ok := desiredObj.(*v1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", desiredObj)
+	}
+
+	updated := false
+
+	for k := range desired.StringData {
+		tmpUpdated := SecretReconcileField(desired, existing, k)
+		updated = updated || tmpUpdated
+	}
+
+	return updated, nil
+}
+
 func SecretReconcileField(desired, existing *v1.Secret, fieldName string) bool {
 	updated := false
 
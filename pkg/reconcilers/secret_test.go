@@ -76,3 +76,38 @@ func TestDefaultsOnlySecretReconciler(t *testing.T) {
 		t.Fatal("existingSecret does not have a3 data")
 	}
 }
+
+func TestSecretStringDataMutator(t *testing.T) {
+	desired := &v1.Secret{
+		StringData: map[string]string{
+			"a1": "a01Value",
+			"a2": "a02Value",
+		},
+	}
+	existing := &v1.Secret{
+		StringData: map[string]string{
+			"a2": "other_a2_value",
+			"a3": "a3Value",
+		},
+	}
+	existing.Data = helper.GetSecretDataFromStringData(existing.StringData)
+
+	update, err := SecretStringDataMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !update {
+		t.Fatal("when desired fields differ, reconciler reported no update needed")
+	}
+
+	for _, key := range []string{"a1", "a2"} {
+		if existing.StringData[key] != desired.StringData[key] {
+			t.Fatalf("existingSecret data not expected for %s. Expected: '%s', got: %s", key, desired.StringData[key], existing.StringData[key])
+		}
+	}
+
+	if _, ok := existing.StringData["a3"]; !ok {
+		t.Fatal("existingSecret does not have a3 data")
+	}
+}
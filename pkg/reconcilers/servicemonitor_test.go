@@ -0,0 +1,36 @@
+package reconcilers
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+func TestGenericServiceMonitorMutator(t *testing.T) {
+	desired := &monitoringv1.ServiceMonitor{
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{{Port: "metrics", Path: "/metrics", Interval: "30s"}},
+		},
+	}
+
+	existing := desired.DeepCopy()
+	update, err := GenericServiceMonitorMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when existing and desired are cloned, reconciler reported update needed")
+	}
+
+	existing.Spec.Endpoints[0].Interval = "1m"
+	update, err = GenericServiceMonitorMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when existing and desired are different, reconciler reported not update needed")
+	}
+	if existing.Spec.Endpoints[0].Interval != "30s" {
+		t.Errorf("endpoint interval does not match. got [%s], expected [30s]", existing.Spec.Endpoints[0].Interval)
+	}
+}
@@ -2,7 +2,6 @@ package restore
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/backup"
@@ -24,8 +23,14 @@ func NewAPIManagerRestore(options *APIManagerRestoreOptions) *APIManagerRestore
 }
 
 const (
-	RestorePVCMountPath           = "/backup"
-	SystemFileStoragePVCMountPath = "/system-filestorage-pvc"
+	RestorePVCMountPath            = "/backup"
+	SystemFileStoragePVCMountPath  = "/system-filestorage-pvc"
+	restoreDataVolumeName          = "restore-data"
+	restorePlaintextDataVolumeName = "restore-plaintext-data"
+	// VerifyBackupContainerName is the name of the container of the backup
+	// verification Job writing the verification result as its termination
+	// message
+	VerifyBackupContainerName = "verify-backup"
 )
 
 var secretsToRestore map[string]string = map[string]string{
@@ -49,14 +54,36 @@ var configMapsToRestore map[string]string = map[string]string{
 	"APIcastEnvironment": "apicast-environment",
 }
 
-func (b *APIManagerRestore) restoreSourcePVCContainerVolumeMount() v1.VolumeMount {
+func (b *APIManagerRestore) restoreSourceSet() bool {
+	return b.options.APIManagerRestorePVCOptions != nil || b.options.APIManagerRestoreS3Options != nil
+}
+
+func (b *APIManagerRestore) restoreSourceContainerVolumeMount() v1.VolumeMount {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return v1.VolumeMount{
+			Name:      restoreDataVolumeName,
+			MountPath: RestorePVCMountPath,
+		}
+	}
+
 	return v1.VolumeMount{
 		Name:      b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
 		MountPath: RestorePVCMountPath,
 	}
 }
 
-func (b *APIManagerRestore) restoreSourcePVCPodVolume() v1.Volume {
+// When S3 is used the backup data needed by each job is downloaded into an
+// emptyDir volume before the job containers start
+func (b *APIManagerRestore) restoreSourcePodVolume() v1.Volume {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return v1.Volume{
+			Name: restoreDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
 	return v1.Volume{
 		Name: b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
 		VolumeSource: v1.VolumeSource{
@@ -65,6 +92,103 @@ func (b *APIManagerRestore) restoreSourcePVCPodVolume() v1.Volume {
 	}
 }
 
+// When the backup data is encrypted the job containers read the backup data
+// decrypted into an emptyDir volume. Otherwise they read the restore source
+// volume
+func (b *APIManagerRestore) restoreDataPodVolume() v1.Volume {
+	if b.options.EncryptionOptions != nil {
+		return v1.Volume{
+			Name: restorePlaintextDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return b.restoreSourcePodVolume()
+}
+
+func (b *APIManagerRestore) restoreDataContainerVolumeMount() v1.VolumeMount {
+	if b.options.EncryptionOptions != nil {
+		return v1.VolumeMount{
+			Name:      restorePlaintextDataVolumeName,
+			MountPath: RestorePVCMountPath,
+		}
+	}
+
+	return b.restoreSourceContainerVolumeMount()
+}
+
+// withRestoreSource prepends the init containers making the given backup
+// data subdirectories available to the job containers: a container
+// downloading them when S3 is used, and a container decrypting them when the
+// backup data is encrypted
+func (b *APIManagerRestore) withRestoreSource(job *batchv1.Job, subdirs ...string) *batchv1.Job {
+	_, encryptionOptions := b.restoreSourceOptions(job.Namespace)
+	if encryptionOptions == nil {
+		return b.withRestoreSourceDownload(job, b.restoreSourceContainerVolumeMount(), subdirs...)
+	}
+
+	sourceVolumeMount := b.restoreSourceContainerVolumeMount()
+	sourceVolumeMount.MountPath = backup.EncryptedBackupMountPath
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, b.restoreSourcePodVolume(), encryptionOptions.KeyPodVolume())
+	podSpec.InitContainers = append([]v1.Container{
+		encryptionOptions.DecryptContainer(sourceVolumeMount, b.restoreDataContainerVolumeMount(), subdirs...),
+	}, podSpec.InitContainers...)
+	return b.withRestoreSourceDownload(job, sourceVolumeMount, subdirs...)
+}
+
+// withRestoreSourceDownload prepends an init container downloading the given
+// backup data subdirectories into the given volume mount when S3 is used
+func (b *APIManagerRestore) withRestoreSourceDownload(job *batchv1.Job, volumeMount v1.VolumeMount, subdirs ...string) *batchv1.Job {
+	s3Options, _ := b.restoreSourceOptions(job.Namespace)
+	if s3Options == nil {
+		return job
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = append([]v1.Container{
+		s3Options.DownloadContainer(volumeMount, subdirs...),
+	}, podSpec.InitContainers...)
+	return job
+}
+
+// restoreSourceOptions returns the S3 and encryption options of the Jobs
+// created in the given namespace. The Jobs created in the overridden
+// namespace read the copies of the restore source secrets made in it
+func (b *APIManagerRestore) restoreSourceOptions(namespace string) (*backup.S3Options, *backup.EncryptionOptions) {
+	s3Options := b.options.APIManagerRestoreS3Options
+	encryptionOptions := b.options.EncryptionOptions
+	if namespace == b.options.Namespace {
+		return s3Options, encryptionOptions
+	}
+
+	if s3Options != nil {
+		s3OptionsCopy := *s3Options
+		s3OptionsCopy.CredentialsSecretName = SourceSecretCopyName(b.options.APIManagerRestoreName, s3Options.CredentialsSecretName)
+		s3Options = &s3OptionsCopy
+	}
+	if encryptionOptions != nil {
+		encryptionOptionsCopy := *encryptionOptions
+		encryptionOptionsCopy.KeySecretName = SourceSecretCopyName(b.options.APIManagerRestoreName, encryptionOptions.KeySecretName)
+		encryptionOptions = &encryptionOptionsCopy
+	}
+	return s3Options, encryptionOptions
+}
+
+// storageJobServiceAccountName returns the service account of the Jobs
+// writing the storage of the restored APIManager. They do not use the K8s
+// API, so the default service account is used when the operator one is not
+// available in the overridden namespace
+func (b *APIManagerRestore) storageJobServiceAccountName() string {
+	if b.options.TargetNamespace != b.options.Namespace {
+		return ""
+	}
+	return "3scale-operator"
+}
+
 func (b *APIManagerRestore) systemFileStoragePVCContainerVolumeMount() v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      component.SystemFileStoragePVCName,
@@ -83,8 +207,58 @@ func (b *APIManagerRestore) systemFileStoragePVCPodVolume() v1.Volume {
 	}
 }
 
+// VerifyBackupJob checks the backup data against the backup manifest before
+// anything is restored. The job fails, without being retried, when a file
+// checksum does not match or when the backup was performed from a different
+// 3scale release. Encrypted backup data is verified as stored, before it is
+// decrypted. The verification result is written as termination message of
+// the verification container
+func (b *APIManagerRestore) VerifyBackupJob() *batchv1.Job {
+	if !b.restoreSourceSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("verify-backup", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	var backoffLimit int32 = 0
+	return b.withRestoreSourceDownload(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreSourcePodVolume(),
+					},
+					Containers: []v1.Container{
+						backup.BackupAgentContainer(VerifyBackupContainerName, b.options.BackupAgentImageURL,
+							b.verifyBackupAgentArgs(),
+							b.restoreSourceContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}, b.restoreSourceContainerVolumeMount())
+}
+
 func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if !b.restoreSourceSet() {
 		return nil
 	}
 
@@ -94,7 +268,7 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withRestoreSource(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -104,16 +278,61 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 			Namespace: b.options.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
+						b.restoreDataPodVolume(),
+					},
+					Containers: []v1.Container{
+						backup.BackupAgentContainer("restore-cfgmaps-secrets", b.options.BackupAgentImageURL,
+							b.restoreSecretsAndConfigMapsAgentArgs(),
+							b.restoreDataContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}, "secrets", "configmaps")
+}
+
+func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job {
+	if !b.restoreSourceSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("restore-system-fs", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return b.withRestoreSource(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.TargetNamespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreDataPodVolume(),
+						b.systemFileStoragePVCPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "restore-cfgmaps-secrets",
+							Name:  "backup-system-filestorage-pvc",
 							Image: b.options.OCCLIImageURL,
 							Command: []string{
 								"/bin/bash",
@@ -121,34 +340,35 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 							Args: []string{
 								"-c",
 								"-e",
-								b.restoreSecretsAndConfigMapsContainerArgs(),
+								b.restoreSystemFilestoragePVCContainerArgs(),
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
+								b.restoreDataContainerVolumeMount(),
+								b.systemFileStoragePVCContainerVolumeMount(),
 							},
 						},
 					},
-					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					ServiceAccountName: b.storageJobServiceAccountName(),
 					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
 				},
 			},
 		},
-	}
+	}, "system-filestorage-pvc")
 }
 
-func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
+	if !b.restoreSourceSet() {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("restore-system-fs", b.options.APIManagerRestoreUID)
+	jobName, err := helper.UIDBasedJobName("restore-apm-tosecret", b.options.APIManagerRestoreUID)
 	if err != nil {
 		panic(err)
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withRestoreSource(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -158,17 +378,57 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 			Namespace: b.options.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried. Should we limit to 1?
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
-						b.systemFileStoragePVCPodVolume(),
+						b.restoreDataPodVolume(),
 					},
+					Containers: []v1.Container{
+						backup.BackupAgentContainer("job", b.options.BackupAgentImageURL,
+							b.createAPIManagerSharedSecretAgentArgs(),
+							b.restoreDataContainerVolumeMount(),
+						),
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}, "apimanager")
+}
+
+func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
+	if !b.restoreSourceSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("resync-domains", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "backup-system-filestorage-pvc",
+							Name:  "job",
 							Image: b.options.OCCLIImageURL,
 							Command: []string{
 								"/bin/bash",
@@ -176,13 +436,9 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 							Args: []string{
 								"-c",
 								"-e",
-								b.restoreSystemFilestoragePVCContainerArgs(),
+								b.zyncResyncDomainsContainerArgs(),
 							},
 							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
-								b.systemFileStoragePVCContainerVolumeMount(),
-							},
 						},
 					},
 					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
@@ -193,88 +449,114 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 	}
 }
 
-func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+// RestoreSystemDatabaseFromPVCJob loads the internal system database dump
+// stored in the restore source. It returns nil when the system database
+// of the restored APIManager is external
+func (b *APIManagerRestore) RestoreSystemDatabaseFromPVCJob(restoreInfo *RuntimeAPIManagerRestoreInfo) *batchv1.Job {
+	if !b.restoreSourceSet() {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("restore-apm-tosecret", b.options.APIManagerRestoreUID)
+	var containerArgs string
+	switch restoreInfo.SystemDatabaseType {
+	case component.SystemDatabaseTypeInternalMySQL:
+		containerArgs = b.restoreSystemMySQLContainerArgs()
+	case component.SystemDatabaseTypeInternalPostgreSQL:
+		containerArgs = b.restoreSystemPostgreSQLContainerArgs()
+	default:
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("restore-system-database", b.options.APIManagerRestoreUID)
 	if err != nil {
 		panic(err)
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withRestoreSource(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
-			Namespace: b.options.Namespace,
+			Namespace: b.options.TargetNamespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
+						b.restoreDataPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "job",
-							Image: b.options.OCCLIImageURL,
+							Name:  "restore-system-database",
+							Image: restoreInfo.SystemDatabaseImageURL,
 							Command: []string{
 								"/bin/bash",
 							},
 							Args: []string{
 								"-c",
 								"-e",
-								b.createAPIManagerSharedSecretContainerArgs(),
+								containerArgs,
+							},
+							Env: []v1.EnvVar{
+								helper.EnvVarFromSecret("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
 							},
-							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
+								b.restoreDataContainerVolumeMount(),
 							},
 						},
 					},
-					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					ServiceAccountName: b.storageJobServiceAccountName(),
 					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
 				},
 			},
 		},
-	}
+	}, backup.SystemDatabaseBackupSubdir)
 }
 
-func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+// RestoreRedisFromPVCJob copies the backed up append only files into the
+// backend-redis and system-redis PVCs. It has to be run before the Redis
+// DeploymentConfigs are deployed. It returns nil when the Redis databases of
+// the restored APIManager are external
+func (b *APIManagerRestore) RestoreRedisFromPVCJob(restoreInfo *RuntimeAPIManagerRestoreInfo) *batchv1.Job {
+	if !b.restoreSourceSet() || !restoreInfo.InternalRedisDatabases {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("resync-domains", b.options.APIManagerRestoreUID)
+	jobName, err := helper.UIDBasedJobName("restore-redis", b.options.APIManagerRestoreUID)
 	if err != nil {
 		panic(err)
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	return b.withRestoreSource(&batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
-			Namespace: b.options.Namespace,
+			Namespace: b.options.TargetNamespace,
 		},
 		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreDataPodVolume(),
+						b.redisPVCPodVolume(component.BackendRedisPVCName),
+						b.redisPVCPodVolume(component.SystemRedisPVCName),
+					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:  "job",
+							Name:  "restore-redis",
 							Image: b.options.OCCLIImageURL,
 							Command: []string{
 								"/bin/bash",
@@ -282,16 +564,84 @@ func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
 							Args: []string{
 								"-c",
 								"-e",
-								b.zyncResyncDomainsContainerArgs(),
+								b.restoreRedisContainerArgs(),
 							},
 							//Env: []v1.EnvVar{},
+							VolumeMounts: []v1.VolumeMount{
+								b.restoreDataContainerVolumeMount(),
+								b.redisPVCContainerVolumeMount(component.BackendRedisPVCName),
+								b.redisPVCContainerVolumeMount(component.SystemRedisPVCName),
+							},
 						},
 					},
+					ServiceAccountName: b.storageJobServiceAccountName(),
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}, backup.RedisBackupSubdir)
+}
+
+// RestoreCapabilitiesFromPVCJob recreates the capabilities custom resources,
+// and the secrets they reference, when they have been included in the backup
+func (b *APIManagerRestore) RestoreCapabilitiesFromPVCJob() *batchv1.Job {
+	if !b.restoreSourceSet() {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("restore-capabilities", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	return b.withRestoreSource(&batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:           &completions,
+			BackoffLimit:          b.options.JobOptions.BackoffLimit,
+			ActiveDeadlineSeconds: b.options.JobOptions.ActiveDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreDataPodVolume(),
+					},
+					Containers: []v1.Container{
+						backup.BackupAgentContainer("restore-capabilities", b.options.BackupAgentImageURL,
+							b.restoreCapabilitiesAgentArgs(),
+							b.restoreDataContainerVolumeMount(),
+						),
+					},
 					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
 					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
 				},
 			},
 		},
+	}, backup.CapabilitiesBackupSubdir)
+}
+
+func (b *APIManagerRestore) redisPVCContainerVolumeMount(pvcName string) v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      pvcName,
+		MountPath: fmt.Sprintf("/%s", pvcName),
+	}
+}
+
+func (b *APIManagerRestore) redisPVCPodVolume(pvcName string) v1.Volume {
+	return v1.Volume{
+		Name: pvcName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvcName,
+			},
+		},
 	}
 }
 
@@ -303,7 +653,7 @@ func (b *APIManagerRestore) SystemStoragePVC(restoreInfo *RuntimeAPIManagerResto
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      component.SystemFileStoragePVCName,
-			Namespace: b.options.Namespace,
+			Namespace: b.options.TargetNamespace,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
 			StorageClassName: restoreInfo.PVCStorageClass,
@@ -347,58 +697,157 @@ func (b *APIManagerRestore) restoreSystemFilestoragePVCContainerArgs() string {
 	)
 }
 
-func (b *APIManagerRestore) createAPIManagerSharedSecretContainerArgs() string {
+func (b *APIManagerRestore) createAPIManagerSharedSecretAgentArgs() []string {
+	return []string{
+		"share-apimanager",
+		"--namespace", b.options.Namespace,
+		"--source", RestorePVCMountPath,
+		"--secret-name", b.SecretToShareName(),
+	}
+}
+
+// The system-environment ConfigMap is updated when the wildcard domain is
+// overridden
+func (b *APIManagerRestore) restoreSecretsAndConfigMapsAgentArgs() []string {
+	args := []string{
+		"restore-objects",
+		"--namespace", b.options.TargetNamespace,
+		"--source", RestorePVCMountPath,
+		"--wildcard-domain", b.options.WildcardDomain,
+	}
+	args = append(args, backup.BackupAgentRepeatedFlagArgs("--secret", helper.SortedMapStringStringValues(secretsToRestore))...)
+	args = append(args, backup.BackupAgentRepeatedFlagArgs("--configmap", helper.SortedMapStringStringValues(configMapsToRestore))...)
+	return args
+}
+
+// Secret references pointing to the namespace of the backed up APIManager
+// are moved to the namespace the APIManager is restored into
+func (b *APIManagerRestore) restoreCapabilitiesAgentArgs() []string {
+	return []string{
+		"restore-capabilities",
+		"--namespace", b.options.TargetNamespace,
+		"--source", RestorePVCMountPath,
+		"--source-namespace", b.options.SourceNamespace,
+	}
+}
+
+func (b *APIManagerRestore) verifyBackupAgentArgs() []string {
+	return []string{
+		"verify-manifest",
+		"--source", RestorePVCMountPath,
+		"--threescale-release", b.options.ThreescaleRelease,
+		fmt.Sprintf("--encrypted=%t", b.options.EncryptionOptions != nil),
+		fmt.Sprintf("--allow-missing-manifest=%t", b.options.AllowBackupWithoutManifest),
+	}
+}
+
+// When the wildcard domain is overridden, the tenant and APIcast domains
+// stored in the system database are moved to the new wildcard domain before
+// the zync domains are resynchronized
+func (b *APIManagerRestore) zyncResyncDomainsContainerArgs() string {
 	return fmt.Sprintf(`
-  BASEPATH='%s';
-  APIMANAGER_BACKUP_SUBDDIR="${BASEPATH}/apimanager";
-  SECRET_TO_SHARE='%s';
-  APIMANAGER_BACKUP_FILENAME="%s";
-  oc create secret generic ${SECRET_TO_SHARE} --from-file=${APIMANAGER_BACKUP_SUBDDIR}/${APIMANAGER_BACKUP_FILENAME};
+	NAMESPACE='%s';
+	dcname="system-sidekiq"
+	dcpods=$(oc get pods -n ${NAMESPACE} --ignore-not-found=true -l deploymentconfig=${dcname} --no-headers=true -o custom-columns=:metadata.name)
+	if [ -z "${dcpods}" ]; then
+		echo "No pods found for Deployment ${dcname} in namespace ${NAMESPACE}"
+		exit 1
+	fi
+	podname=$(echo -n $dcpods | awk '{print $1}')
+	MOVE_DOMAINS='%t';
+	RUBY_MOVE_DOMAINS_SUBSCRIPT="%s"
+	if [ "${MOVE_DOMAINS}" = "true" ]; then
+		oc exec -n ${NAMESPACE} ${podname} bash -- -c 'bundle exec rails runner "$0"' "${RUBY_MOVE_DOMAINS_SUBSCRIPT}"
+	fi
+	oc exec -n ${NAMESPACE} ${podname} bash -- -c "bundle exec rake zync:resync:domains"
+`,
+		b.options.TargetNamespace,
+		b.wildcardDomainMoved(),
+		b.rubyMoveDomainsScript(),
+	)
+}
+
+func (b *APIManagerRestore) wildcardDomainMoved() bool {
+	return b.options.WildcardDomain != "" && b.options.SourceWildcardDomain != "" &&
+		b.options.WildcardDomain != b.options.SourceWildcardDomain
+}
+
+// Domains already in the new wildcard domain are kept so the script can be
+// run more than once
+func (b *APIManagerRestore) rubyMoveDomainsScript() string {
+	return fmt.Sprintf(`
+sourceSuffix = %%q(.%s)
+targetSuffix = %%q(.%s)
+
+moveDomain = lambda do |domain|
+  return domain if domain.blank? || domain.end_with?(targetSuffix) || !domain.end_with?(sourceSuffix)
+  domain.chomp(sourceSuffix) + targetSuffix
+end
+
+accountColumns = %%w(domain self_domain internal_domain internal_admin_domain) & Account.column_names
+Account.find_each do |account|
+  changes = accountColumns.each_with_object({}) { |c, h| h[c] = moveDomain.call(account[c]) }
+  account.update_columns(changes) unless changes.empty?
+end
+
+proxyColumns = %%w(endpoint sandbox_endpoint) & Proxy.column_names
+Proxy.find_each do |proxy|
+  changes = proxyColumns.each_with_object({}) do |c, h|
+    next if proxy[c].blank?
+    uri = URI.parse(proxy[c])
+    uri.host = moveDomain.call(uri.host)
+    h[c] = uri.to_s
+  end
+  proxy.update_columns(changes) unless changes.empty?
+end
+`, b.options.SourceWildcardDomain, b.options.WildcardDomain)
+}
+
+// The database URL is split at its last '@' as the password can contain it
+func (b *APIManagerRestore) restoreSystemMySQLContainerArgs() string {
+	return fmt.Sprintf(`
+	set -o pipefail;
+	BASEPATH='%s';
+	DUMP_FILE="${BASEPATH}/%s/%s";
+	URL_NO_SCHEME="${DATABASE_URL#*://}";
+	DB_CREDENTIALS="${URL_NO_SCHEME%%@*}";
+	DB_HOST_AND_NAME="${URL_NO_SCHEME##*@}";
+	DB_USER="${DB_CREDENTIALS%%%%:*}";
+	export MYSQL_PWD="${DB_CREDENTIALS#*:}";
+	DB_HOST="${DB_HOST_AND_NAME%%%%/*}";
+	DB_NAME="${DB_HOST_AND_NAME#*/}";
+	gunzip -c ${DUMP_FILE} | mysql -h "${DB_HOST}" -u "${DB_USER}" "${DB_NAME}";
 `,
 		RestorePVCMountPath,
-		b.SecretToShareName(),
-		backup.APIManagerSerializedBackupFileName,
+		backup.SystemDatabaseBackupSubdir,
+		backup.SystemMySQLBackupFileName,
 	)
 }
 
-func (b *APIManagerRestore) restoreSecretsAndConfigMapsContainerArgs() string {
+func (b *APIManagerRestore) restoreSystemPostgreSQLContainerArgs() string {
 	return fmt.Sprintf(`
-	SECRETS='%s';
-	CONFIGMAPS='%s';
 	BASEPATH='%s';
-	for i in $(echo -n $SECRETS); do
-		res=$(oc get secret ${i} --ignore-not-found=true)
-		if [ -z "${res}" ]; then
-			oc create -f ${BASEPATH}/secrets/${i}.json
-		else
-			echo "Secret ${i} already exists. Skipping restore of the secret"
-		fi
-	done;
-	
-	for i in $(echo -n $CONFIGMAPS); do
-		res=$(oc get configmap ${i} --ignore-not-found=true);
-		if [ -z "${res}" ]; then
-			oc create -f ${BASEPATH}/configmaps/${i}.json;
-		else
-			echo "ConfigMap '${i}' already exists. Skipping restore of the ConfigMap";
-		fi
-	done;
+	DUMP_FILE="${BASEPATH}/%s/%s";
+	pg_restore --clean --if-exists --no-owner --no-privileges --dbname="${DATABASE_URL}" ${DUMP_FILE};
 `,
-		strings.Join(helper.SortedMapStringStringValues(secretsToRestore), " "),
-		strings.Join(helper.SortedMapStringStringValues(configMapsToRestore), " "),
 		RestorePVCMountPath,
+		backup.SystemDatabaseBackupSubdir,
+		backup.SystemPostgreSQLBackupFileName,
 	)
 }
 
-func (b *APIManagerRestore) zyncResyncDomainsContainerArgs() string {
-	return `
-	dcname="system-sidekiq"
-	dcpods=$(oc get pods --ignore-not-found=true -l deploymentconfig=${dcname} --no-headers=true -o custom-columns=:metadata.name)
-	if [ -z "${dcpods}" ]; then
-		echo "No pods found for Deployment ${dcname}"
-		exit 1
-	fi
-	podname=$(echo -n $dcpods | awk '{print $1}')
-	oc exec ${podname} bash -- -c "bundle exec rake zync:resync:domains"
-`
+func (b *APIManagerRestore) restoreRedisContainerArgs() string {
+	return fmt.Sprintf(`
+	BASEPATH='%s';
+	REDIS_SUBDIR="${BASEPATH}/%s";
+	REDIS_AOF_FILENAME='%s';
+	cp ${REDIS_SUBDIR}/%s /%s/${REDIS_AOF_FILENAME};
+	cp ${REDIS_SUBDIR}/%s /%s/${REDIS_AOF_FILENAME};
+`,
+		RestorePVCMountPath,
+		backup.RedisBackupSubdir,
+		backup.RedisAOFFileName,
+		backup.RedisBackupFileName(component.BackendRedisDeploymentName), component.BackendRedisPVCName,
+		backup.RedisBackupFileName(component.SystemRedisDeploymentName), component.SystemRedisPVCName,
+	)
 }
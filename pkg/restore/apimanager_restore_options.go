@@ -1,17 +1,28 @@
 package restore
 
 import (
+	"github.com/3scale/3scale-operator/pkg/backup"
 	validator "github.com/go-playground/validator/v10"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 type APIManagerRestoreOptions struct {
-	Namespace             string    `validate:"required"` // Namespace where the K8s related objects to the restore will be created/looked
+	Namespace             string    `validate:"required"` // Namespace of the APIManagerRestore. The restore Jobs using the K8s API are created in it
+	TargetNamespace       string    `validate:"required"` // Namespace the APIManager is restored into. The restore Jobs writing its storage are created in it
 	APIManagerRestoreName string    `validate:"required"` // Name of the APIManagerRestore CR. NOT the backup or APIManager name
 	APIManagerRestoreUID  types.UID `validate:"required"` // UID of the APIManagerRestore CR
 
-	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions `validate:"required"`
-	OCCLIImageURL               string                       `validate:"required"`
+	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions // Only one restore source is set
+	APIManagerRestoreS3Options  *backup.S3Options
+	EncryptionOptions           *backup.EncryptionOptions // Nil when the backup data is not encrypted
+	JobOptions                  *backup.JobOptions        `validate:"required"`
+	OCCLIImageURL               string                    `validate:"required"`
+	BackupAgentImageURL         string                    `validate:"required"` // Operator image running the backup agent
+	ThreescaleRelease           string                    `validate:"required"` // Only backups of this 3scale release can be restored
+	WildcardDomain              string                    // Wildcard domain of the restored APIManager. Empty when it is not overridden
+	SourceWildcardDomain        string                    // Wildcard domain of the backed up APIManager. Empty when the backup has no manifest
+	SourceNamespace             string                    // Namespace of the backed up APIManager. Empty when the backup has no manifest
+	AllowBackupWithoutManifest  bool                      // Backups without manifest are restored without verification
 }
 
 func NewAPIManagerRestoreOptions() *APIManagerRestoreOptions {
@@ -1,11 +1,17 @@
 package restore
 
 import (
+	"context"
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,20 +32,51 @@ func (a *APIManagerRestoreOptionsProvider) Options() (*APIManagerRestoreOptions,
 	res.APIManagerRestoreName = a.APIManagerRestoreCR.Name
 	res.APIManagerRestoreUID = a.APIManagerRestoreCR.UID
 	res.Namespace = a.APIManagerRestoreCR.Namespace
+	res.TargetNamespace = a.APIManagerRestoreCR.TargetNamespace()
 
-	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.OCCLIImageURL = operator.RegistryImageURL(a.imageRegistry(), a.ocCLIImageURL())
+	res.BackupAgentImageURL = operator.RegistryImageURL(a.imageRegistry(), operator.BackupAgentImageURL())
+	res.JobOptions = backup.JobOptionsFromSpec(a.APIManagerRestoreCR.Spec.Jobs)
+	res.ThreescaleRelease = product.ThreescaleRelease
 
 	pvcOptions, err := a.pvcRestoreOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3RestoreOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerRestore struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one restore source has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one restore source can be specified")
+	}
 
 	res.APIManagerRestorePVCOptions = pvcOptions
+	res.APIManagerRestoreS3Options = s3Options
+
+	encryptionOptions, err := backup.EncryptionOptionsFromSecretRef(a.Client, a.APIManagerRestoreCR.Namespace, a.APIManagerRestoreCR.Spec.EncryptionKeySecretRef, a.imageRegistry())
+	if err != nil {
+		return nil, err
+	}
+	res.EncryptionOptions = encryptionOptions
+	res.AllowBackupWithoutManifest = a.APIManagerRestoreCR.BackupWithoutManifestAllowed()
+
+	overrides := a.APIManagerRestoreCR.Spec.Overrides
+	if overrides != nil && overrides.WildcardDomain != nil {
+		res.WildcardDomain = *overrides.WildcardDomain
+	}
+
+	// The backup manifest is available once the backup data has been verified
+	if backupManifest := a.APIManagerRestoreCR.Status.BackupManifest; backupManifest != nil {
+		res.SourceWildcardDomain = backupManifest.WildcardDomain
+		res.SourceNamespace = backupManifest.Namespace
+	}
 
 	return res, res.Validate()
 }
@@ -55,6 +92,44 @@ func (a *APIManagerRestoreOptionsProvider) pvcRestoreOptions() (*APIManagerResto
 	return res, res.Validate()
 }
 
+func (a *APIManagerRestoreOptionsProvider) s3RestoreOptions() (*backup.S3Options, error) {
+	s3Spec := a.APIManagerRestoreCR.Spec.RestoreSource.S3
+	if s3Spec == nil {
+		return nil, nil
+	}
+
+	secret := &v1.Secret{}
+	err := a.Client.Get(context.TODO(), types.NamespacedName{Name: s3Spec.CredentialsSecretRef.Name, Namespace: a.APIManagerRestoreCR.Namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = backup.S3CredentialsSecretIsValid(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	res := backup.NewS3Options()
+	res.Bucket = s3Spec.Bucket
+	if s3Spec.Prefix != nil {
+		res.Prefix = *s3Spec.Prefix
+	}
+	res.Region = s3Spec.Region
+	res.Endpoint = s3Spec.Endpoint
+	res.CredentialsSecretName = s3Spec.CredentialsSecretRef.Name
+	res.AWSCLIImageURL = operator.RegistryImageURL(a.imageRegistry(), backup.AWSCLIImageURL())
+
+	return res, res.Validate()
+}
+
+// imageRegistry returns the image registry the restore Jobs images are
+// pulled through. Nil when it is not overridden
+func (a *APIManagerRestoreOptionsProvider) imageRegistry() *appsv1alpha1.ImageRegistrySpec {
+	if a.APIManagerRestoreCR.Spec.Overrides == nil {
+		return nil
+	}
+	return a.APIManagerRestoreCR.Spec.Overrides.ImageRegistry
+}
+
 func (a *APIManagerRestoreOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_OC_CLI", component.OCCLIImageURL())
 }
@@ -0,0 +1,387 @@
+package restore
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testRestoreNamespace = "operator-test"
+	testRestoreName      = "example-apimanagerrestore"
+)
+
+func testRestoreOptions() *APIManagerRestoreOptions {
+	var backoffLimit int32 = 3
+	return &APIManagerRestoreOptions{
+		Namespace:             testRestoreNamespace,
+		TargetNamespace:       testRestoreNamespace,
+		APIManagerRestoreName: testRestoreName,
+		APIManagerRestoreUID:  "b6ee6a0c-1c40-4a43-9b0c-47b06a8c64d1",
+		APIManagerRestoreS3Options: &backup.S3Options{
+			Bucket:                "3scale-backups",
+			Prefix:                "daily/example-apimanagerbackup",
+			CredentialsSecretName: "s3-credentials",
+			AWSCLIImageURL:        "amazon/aws-cli:2.0.6",
+		},
+		EncryptionOptions: &backup.EncryptionOptions{
+			KeySecretName:       "backup-encryption-key",
+			BackupAgentImageURL: "quay.io/3scale/3scale-operator:master",
+		},
+		JobOptions:          &backup.JobOptions{BackoffLimit: &backoffLimit},
+		OCCLIImageURL:       "quay.io/openshift/origin-cli:4.7",
+		BackupAgentImageURL: "quay.io/3scale/3scale-operator:master",
+		ThreescaleRelease:   "2.10",
+	}
+}
+
+func testRestoreInfo() *RuntimeAPIManagerRestoreInfo {
+	return &RuntimeAPIManagerRestoreInfo{
+		SystemDatabaseType:     component.SystemDatabaseTypeInternalMySQL,
+		SystemDatabaseImageURL: "centos/mysql-57-centos7",
+		InternalRedisDatabases: true,
+	}
+}
+
+// jobContainer returns the container, or init container, of the job with
+// the given name
+func jobContainer(t *testing.T, job *batchv1.Job, name string) v1.Container {
+	podSpec := job.Spec.Template.Spec
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		if container.Name == name {
+			return container
+		}
+	}
+	t.Fatalf("job '%s' has no container '%s'", job.Name, name)
+	return v1.Container{}
+}
+
+// jobSecretNames returns the secrets the containers of the job read, as
+// environment variables or as volumes
+func jobSecretNames(job *batchv1.Job) []string {
+	names := []string{}
+	podSpec := job.Spec.Template.Spec
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		for _, envVar := range container.Env {
+			if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+				names = append(names, envVar.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			names = append(names, volume.Secret.SecretName)
+		}
+	}
+	return names
+}
+
+func argsContain(args []string, expected ...string) bool {
+	return strings.Contains(strings.Join(args, " "), strings.Join(expected, " "))
+}
+
+func TestAPIManagerRestoreNamespaceOverride(t *testing.T) {
+	options := testRestoreOptions()
+	options.TargetNamespace = "3scale-dr"
+	apiManagerRestore := NewAPIManagerRestore(options)
+	restoreInfo := testRestoreInfo()
+
+	// The Jobs writing the storage of the APIManager run in its namespace,
+	// reading the copies of the restore source secrets
+	storageJobs := []*batchv1.Job{
+		apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
+		apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo),
+		apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo),
+	}
+	for _, job := range storageJobs {
+		if job.Namespace != "3scale-dr" {
+			t.Errorf("job '%s' created in namespace '%s'", job.Name, job.Namespace)
+		}
+		if job.Spec.Template.Spec.ServiceAccountName != "" {
+			t.Errorf("job '%s' uses the service account '%s'", job.Name, job.Spec.Template.Spec.ServiceAccountName)
+		}
+		for _, secretName := range jobSecretNames(job) {
+			if secretName == "s3-credentials" || secretName == "backup-encryption-key" {
+				t.Errorf("job '%s' reads the restore source secret '%s' instead of its copy", job.Name, secretName)
+			}
+		}
+		secretNames := strings.Join(jobSecretNames(job), ",")
+		for _, copyName := range []string{"example-apimanagerrestore-s3-credentials", "example-apimanagerrestore-backup-encryption-key"} {
+			if !strings.Contains(secretNames, copyName) {
+				t.Errorf("job '%s' does not read the secret copy '%s': %s", job.Name, copyName, secretNames)
+			}
+		}
+	}
+
+	if pvc := apiManagerRestore.SystemStoragePVC(restoreInfo); pvc.Namespace != "3scale-dr" {
+		t.Errorf("system storage PVC created in namespace '%s'", pvc.Namespace)
+	}
+
+	// The Jobs using the K8s API run in the namespace of the restore and
+	// target the overridden one
+	objectsJob := apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob()
+	capabilitiesJob := apiManagerRestore.RestoreCapabilitiesFromPVCJob()
+	zyncJob := apiManagerRestore.ZyncResyncDomainsJob()
+	for _, job := range []*batchv1.Job{objectsJob, capabilitiesJob, zyncJob} {
+		if job.Namespace != testRestoreNamespace {
+			t.Errorf("job '%s' created in namespace '%s'", job.Name, job.Namespace)
+		}
+		if job.Spec.Template.Spec.ServiceAccountName != "3scale-operator" {
+			t.Errorf("job '%s' uses the service account '%s'", job.Name, job.Spec.Template.Spec.ServiceAccountName)
+		}
+	}
+	if args := jobContainer(t, objectsJob, "restore-cfgmaps-secrets").Args; !argsContain(args, "restore-objects", "--namespace", "3scale-dr") {
+		t.Errorf("unexpected restore objects args: %v", args)
+	}
+	if args := jobContainer(t, capabilitiesJob, "restore-capabilities").Args; !argsContain(args, "restore-capabilities", "--namespace", "3scale-dr") {
+		t.Errorf("unexpected restore capabilities args: %v", args)
+	}
+	if secretNames := strings.Join(jobSecretNames(objectsJob), ","); !strings.Contains(secretNames, "s3-credentials") || strings.Contains(secretNames, "example-apimanagerrestore-") {
+		t.Errorf("job '%s' does not read the restore source secrets: %s", objectsJob.Name, secretNames)
+	}
+
+	script := jobContainer(t, zyncJob, "job").Args[2]
+	for _, line := range []string{
+		"NAMESPACE='3scale-dr';",
+		"dcpods=$(oc get pods -n ${NAMESPACE} --ignore-not-found=true -l deploymentconfig=${dcname} --no-headers=true -o custom-columns=:metadata.name)",
+		"oc exec -n ${NAMESPACE} ${podname} bash -- -c \"bundle exec rake zync:resync:domains\"",
+	} {
+		if !strings.Contains(script, line) {
+			t.Errorf("zync resync script does not contain %q:\n%s", line, script)
+		}
+	}
+}
+
+func TestAPIManagerRestoreSameNamespace(t *testing.T) {
+	apiManagerRestore := NewAPIManagerRestore(testRestoreOptions())
+
+	job := apiManagerRestore.RestoreRedisFromPVCJob(testRestoreInfo())
+	if job.Namespace != testRestoreNamespace || job.Spec.Template.Spec.ServiceAccountName != "3scale-operator" {
+		t.Errorf("unexpected job namespace '%s' and service account '%s'", job.Namespace, job.Spec.Template.Spec.ServiceAccountName)
+	}
+	secretNames := strings.Join(jobSecretNames(job), ",")
+	if secretNames != "s3-credentials,s3-credentials,backup-encryption-key" {
+		t.Errorf("unexpected secrets: %s", secretNames)
+	}
+}
+
+func TestValidateTargetNamespace(t *testing.T) {
+	s3Source := appsv1alpha1.APIManagerRestoreSource{S3: &appsv1alpha1.S3Location{}}
+	pvcSource := appsv1alpha1.APIManagerRestoreSource{PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimRestoreSource{}}
+
+	cases := []struct {
+		name            string
+		source          appsv1alpha1.APIManagerRestoreSource
+		namespace       *string
+		watchNamespace  string
+		expectedFailure string
+	}{
+		{"not overridden", pvcSource, nil, testRestoreNamespace, ""},
+		{"same namespace", pvcSource, stringPtr(testRestoreNamespace), testRestoreNamespace, ""},
+		{"all namespaces watched", s3Source, stringPtr("3scale-dr"), "", ""},
+		{"namespace watched", s3Source, stringPtr("3scale-dr"), "operator-test,3scale-dr", ""},
+		{"namespace not watched", s3Source, stringPtr("3scale-dr"), testRestoreNamespace,
+			"Namespace '3scale-dr' cannot be restored into: it is not watched by the operator"},
+		{"PVC restore source", pvcSource, stringPtr("3scale-dr"), "",
+			"Namespace '3scale-dr' cannot be restored into from the persistentVolumeClaim restore source"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			cr := &appsv1alpha1.APIManagerRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: testRestoreName, Namespace: testRestoreNamespace},
+				Spec: appsv1alpha1.APIManagerRestoreSpec{
+					RestoreSource: tc.source,
+					Overrides:     &appsv1alpha1.APIManagerRestoreOverrides{Namespace: tc.namespace},
+				},
+			}
+			err := ValidateTargetNamespace(cr, tc.watchNamespace)
+			if tc.expectedFailure == "" && err != nil {
+				subT.Errorf("unexpected error: %s", err)
+			}
+			if tc.expectedFailure != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedFailure)) {
+				subT.Errorf("expected failure '%s', got %v", tc.expectedFailure, err)
+			}
+		})
+	}
+}
+
+func TestRestoreRedisFromPVCJob(t *testing.T) {
+	apiManagerRestore := NewAPIManagerRestore(testRestoreOptions())
+
+	restoreInfo := testRestoreInfo()
+	restoreInfo.InternalRedisDatabases = false
+	if job := apiManagerRestore.RestoreRedisFromPVCJob(restoreInfo); job != nil {
+		t.Errorf("job '%s' created for external Redis databases", job.Name)
+	}
+
+	job := apiManagerRestore.RestoreRedisFromPVCJob(testRestoreInfo())
+	if job == nil {
+		t.Fatal("job not created for internal Redis databases")
+	}
+	if *job.Spec.Completions != 1 || *job.Spec.BackoffLimit != 3 {
+		t.Errorf("unexpected completions %d and backoff limit %d", *job.Spec.Completions, *job.Spec.BackoffLimit)
+	}
+
+	// The backup data is downloaded and decrypted before it is copied
+	podSpec := job.Spec.Template.Spec
+	initContainerNames := []string{}
+	for _, container := range podSpec.InitContainers {
+		initContainerNames = append(initContainerNames, container.Name)
+	}
+	if !reflect.DeepEqual(initContainerNames, []string{"s3-download", "backup-decrypt"}) {
+		t.Errorf("unexpected init containers: %v", initContainerNames)
+	}
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("unexpected containers: %v", podSpec.Containers)
+	}
+
+	// The append only files are written into the Redis claims
+	for _, pvcName := range []string{"backend-redis-storage", "system-redis-storage"} {
+		expectedVolume := v1.Volume{
+			Name: pvcName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+			},
+		}
+		found := false
+		for _, volume := range podSpec.Volumes {
+			found = found || reflect.DeepEqual(volume, expectedVolume)
+		}
+		if !found {
+			t.Errorf("claim '%s' not mounted: %v", pvcName, podSpec.Volumes)
+		}
+	}
+
+	container := jobContainer(t, job, "restore-redis")
+	if container.Image != "quay.io/openshift/origin-cli:4.7" {
+		t.Errorf("unexpected image: %s", container.Image)
+	}
+	expectedVolumeMounts := []v1.VolumeMount{
+		{Name: "restore-plaintext-data", MountPath: "/backup"},
+		{Name: "backend-redis-storage", MountPath: "/backend-redis-storage"},
+		{Name: "system-redis-storage", MountPath: "/system-redis-storage"},
+	}
+	if !reflect.DeepEqual(container.VolumeMounts, expectedVolumeMounts) {
+		t.Errorf("unexpected volume mounts: %v", container.VolumeMounts)
+	}
+	if len(container.Args) != 3 || container.Args[0] != "-c" || container.Args[1] != "-e" {
+		t.Fatalf("unexpected args: %v", container.Args)
+	}
+	for _, line := range []string{
+		"BASEPATH='/backup';",
+		`REDIS_SUBDIR="${BASEPATH}/redis";`,
+		"REDIS_AOF_FILENAME='appendonly.aof';",
+		"cp ${REDIS_SUBDIR}/backend-redis.aof /backend-redis-storage/${REDIS_AOF_FILENAME};",
+		"cp ${REDIS_SUBDIR}/system-redis.aof /system-redis-storage/${REDIS_AOF_FILENAME};",
+	} {
+		if !strings.Contains(container.Args[2], line+"\n") {
+			t.Errorf("restore redis script does not contain %q:\n%s", line, container.Args[2])
+		}
+	}
+}
+
+func TestRestoreSystemDatabaseFromPVCJob(t *testing.T) {
+	apiManagerRestore := NewAPIManagerRestore(testRestoreOptions())
+
+	cases := []struct {
+		name          string
+		databaseType  component.SystemDatabaseType
+		imageURL      string
+		expectedLines []string
+	}{
+		{"MySQL", component.SystemDatabaseTypeInternalMySQL, "centos/mysql-57-centos7", []string{
+			"set -o pipefail;",
+			`DUMP_FILE="${BASEPATH}/system-database/system-mysql.sql.gz";`,
+			`gunzip -c ${DUMP_FILE} | mysql -h "${DB_HOST}" -u "${DB_USER}" "${DB_NAME}";`,
+		}},
+		{"PostgreSQL", component.SystemDatabaseTypeInternalPostgreSQL, "centos/postgresql-10-centos7", []string{
+			`DUMP_FILE="${BASEPATH}/system-database/system-postgresql.dump";`,
+			`pg_restore --clean --if-exists --no-owner --no-privileges --dbname="${DATABASE_URL}" ${DUMP_FILE};`,
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			restoreInfo := testRestoreInfo()
+			restoreInfo.SystemDatabaseType = tc.databaseType
+			restoreInfo.SystemDatabaseImageURL = tc.imageURL
+			job := apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo)
+			if job == nil {
+				subT.Fatal("job not created for the internal system database")
+			}
+			if job.Namespace != testRestoreNamespace || *job.Spec.Completions != 1 {
+				subT.Errorf("unexpected namespace '%s' and completions %d", job.Namespace, *job.Spec.Completions)
+			}
+
+			// The dump is downloaded and decrypted before it is loaded
+			initContainerNames := []string{}
+			for _, container := range job.Spec.Template.Spec.InitContainers {
+				initContainerNames = append(initContainerNames, container.Name)
+			}
+			if !reflect.DeepEqual(initContainerNames, []string{"s3-download", "backup-decrypt"}) {
+				subT.Errorf("unexpected init containers: %v", initContainerNames)
+			}
+
+			container := jobContainer(subT, job, "restore-system-database")
+			// The database client of the deployed database image loads the dump
+			if container.Image != tc.imageURL {
+				subT.Errorf("unexpected image: %s", container.Image)
+			}
+			expectedEnv := []v1.EnvVar{{Name: "DATABASE_URL", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "system-database"}, Key: "URL",
+			}}}}
+			if !reflect.DeepEqual(container.Env, expectedEnv) {
+				subT.Errorf("unexpected env vars: %v", container.Env)
+			}
+			if !reflect.DeepEqual(container.VolumeMounts, []v1.VolumeMount{{Name: "restore-plaintext-data", MountPath: "/backup"}}) {
+				subT.Errorf("unexpected volume mounts: %v", container.VolumeMounts)
+			}
+			if len(container.Args) != 3 || container.Args[0] != "-c" || container.Args[1] != "-e" {
+				subT.Fatalf("unexpected args: %v", container.Args)
+			}
+			for _, line := range append([]string{"BASEPATH='/backup';"}, tc.expectedLines...) {
+				if !strings.Contains(container.Args[2], line+"\n") {
+					subT.Errorf("restore system database script does not contain %q:\n%s", line, container.Args[2])
+				}
+			}
+		})
+	}
+
+	restoreInfo := testRestoreInfo()
+	restoreInfo.SystemDatabaseType = component.SystemDatabaseTypeExternal
+	if job := apiManagerRestore.RestoreSystemDatabaseFromPVCJob(restoreInfo); job != nil {
+		t.Errorf("job '%s' created for the external system database", job.Name)
+	}
+}
+
+// The MySQL client is given the host, user, password and database of the
+// system database URL
+func TestRestoreSystemMySQLDatabaseURL(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not found")
+	}
+
+	script := NewAPIManagerRestore(testRestoreOptions()).restoreSystemMySQLContainerArgs()
+	// The dump is not loaded, the connection parameters are printed instead
+	script = strings.Replace(script, `gunzip -c ${DUMP_FILE} | mysql -h "${DB_HOST}" -u "${DB_USER}" "${DB_NAME}";`,
+		`echo "${DB_HOST} ${DB_USER} ${MYSQL_PWD} ${DB_NAME}";`, 1)
+
+	cmd := exec.Command("bash", "-c", "-e", script)
+	cmd.Env = []string{"DATABASE_URL=mysql2://app:p@ss:w0rd@system-mysql:3306/system"}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+	if strings.TrimSpace(string(out)) != "system-mysql:3306 app p@ss:w0rd system" {
+		t.Errorf("unexpected connection parameters: %s", out)
+	}
+}
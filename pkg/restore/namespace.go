@@ -0,0 +1,59 @@
+package restore
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// SourceSecretCopyLabel is set on the copies of the restore source secrets
+// made in the namespace the APIManager is restored into. Its value is the
+// name of the APIManagerRestore
+const SourceSecretCopyLabel = "apps.3scale.net/apimanagerrestore"
+
+// SourceSecretCopyName returns the name of the copy of the given restore
+// source secret in the namespace the APIManager is restored into. The Jobs
+// restoring the storage of the APIManager run in that namespace and read
+// the copies
+func SourceSecretCopyName(restoreName, secretName string) string {
+	return fmt.Sprintf("%s-%s", restoreName, secretName)
+}
+
+// SourceSecretNames returns the secrets of the restore source read by the
+// restore Jobs: the S3 credentials and the encryption key
+func SourceSecretNames(cr *appsv1alpha1.APIManagerRestore) []string {
+	names := []string{}
+	if s3Spec := cr.Spec.RestoreSource.S3; s3Spec != nil {
+		names = append(names, s3Spec.CredentialsSecretRef.Name)
+	}
+	if cr.Spec.EncryptionKeySecretRef != nil {
+		names = append(names, cr.Spec.EncryptionKeySecretRef.Name)
+	}
+	return names
+}
+
+// ValidateTargetNamespace verifies the APIManager can be restored into the
+// overridden namespace. The restore source has to be reachable from it, and
+// the operator has to watch it. An empty watch namespace means the operator
+// watches all the namespaces
+func ValidateTargetNamespace(cr *appsv1alpha1.APIManagerRestore, watchNamespace string) error {
+	if !cr.NamespaceOverridden() {
+		return nil
+	}
+
+	targetNamespace := cr.TargetNamespace()
+	if cr.Spec.RestoreSource.PersistentVolumeClaim != nil {
+		return fmt.Errorf("Namespace '%s' cannot be restored into from the persistentVolumeClaim restore source: claims cannot be mounted from other namespaces", targetNamespace)
+	}
+
+	if watchNamespace == "" {
+		return nil
+	}
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		if namespace == targetNamespace {
+			return nil
+		}
+	}
+	return fmt.Errorf("Namespace '%s' cannot be restored into: it is not watched by the operator", targetNamespace)
+}
@@ -0,0 +1,139 @@
+package restore
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// ApplyAPIManagerOverrides replaces the attributes of the backed up
+// APIManager set in the restore overrides. The storage class is set in
+// all the PersistentVolumeClaims deployed by the APIManager
+func ApplyAPIManagerOverrides(apimanager *appsv1alpha1.APIManager, overrides *appsv1alpha1.APIManagerRestoreOverrides) {
+	if overrides == nil {
+		return
+	}
+
+	if overrides.WildcardDomain != nil {
+		apimanager.Spec.WildcardDomain = *overrides.WildcardDomain
+	}
+
+	if overrides.StorageClassName != nil {
+		applyStorageClassOverride(apimanager, overrides.StorageClassName)
+	}
+
+	if overrides.Images != nil {
+		applyImagesOverrides(apimanager, overrides.Images)
+	}
+
+	if overrides.ImageRegistry != nil {
+		apimanager.Spec.ImageRegistry = overrides.ImageRegistry.DeepCopy()
+	}
+}
+
+func applyStorageClassOverride(apimanager *appsv1alpha1.APIManager, storageClassName *string) {
+	spec := &apimanager.Spec
+	if spec.Backend == nil {
+		spec.Backend = &appsv1alpha1.BackendSpec{}
+	}
+	if spec.System == nil {
+		spec.System = &appsv1alpha1.SystemSpec{}
+	}
+	systemSpec := spec.System
+
+	// The deprecated S3 field is ignored and system storage still uses the PVC
+	fileStorageSpec := systemSpec.FileStorageSpec
+	if fileStorageSpec == nil || fileStorageSpec.S3 == nil {
+		if systemSpec.FileStorageSpec == nil {
+			systemSpec.FileStorageSpec = &appsv1alpha1.SystemFileStorageSpec{}
+		}
+		if systemSpec.FileStorageSpec.PVC == nil {
+			systemSpec.FileStorageSpec.PVC = &appsv1alpha1.SystemPVCSpec{}
+		}
+		systemSpec.FileStorageSpec.PVC.StorageClassName = copyString(storageClassName)
+	}
+
+	// Redis and system database are external in high availability mode
+	if apimanager.IsExternalDatabaseEnabled() {
+		return
+	}
+
+	if spec.Backend.RedisPersistentVolumeClaimSpec == nil {
+		spec.Backend.RedisPersistentVolumeClaimSpec = &appsv1alpha1.BackendRedisPersistentVolumeClaimSpec{}
+	}
+	spec.Backend.RedisPersistentVolumeClaimSpec.StorageClassName = copyString(storageClassName)
+
+	if systemSpec.RedisPersistentVolumeClaimSpec == nil {
+		systemSpec.RedisPersistentVolumeClaimSpec = &appsv1alpha1.SystemRedisPersistentVolumeClaimSpec{}
+	}
+	systemSpec.RedisPersistentVolumeClaimSpec.StorageClassName = copyString(storageClassName)
+
+	if apimanager.IsSystemPostgreSQLEnabled() {
+		postgreSQLSpec := systemSpec.DatabaseSpec.PostgreSQL
+		if postgreSQLSpec.PersistentVolumeClaimSpec == nil {
+			postgreSQLSpec.PersistentVolumeClaimSpec = &appsv1alpha1.SystemPostgreSQLPVCSpec{}
+		}
+		postgreSQLSpec.PersistentVolumeClaimSpec.StorageClassName = copyString(storageClassName)
+		return
+	}
+
+	mysqlSpec := systemMySQLSpec(systemSpec)
+	if mysqlSpec.PersistentVolumeClaimSpec == nil {
+		mysqlSpec.PersistentVolumeClaimSpec = &appsv1alpha1.SystemMySQLPVCSpec{}
+	}
+	mysqlSpec.PersistentVolumeClaimSpec.StorageClassName = copyString(storageClassName)
+}
+
+func applyImagesOverrides(apimanager *appsv1alpha1.APIManager, images *appsv1alpha1.APIManagerRestoreImagesOverrides) {
+	spec := &apimanager.Spec
+	if spec.Apicast == nil {
+		spec.Apicast = &appsv1alpha1.ApicastSpec{}
+	}
+	if spec.Backend == nil {
+		spec.Backend = &appsv1alpha1.BackendSpec{}
+	}
+	if spec.System == nil {
+		spec.System = &appsv1alpha1.SystemSpec{}
+	}
+	if spec.Zync == nil {
+		spec.Zync = &appsv1alpha1.ZyncSpec{}
+	}
+
+	overrideImage(&spec.Apicast.Image, images.Apicast)
+	overrideImage(&spec.Backend.Image, images.Backend)
+	overrideImage(&spec.Backend.RedisImage, images.BackendRedis)
+	overrideImage(&spec.System.Image, images.System)
+	overrideImage(&spec.System.MemcachedImage, images.SystemMemcached)
+	overrideImage(&spec.System.RedisImage, images.SystemRedis)
+	overrideImage(&spec.Zync.Image, images.Zync)
+	overrideImage(&spec.Zync.PostgreSQLImage, images.ZyncPostgreSQL)
+
+	if apimanager.IsExternalDatabaseEnabled() {
+		return
+	}
+	if apimanager.IsSystemPostgreSQLEnabled() {
+		overrideImage(&spec.System.DatabaseSpec.PostgreSQL.Image, images.SystemPostgreSQL)
+	} else if images.SystemMySQL != nil {
+		overrideImage(&systemMySQLSpec(spec.System).Image, images.SystemMySQL)
+	}
+}
+
+// MySQL is the default internal system database
+func systemMySQLSpec(systemSpec *appsv1alpha1.SystemSpec) *appsv1alpha1.SystemMySQLSpec {
+	if systemSpec.DatabaseSpec == nil {
+		systemSpec.DatabaseSpec = &appsv1alpha1.SystemDatabaseSpec{}
+	}
+	if systemSpec.DatabaseSpec.MySQL == nil {
+		systemSpec.DatabaseSpec.MySQL = &appsv1alpha1.SystemMySQLSpec{}
+	}
+	return systemSpec.DatabaseSpec.MySQL
+}
+
+func overrideImage(image **string, override *string) {
+	if override != nil {
+		*image = copyString(override)
+	}
+}
+
+func copyString(value *string) *string {
+	res := *value
+	return &res
+}
@@ -0,0 +1,135 @@
+package restore
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestApplyAPIManagerOverrides(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Spec.WildcardDomain = "source.example.com"
+	apimanager.Spec.System = &appsv1alpha1.SystemSpec{
+		Image: stringPtr("system:source"),
+	}
+
+	overrides := &appsv1alpha1.APIManagerRestoreOverrides{
+		WildcardDomain:   stringPtr("target.example.com"),
+		StorageClassName: stringPtr("fast"),
+		Images: &appsv1alpha1.APIManagerRestoreImagesOverrides{
+			System:      stringPtr("mirror.example.com/system:target"),
+			SystemMySQL: stringPtr("mirror.example.com/mysql:target"),
+			Zync:        stringPtr("mirror.example.com/zync:target"),
+		},
+		ImageRegistry: &appsv1alpha1.ImageRegistrySpec{Override: stringPtr("mirror.example.com")},
+	}
+
+	ApplyAPIManagerOverrides(apimanager, overrides)
+
+	if apimanager.Spec.WildcardDomain != "target.example.com" {
+		t.Errorf("unexpected wildcard domain: %s", apimanager.Spec.WildcardDomain)
+	}
+
+	storageClasses := map[string]*string{
+		"backend-redis":  apimanager.Spec.Backend.RedisPersistentVolumeClaimSpec.StorageClassName,
+		"system-redis":   apimanager.Spec.System.RedisPersistentVolumeClaimSpec.StorageClassName,
+		"system-storage": apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName,
+		"system-mysql":   apimanager.Spec.System.DatabaseSpec.MySQL.PersistentVolumeClaimSpec.StorageClassName,
+	}
+	for pvc, storageClass := range storageClasses {
+		if storageClass == nil || *storageClass != "fast" {
+			t.Errorf("unexpected storage class of PVC '%s': %v", pvc, storageClass)
+		}
+	}
+
+	images := []struct {
+		component string
+		image     *string
+		expected  string
+	}{
+		{"system", apimanager.Spec.System.Image, "mirror.example.com/system:target"},
+		{"system-mysql", apimanager.Spec.System.DatabaseSpec.MySQL.Image, "mirror.example.com/mysql:target"},
+		{"zync", apimanager.Spec.Zync.Image, "mirror.example.com/zync:target"},
+	}
+	for _, tc := range images {
+		if tc.image == nil || *tc.image != tc.expected {
+			t.Errorf("unexpected image of '%s': %v", tc.component, tc.image)
+		}
+	}
+	if apimanager.Spec.Apicast.Image != nil {
+		t.Errorf("image not overridden has been changed: %s", *apimanager.Spec.Apicast.Image)
+	}
+	if imageRegistry := apimanager.Spec.ImageRegistry; imageRegistry == nil || *imageRegistry.Override != "mirror.example.com" {
+		t.Errorf("unexpected image registry: %v", imageRegistry)
+	}
+
+	// Overrides are copied
+	*overrides.StorageClassName = "slow"
+	if *apimanager.Spec.System.FileStorageSpec.PVC.StorageClassName != "fast" {
+		t.Error("storage class override is not copied")
+	}
+}
+
+func TestApplyAPIManagerOverridesExternalDatabases(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Spec.HighAvailability = &appsv1alpha1.HighAvailabilitySpec{Enabled: true}
+	apimanager.Spec.System = &appsv1alpha1.SystemSpec{
+		FileStorageSpec: &appsv1alpha1.SystemFileStorageSpec{
+			S3: &appsv1alpha1.SystemS3Spec{},
+		},
+	}
+
+	ApplyAPIManagerOverrides(apimanager, &appsv1alpha1.APIManagerRestoreOverrides{
+		StorageClassName: stringPtr("fast"),
+		Images: &appsv1alpha1.APIManagerRestoreImagesOverrides{
+			SystemMySQL: stringPtr("mirror.example.com/mysql:target"),
+		},
+	})
+
+	if apimanager.Spec.System.FileStorageSpec.PVC != nil {
+		t.Error("system storage PVC set when S3 file storage is used")
+	}
+	if apimanager.Spec.Backend.RedisPersistentVolumeClaimSpec != nil {
+		t.Error("backend-redis PVC set when redis is external")
+	}
+	if apimanager.Spec.System.DatabaseSpec != nil {
+		t.Error("system database set when it is external")
+	}
+}
+
+func TestApplyAPIManagerOverridesDeprecatedS3(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Spec.System = &appsv1alpha1.SystemSpec{
+		FileStorageSpec: &appsv1alpha1.SystemFileStorageSpec{
+			DeprecatedS3: &appsv1alpha1.DeprecatedSystemS3Spec{},
+		},
+	}
+
+	ApplyAPIManagerOverrides(apimanager, &appsv1alpha1.APIManagerRestoreOverrides{
+		StorageClassName: stringPtr("fast"),
+	})
+
+	// The deprecated S3 field is ignored, system storage uses the PVC
+	pvcSpec := apimanager.Spec.System.FileStorageSpec.PVC
+	if pvcSpec == nil || pvcSpec.StorageClassName == nil || *pvcSpec.StorageClassName != "fast" {
+		t.Errorf("unexpected system storage PVC spec: %v", pvcSpec)
+	}
+}
+
+func TestApplyAPIManagerOverridesNil(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{}
+	apimanager.Spec.WildcardDomain = "source.example.com"
+
+	ApplyAPIManagerOverrides(apimanager, nil)
+
+	if apimanager.Spec.WildcardDomain != "source.example.com" {
+		t.Errorf("unexpected wildcard domain: %s", apimanager.Spec.WildcardDomain)
+	}
+	if apimanager.Spec.System != nil || apimanager.Spec.Backend != nil {
+		t.Error("spec changed without overrides")
+	}
+}
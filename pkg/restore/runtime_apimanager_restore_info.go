@@ -1,5 +1,10 @@
 package restore
 
+import "github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
 type RuntimeAPIManagerRestoreInfo struct {
-	PVCStorageClass *string
+	PVCStorageClass        *string
+	SystemDatabaseType     component.SystemDatabaseType
+	SystemDatabaseImageURL string
+	InternalRedisDatabases bool
 }
@@ -28,6 +28,19 @@ const (
 	systemPostgreSQLPVCResourceRequestsPath  = "/spec/system/database/postgresql/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath         = "/spec/policies/configuration"
 	policyConfigurationPath                  = "/spec/schema/configuration"
+	lastRotationTimePath                     = "/status/databaseCredentialsRotation/lastRotationTime"
+	rotationIntervalPath                     = "/spec/databaseCredentialsRotation/interval"
+	backupTemplatePVCResourceRequestsPath    = "/spec/backupTemplate/backupDestination/persistentVolumeClaim/resources/requests"
+	lastScheduleTimePath                     = "/status/lastScheduleTime"
+	nextScheduleTimePath                     = "/status/nextScheduleTime"
+	upgradeStartTimePath                     = "/status/upgrade/startTime"
+	upgradeCompletionTimePath                = "/status/upgrade/completionTime"
+	upgradeAvailabilityDeadlinePath          = "/status/upgrade/availabilityDeadline"
+	upgradePolicyAvailabilityTimeoutPath     = "/spec/upgradePolicy/availabilityTimeout"
+	upgradePolicyBackupPVCRequestsPath       = "/spec/upgradePolicy/backupTemplate/backupDestination/persistentVolumeClaim/resources/requests"
+	apicastCanaryAnalysisDurationPath        = "/spec/apicast/productionSpec/canary/analysisDuration"
+	apicastCanaryStartTimePath               = "/status/apicastCanary/startTime"
+	apicastCanaryCompletionTimePath          = "/status/apicastCanary/completionTime"
 )
 
 type testCRInfo struct {
@@ -55,6 +68,10 @@ func TestSampleCustomResources(t *testing.T) {
 			crPrefix:   "apps_v1alpha1_apimanagerrestore.yaml",
 			apiVersion: apps.GroupVersion.Version,
 		},
+		"apps.3scale.net_apimanagerbackupschedules.yaml": testCRInfo{
+			crPrefix:   "apps_v1alpha1_apimanagerbackupschedule.yaml",
+			apiVersion: apps.GroupVersion.Version,
+		},
 		"capabilities.3scale.net_tenants.yaml": testCRInfo{
 			crPrefix:   "capabilities_v1alpha1_tenant",
 			apiVersion: capabilitiesv1alpha1.GroupVersion.Version,
@@ -136,6 +153,10 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &apps.APIManagerRestore{},
 			apiVersion: apps.GroupVersion.Version,
 		},
+		"apps.3scale.net_apimanagerbackupschedules.yaml": testCRDInfo{
+			obj:        &apps.APIManagerBackupSchedule{},
+			apiVersion: apps.GroupVersion.Version,
+		},
 		"capabilities.3scale.net_tenants.yaml": testCRDInfo{
 			obj:        &capabilitiesv1alpha1.Tenant{},
 			apiVersion: capabilitiesv1alpha1.GroupVersion.Version,
@@ -180,6 +201,19 @@ func TestCompleteCRD(t *testing.T) {
 		systemPostgreSQLPVCResourceRequestsPath,
 		productPoliciesConfigurationPath,
 		policyConfigurationPath,
+		lastRotationTimePath,
+		rotationIntervalPath,
+		backupTemplatePVCResourceRequestsPath,
+		lastScheduleTimePath,
+		nextScheduleTimePath,
+		upgradeStartTimePath,
+		upgradeCompletionTimePath,
+		upgradeAvailabilityDeadlinePath,
+		upgradePolicyAvailabilityTimeoutPath,
+		upgradePolicyBackupPVCRequestsPath,
+		apicastCanaryAnalysisDurationPath,
+		apicastCanaryStartTimePath,
+		apicastCanaryCompletionTimePath,
 	}
 
 	for crd, elem := range crdStructMap {